@@ -0,0 +1,100 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/winartodev/cat-cafe/pkg/jwk"
+)
+
+// fakeidp is a local OpenID Connect provider for development and testing.
+//
+// POST /token exchanges an authorization code for an ID token, the code is
+// treated as the player email. GET /.well-known/jwks.json serves the signing key
+const keyID = "fake-idp-key"
+
+func main() {
+	addr := flag.String("addr", ":9999", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9999", "issuer written into ID tokens")
+	flag.Parse()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("Could not generate signing key: %v", err)
+	}
+
+	publicJWK, err := jwk.FromPublicKey(&privateKey.PublicKey, keyID, jwt.SigningMethodRS256.Alg())
+	if err != nil {
+		log.Fatalf("Could not build jwks: %v", err)
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, jwk.Set{Keys: []jwk.Key{*publicJWK}})
+	})
+
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+			return
+		}
+
+		code := r.PostForm.Get("code")
+		if r.PostForm.Get("grant_type") != "authorization_code" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+			return
+		}
+
+		if _, err := mail.ParseAddress(code); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		now := time.Now()
+		claims := jwt.MapClaims{
+			"iss":            *issuer,
+			"sub":            "fake|" + code,
+			"aud":            r.PostForm.Get("client_id"),
+			"iat":            now.Unix(),
+			"exp":            now.Add(5 * time.Minute).Unix(),
+			"email":          code,
+			"email_verified": true,
+			"name":           code,
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = keyID
+
+		idToken, err := token.SignedString(privateKey)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{
+			"id_token":     idToken,
+			"access_token": idToken,
+			"token_type":   "Bearer",
+			"expires_in":   300,
+		})
+	})
+
+	log.Printf("Fake identity provider listening on %s", *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		log.Fatalf("Fake identity provider stopped: %v", err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
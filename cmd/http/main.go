@@ -11,7 +11,6 @@ import (
 	"github.com/winartodev/cat-cafe/internal/repositories"
 	"github.com/winartodev/cat-cafe/internal/usecase"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
-	"github.com/winartodev/cat-cafe/pkg/jwt"
 	"log"
 	"os"
 	"os/signal"
@@ -42,13 +41,27 @@ func main() {
 	app.Use(logger.New())
 	app.Use(cors.New())
 
-	jwtManager := jwt.NewJWT(cfg.JWT.SecretKey, cfg.JWT.TokenDuration)
+	jwtManager, err := cfg.JWT.SetupJWT()
+	if err != nil {
+		log.Fatalf("Could setup jwt: %v", err)
+	}
 
 	repo := repositories.SetupRepository(db, redisClient)
 
-	uc := usecase.SetUpUseCase(*repo, jwtManager)
+	identityProvider, err := cfg.Identity.SetupProvider()
+	if err != nil {
+		log.Fatalf("Could setup identity provider: %v", err)
+	}
+
+	dailyRewardSettings, err := cfg.DailyReward.SetupSettings()
+	if err != nil {
+		log.Fatalf("Could setup daily reward: %v", err)
+	}
+
+	// Payouts are only queued by the api, cmd/payout sends them to the provider
+	uc := usecase.SetUpUseCase(*repo, jwtManager, identityProvider, nil, dailyRewardSettings)
 
-	middleware_ := middleware.NewMiddleware(jwtManager, repo.UserRepository)
+	middleware_ := middleware.NewMiddleware(jwtManager, repo.UserRepository, repo.AdminRepository, repo.IdempotencyRepository, repo.SessionRepository, repo.ModerationRepository)
 	handlers.SetupHandler(app, *uc, middleware_)
 
 	go func() {
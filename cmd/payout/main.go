@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/config"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/internal/usecase"
+)
+
+const defaultPollInterval = 10 * time.Second
+
+// payout sends the queued external rewards, e.g. GOPAY_COIN, to the configured payout provider.
+// It keeps polling until it is stopped, or runs a single pass with -once, e.g. from cron
+//
+//	go run ./cmd/payout
+//	go run ./cmd/payout -once
+func main() {
+	once := flag.Bool("once", false, "process the due payouts once and exit")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Could not load config: %v", err)
+	}
+
+	db, err := cfg.Database.SetupConnection()
+	if err != nil {
+		log.Fatalf("Could setup database: %v", err)
+	}
+	defer db.Close()
+
+	provider, err := cfg.Payout.SetupProvider()
+	if err != nil {
+		log.Fatalf("Could setup payout provider: %v", err)
+	}
+
+	payoutUC := usecase.NewPayoutUseCase(repositories.NewPayoutRepository(db), provider)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *once {
+		processDuePayouts(ctx, payoutUC, cfg.Payout.BatchSize)
+		return
+	}
+
+	pollInterval := time.Duration(cfg.Payout.PollInterval) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	log.Printf("Payout worker polling every %s", pollInterval)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		processDuePayouts(ctx, payoutUC, cfg.Payout.BatchSize)
+
+		select {
+		case <-ctx.Done():
+			log.Println("Payout worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processDuePayouts keeps claiming batches until the queue has nothing due anymore
+func processDuePayouts(ctx context.Context, payoutUC usecase.PayoutUseCase, batchSize int) {
+	for ctx.Err() == nil {
+		res, err := payoutUC.ProcessDuePayouts(ctx, batchSize)
+		if err != nil {
+			log.Printf("Processing payouts failed: %v", err)
+			return
+		}
+
+		if res.Claimed == 0 {
+			return
+		}
+
+		log.Printf("Payouts processed: %d claimed, %d succeeded, %d retrying, %d dead", res.Claimed, res.Succeeded, res.Retrying, res.Dead)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/winartodev/cat-cafe/internal/config"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/internal/usecase"
+)
+
+// reconcile compares every user balance with the sum of their currency ledger
+// and flags the mismatches. It is meant to run periodically, e.g. from cron
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Could not load config: %v", err)
+	}
+
+	db, err := cfg.Database.SetupConnection()
+	if err != nil {
+		log.Fatalf("Could setup database: %v", err)
+	}
+	defer db.Close()
+
+	// Reconciliation only reads balances and the ledger from the database, so no cache or auth is needed
+	currencyLedgerUC := usecase.NewCurrencyLedgerUseCase(
+		repositories.NewCurrencyLedgerRepository(db),
+		repositories.NewUserRepository(db, nil),
+	)
+
+	res, err := currencyLedgerUC.ReconcileBalances(context.Background())
+	if err != nil {
+		log.Fatalf("Reconciliation failed: %v", err)
+	}
+
+	log.Printf("Reconciliation finished at %s: %d flagged, %d resolved", res.RunAt.Format("2006-01-02 15:04:05"), res.Flagged, res.Resolved)
+}
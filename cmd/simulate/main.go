@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/config"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/internal/usecase"
+	"gopkg.in/yaml.v3"
+)
+
+// simulate plays a stage with a simulated player so game designers can see how a
+// kitchen config feels before shipping it. The stage is loaded from the database
+// with -stage, or from a JSON/YAML file with -config
+//
+//	go run ./cmd/simulate -stage downtown -strategy all -format csv -out downtown.csv
+//	go run ./cmd/simulate -config draft.yaml -strategy best_roi
+const strategyAll = "all"
+
+func main() {
+	stageSlug := flag.String("stage", "", "slug of the stage to load from the database")
+	configPath := flag.String("config", "", "JSON or YAML file with the stage economy, used instead of the database")
+	strategy := flag.String("strategy", entities.SimulationStrategyCheapestFirst.String(), "cheapest_first, best_roi or all")
+	maxDuration := flag.Duration("max-duration", 30*24*time.Hour, "simulated play time after which a run gives up")
+	customerLimited := flag.Bool("customer-limited", false, "cap the earnings by the customer spawn rate")
+	format := flag.String("format", "json", "json for the full report, csv for the coin curve")
+	outPath := flag.String("out", "", "output file, stdout when empty")
+	flag.Parse()
+
+	if (*stageSlug == "") == (*configPath == "") {
+		log.Fatal("Exactly one of -stage or -config is required")
+	}
+
+	strategies, err := parseStrategies(*strategy)
+	if err != nil {
+		log.Fatalf("Invalid strategy: %v", err)
+	}
+
+	var simulator usecase.EconomySimulatorUseCase
+	var stageConfig *entities.EconomySimulationConfig
+	if *configPath != "" {
+		// Simulating a file never touches the database
+		simulator = usecase.NewEconomySimulatorUseCase(nil, nil, nil, nil)
+
+		stageConfig, err = readConfigFile(*configPath)
+		if err != nil {
+			log.Fatalf("Could not read config: %v", err)
+		}
+	} else {
+		simulator, stageConfig, err = loadFromDatabase(*stageSlug)
+		if err != nil {
+			log.Fatalf("Could not load stage: %v", err)
+		}
+	}
+
+	var results []*entities.EconomySimulationResult
+	for _, s := range strategies {
+		res, err := simulator.Simulate(stageConfig, entities.EconomySimulationParams{
+			Strategy:        s,
+			MaxDuration:     *maxDuration,
+			CustomerLimited: *customerLimited,
+		})
+		if err != nil {
+			log.Fatalf("Simulation with %s failed: %v", s, err)
+		}
+
+		results = append(results, res)
+	}
+
+	out := io.Writer(os.Stdout)
+	if *outPath != "" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			log.Fatalf("Could not create output: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch *format {
+	case "json":
+		err = writeJSON(out, results)
+	case "csv":
+		err = writeCSV(out, results)
+	default:
+		log.Fatalf("Unknown format %q, expected json or csv", *format)
+	}
+	if err != nil {
+		log.Fatalf("Could not write output: %v", err)
+	}
+}
+
+func parseStrategies(s string) ([]entities.SimulationStrategy, error) {
+	if s == strategyAll {
+		return entities.AllSimulationStrategy(), nil
+	}
+
+	var strategies []entities.SimulationStrategy
+	for _, name := range strings.Split(s, ",") {
+		strategy, err := entities.ParseSimulationStrategy(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+
+		strategies = append(strategies, strategy)
+	}
+
+	return strategies, nil
+}
+
+func loadFromDatabase(slug string) (usecase.EconomySimulatorUseCase, *entities.EconomySimulationConfig, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := cfg.Database.SetupConnection()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+
+	// Only database reads are needed, so redis is left out
+	simulator := usecase.NewEconomySimulatorUseCase(
+		repositories.NewGameStageRepository(db),
+		repositories.NewStageKitchenConfigRepository(db),
+		repositories.NewRewardRepository(db, nil),
+		repositories.NewFoodItemRepository(db),
+	)
+
+	stageConfig, err := simulator.LoadStageConfig(context.Background(), slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return simulator, stageConfig, nil
+}
+
+// readConfigFile accepts the same field names in JSON and YAML, YAML is converted to JSON
+// first so the json tags of the entities are the only naming to keep in mind
+func readConfigFile(path string) (*entities.EconomySimulationConfig, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var raw map[string]interface{}
+		if err := yaml.Unmarshal(buf, &raw); err != nil {
+			return nil, fmt.Errorf("unmarshal yaml: %w", err)
+		}
+
+		buf, err = json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var stageConfig entities.EconomySimulationConfig
+	if err := json.Unmarshal(buf, &stageConfig); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+
+	return &stageConfig, nil
+}
+
+func writeJSON(out io.Writer, results []*entities.EconomySimulationResult) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
+
+// writeCSV writes the coin curve, one row per event of every simulated strategy
+func writeCSV(out io.Writer, results []*entities.EconomySimulationResult) error {
+	writer := csv.NewWriter(out)
+
+	header := []string{"strategy", "time_seconds", "type", "station", "level", "phase", "amount", "coins", "coins_per_second"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, res := range results {
+		for _, event := range res.Events {
+			err := writer.Write([]string{
+				res.Strategy,
+				strconv.FormatFloat(event.TimeSeconds, 'f', 2, 64),
+				string(event.Type),
+				event.Station,
+				strconv.FormatInt(event.Level, 10),
+				strconv.FormatInt(event.Phase, 10),
+				strconv.FormatInt(event.Amount, 10),
+				strconv.FormatInt(event.Coins, 10),
+				strconv.FormatFloat(event.CoinsPerSecond, 'f', 4, 64),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
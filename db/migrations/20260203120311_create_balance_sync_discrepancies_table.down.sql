@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS balance_sync_discrepancies;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS balance_sync_discrepancies (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    stage_id BIGINT REFERENCES game_stages(id) ON DELETE SET NULL,
+    coins_claimed BIGINT NOT NULL,
+    coins_allowed BIGINT NOT NULL,
+    coins_per_second DOUBLE PRECISION NOT NULL,
+    elapsed_seconds DOUBLE PRECISION NOT NULL,
+    last_sync_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_sync_discrepancies_user_id ON balance_sync_discrepancies(user_id);
+CREATE INDEX IF NOT EXISTS idx_balance_sync_discrepancies_created_at ON balance_sync_discrepancies(created_at);
+
+COMMIT;
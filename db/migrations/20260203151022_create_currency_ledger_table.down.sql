@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS currency_ledger_mismatches;
+DROP TRIGGER IF EXISTS trg_currency_ledger_append_only ON currency_ledger;
+DROP FUNCTION IF EXISTS prevent_currency_ledger_change();
+DROP TABLE IF EXISTS currency_ledger;
+
+COMMIT;
@@ -0,0 +1,51 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS currency_ledger (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE RESTRICT NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    source_type VARCHAR(50) NOT NULL,
+    source_ref VARCHAR(255) DEFAULT '' NOT NULL,
+    delta BIGINT NOT NULL,
+    balance_after BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_currency_ledger_user_created_at ON currency_ledger(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_currency_ledger_user_currency ON currency_ledger(user_id, currency);
+
+-- Ledger entries are append only, they can not be changed or deleted. Users with ledger entries can not
+-- be deleted either, so the history of a balance is never lost
+CREATE OR REPLACE FUNCTION prevent_currency_ledger_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'currency_ledger is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_currency_ledger_append_only
+    BEFORE UPDATE OR DELETE ON currency_ledger
+    FOR EACH ROW EXECUTE FUNCTION prevent_currency_ledger_change();
+
+-- Opening balances so existing users reconcile against the ledger
+INSERT INTO currency_ledger (user_id, currency, source_type, delta, balance_after)
+SELECT id, 'COIN', 'opening_balance', coin, coin FROM users WHERE coin <> 0;
+
+INSERT INTO currency_ledger (user_id, currency, source_type, delta, balance_after)
+SELECT id, 'GEM', 'opening_balance', gem, gem FROM users WHERE gem <> 0;
+
+CREATE TABLE IF NOT EXISTS currency_ledger_mismatches (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    balance BIGINT NOT NULL,
+    ledger_sum BIGINT NOT NULL,
+    first_detected_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    last_detected_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    resolved_at TIMESTAMPTZ DEFAULT NULL,
+    UNIQUE(user_id, currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_currency_ledger_mismatches_resolved_at ON currency_ledger_mismatches(resolved_at);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+UPDATE users SET external_id = 'legacy-' || id WHERE external_id IS NULL;
+
+ALTER TABLE users ALTER COLUMN external_id SET NOT NULL;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS display_name,
+    DROP COLUMN IF EXISTS avatar_url,
+    DROP COLUMN IF EXISTS last_login_at;
+
+COMMIT;
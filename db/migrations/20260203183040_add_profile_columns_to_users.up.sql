@@ -0,0 +1,15 @@
+BEGIN;
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS display_name VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS avatar_url TEXT,
+    ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMPTZ;
+
+ALTER TABLE users ALTER COLUMN email TYPE VARCHAR(255);
+
+-- Accounts created before the identity provider hold a random external id, they are linked on their next login
+ALTER TABLE users ALTER COLUMN external_id DROP NOT NULL;
+
+UPDATE users SET external_id = NULL;
+
+COMMIT;
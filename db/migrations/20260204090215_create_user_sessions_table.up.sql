@@ -0,0 +1,32 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    device_name VARCHAR(100),
+    user_agent TEXT,
+    ip_address VARCHAR(64),
+    last_used_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    revoke_reason VARCHAR(30),
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_id ON user_sessions(user_id);
+
+-- Every refresh token ever issued for a session, a token that is presented again
+-- after it was rotated means it leaked and the whole session is revoked
+CREATE TABLE IF NOT EXISTS user_session_refresh_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    session_id BIGINT REFERENCES user_sessions(id) ON DELETE CASCADE NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_session_refresh_tokens_session_id ON user_session_refresh_tokens(session_id);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_suspensions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    expires_at TIMESTAMPTZ,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    lifted_at TIMESTAMPTZ,
+    lifted_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    CONSTRAINT chk_user_suspensions_type CHECK (type IN ('suspension', 'ban'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_suspensions_active ON user_suspensions(user_id) WHERE lifted_at IS NULL;
+
+COMMIT;
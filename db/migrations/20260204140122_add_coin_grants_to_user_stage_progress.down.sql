@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE user_stage_progress
+    DROP COLUMN IF EXISTS starting_coin_granted_at,
+    DROP COLUMN IF EXISTS prize_granted_at;
+
+COMMIT;
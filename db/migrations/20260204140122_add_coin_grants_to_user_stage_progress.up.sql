@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE user_stage_progress
+    ADD COLUMN IF NOT EXISTS starting_coin_granted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS prize_granted_at TIMESTAMPTZ;
+
+-- Stages started or completed before grants existed must not be paid out retroactively
+UPDATE user_stage_progress SET starting_coin_granted_at = last_started_at WHERE last_started_at IS NOT NULL;
+UPDATE user_stage_progress SET prize_granted_at = completed_at WHERE is_complete = true;
+
+COMMIT;
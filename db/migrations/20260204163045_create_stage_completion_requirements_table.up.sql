@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS stage_completion_requirements (
+    id BIGSERIAL PRIMARY KEY,
+    game_stage_id BIGINT NOT NULL REFERENCES game_stages(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL CHECK (type IN ('all_stations_level', 'kitchen_phase', 'upgrades_purchased')),
+    target BIGINT NOT NULL CHECK (target > 0),
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    UNIQUE(game_stage_id, type)
+);
+
+CREATE INDEX idx_stage_completion_requirements_game_stage ON stage_completion_requirements(game_stage_id);
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_offline_earnings (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    stage_id BIGINT REFERENCES game_stages(id) ON DELETE SET NULL,
+    coins BIGINT NOT NULL,
+    coins_per_second DOUBLE PRECISION NOT NULL,
+    elapsed_seconds DOUBLE PRECISION NOT NULL,
+    is_capped BOOLEAN DEFAULT false NOT NULL,
+    bonus_coins BIGINT DEFAULT 0 NOT NULL,
+    bonus_granted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_offline_earnings_user_id ON user_offline_earnings(user_id);
+
+COMMIT;
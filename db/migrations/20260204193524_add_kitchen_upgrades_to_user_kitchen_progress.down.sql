@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE user_kitchen_progress
+    DROP COLUMN IF EXISTS kitchen_upgrades;
+
+COMMIT;
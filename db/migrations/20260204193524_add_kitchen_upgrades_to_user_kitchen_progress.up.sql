@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Stage-wide upgrade effects, station_upgrades only holds the ones targeting a single food
+ALTER TABLE user_kitchen_progress
+    ADD COLUMN IF NOT EXISTS kitchen_upgrades JSONB DEFAULT '{}'::jsonb NOT NULL;
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS upgrade_prerequisites (
+    id BIGSERIAL PRIMARY KEY,
+    upgrade_id BIGINT NOT NULL REFERENCES upgrades(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL CHECK (type IN ('upgrade', 'station_level', 'kitchen_phase')),
+    required_upgrade_id BIGINT REFERENCES upgrades(id) ON DELETE CASCADE,
+    food_item_id BIGINT REFERENCES food_items(id) ON DELETE CASCADE,
+    target BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    CHECK (required_upgrade_id IS NULL OR required_upgrade_id <> upgrade_id),
+    CHECK (
+        (type = 'upgrade' AND required_upgrade_id IS NOT NULL) OR
+        (type = 'station_level' AND food_item_id IS NOT NULL AND target > 0) OR
+        (type = 'kitchen_phase' AND target > 0)
+    )
+);
+
+CREATE INDEX idx_upgrade_prerequisites_upgrade ON upgrade_prerequisites(upgrade_id);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TABLE IF EXISTS user_boosts;
+DROP TABLE IF EXISTS boosts;
+
+DELETE FROM reward_types WHERE slug = 'BOOST' AND NOT EXISTS (
+    SELECT 1 FROM rewards r WHERE r.reward_type_id = reward_types.id
+);
+
+COMMIT;
@@ -0,0 +1,39 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS boosts (
+    id BIGSERIAL PRIMARY KEY,
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT DEFAULT '' NOT NULL,
+    effect_type VARCHAR(50) NOT NULL CHECK (effect_type IN ('profit', 'instant_cook')),
+    effect_value DOUBLE PRECISION DEFAULT 0 NOT NULL,
+    duration_seconds BIGINT NOT NULL CHECK (duration_seconds > 0),
+    stacking_rule VARCHAR(50) NOT NULL CHECK (stacking_rule IN ('extend', 'refresh', 'stack')),
+    max_stacks BIGINT DEFAULT 1 NOT NULL CHECK (max_stacks > 0),
+    gem_cost BIGINT DEFAULT 0 NOT NULL CHECK (gem_cost >= 0),
+    reward_id BIGINT UNIQUE REFERENCES rewards(id) ON DELETE SET NULL,
+    is_active BOOLEAN DEFAULT TRUE NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_boosts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    boost_id BIGINT NOT NULL REFERENCES boosts(id) ON DELETE CASCADE,
+    source_type VARCHAR(50) NOT NULL,
+    source_ref VARCHAR(255) DEFAULT '' NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    CHECK (ends_at > started_at)
+);
+
+CREATE INDEX idx_user_boosts_user_ends_at ON user_boosts(user_id, ends_at);
+
+INSERT INTO reward_types (slug, name, created_at, updated_at)
+VALUES ('BOOST', 'Boost', NOW(), NOW())
+ON CONFLICT (slug) DO NOTHING;
+
+COMMIT;
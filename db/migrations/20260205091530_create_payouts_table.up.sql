@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS payouts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reward_id BIGINT NOT NULL REFERENCES rewards(id),
+    currency VARCHAR(50) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    source_type VARCHAR(50) NOT NULL,
+    source_ref VARCHAR(255) DEFAULT '' NOT NULL,
+    status VARCHAR(50) DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'succeeded', 'dead')),
+    attempts BIGINT DEFAULT 0 NOT NULL,
+    next_attempt_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    last_error TEXT DEFAULT '' NOT NULL,
+    provider_ref VARCHAR(255) DEFAULT '' NOT NULL,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX idx_payouts_status_next_attempt_at ON payouts(status, next_attempt_at);
+CREATE INDEX idx_payouts_user_id ON payouts(user_id);
+
+COMMIT;
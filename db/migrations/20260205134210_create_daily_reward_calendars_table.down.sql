@@ -0,0 +1,22 @@
+BEGIN;
+
+ALTER TABLE user_daily_reward_progress
+    DROP COLUMN IF EXISTS cycle_claims,
+    DROP COLUMN IF EXISTS calendar_id;
+
+DROP INDEX IF EXISTS idx_daily_rewards_calendar_day_active;
+
+-- Only the days of the default calendar fit the old unique day number
+DELETE FROM daily_rewards
+WHERE calendar_id <> (SELECT id FROM daily_reward_calendars WHERE slug = 'default');
+
+ALTER TABLE daily_rewards
+    DROP CONSTRAINT IF EXISTS daily_rewards_calendar_day_number_key,
+    DROP COLUMN IF EXISTS calendar_id,
+    ADD CONSTRAINT daily_rewards_day_number_key UNIQUE (day_number);
+
+CREATE INDEX IF NOT EXISTS idx_daily_rewards_day_active ON daily_rewards(day_number, is_active);
+
+DROP TABLE IF EXISTS daily_reward_calendars;
+
+COMMIT;
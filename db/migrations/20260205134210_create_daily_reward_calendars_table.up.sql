@@ -0,0 +1,44 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS daily_reward_calendars (
+    id BIGSERIAL PRIMARY KEY,
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL CHECK (type IN ('loop', 'monthly')),
+    starts_at TIMESTAMPTZ UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+-- The calendar that started last is the active one, the existing days become its default loop
+INSERT INTO daily_reward_calendars (slug, name, type, starts_at, created_at, updated_at)
+VALUES ('default', 'Default', 'loop', '2026-01-01 00:00:00+00', NOW(), NOW())
+ON CONFLICT (slug) DO NOTHING;
+
+ALTER TABLE daily_rewards
+    ADD COLUMN IF NOT EXISTS calendar_id BIGINT REFERENCES daily_reward_calendars(id) ON DELETE CASCADE;
+
+UPDATE daily_rewards
+SET calendar_id = (SELECT id FROM daily_reward_calendars WHERE slug = 'default')
+WHERE calendar_id IS NULL;
+
+ALTER TABLE daily_rewards
+    ALTER COLUMN calendar_id SET NOT NULL,
+    DROP CONSTRAINT IF EXISTS daily_rewards_day_number_key,
+    ADD CONSTRAINT daily_rewards_calendar_day_number_key UNIQUE (calendar_id, day_number);
+
+DROP INDEX IF EXISTS idx_daily_rewards_day_active;
+
+CREATE INDEX IF NOT EXISTS idx_daily_rewards_calendar_day_active ON daily_rewards(calendar_id, day_number, is_active);
+
+-- cycle_claims counts the claims in the current cycle of the calendar the user is on
+ALTER TABLE user_daily_reward_progress
+    ADD COLUMN IF NOT EXISTS calendar_id BIGINT REFERENCES daily_reward_calendars(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS cycle_claims INT DEFAULT 0 NOT NULL;
+
+UPDATE user_daily_reward_progress
+SET calendar_id = (SELECT id FROM daily_reward_calendars WHERE slug = 'default'),
+    cycle_claims = longest_streak
+WHERE calendar_id IS NULL;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE user_daily_reward_progress
+    DROP COLUMN IF EXISTS last_claim_timezone;
+
+ALTER TABLE user_daily_reward_progress
+    ALTER COLUMN last_claim_date TYPE DATE USING (last_claim_date AT TIME ZONE 'UTC')::DATE;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS timezone;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- IANA name or UTC offset sent by the client at login, the daily reset follows it
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) DEFAULT 'UTC' NOT NULL;
+
+-- The claim time is kept so the day can be worked out in the timezone of the player
+ALTER TABLE user_daily_reward_progress
+    ALTER COLUMN last_claim_date TYPE TIMESTAMPTZ USING last_claim_date::TIMESTAMP AT TIME ZONE 'UTC';
+
+-- The timezone of the last claim, the next claim needs a new day in it too so changing timezones can't skip days
+ALTER TABLE user_daily_reward_progress
+    ADD COLUMN IF NOT EXISTS last_claim_timezone VARCHAR(64);
+
+COMMIT;
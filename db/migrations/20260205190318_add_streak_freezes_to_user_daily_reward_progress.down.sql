@@ -0,0 +1,13 @@
+BEGIN;
+
+DELETE FROM reward_types WHERE slug = 'STREAK_FREEZE' AND NOT EXISTS (
+    SELECT 1 FROM rewards r WHERE r.reward_type_id = reward_types.id
+);
+
+ALTER TABLE user_daily_reward_progress
+    DROP CONSTRAINT IF EXISTS check_streak_freezes_non_negative,
+    DROP COLUMN IF EXISTS last_welcome_back_at,
+    DROP COLUMN IF EXISTS streak_freezes_used,
+    DROP COLUMN IF EXISTS streak_freezes;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE user_daily_reward_progress
+    ADD COLUMN IF NOT EXISTS streak_freezes INT DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS streak_freezes_used INT DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS last_welcome_back_at TIMESTAMPTZ,
+    ADD CONSTRAINT check_streak_freezes_non_negative CHECK (streak_freezes >= 0);
+
+INSERT INTO reward_types (slug, name, created_at, updated_at)
+VALUES ('STREAK_FREEZE', 'Streak Freeze', NOW(), NOW())
+ON CONFLICT (slug) DO NOTHING;
+
+COMMIT;
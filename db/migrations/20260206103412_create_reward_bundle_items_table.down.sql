@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS reward_bundle_items;
+
+DELETE FROM reward_types WHERE slug = 'BUNDLE' AND NOT EXISTS (
+    SELECT 1 FROM rewards r WHERE r.reward_type_id = reward_types.id
+);
+
+COMMIT;
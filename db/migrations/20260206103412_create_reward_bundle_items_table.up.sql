@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS reward_bundle_items (
+    id BIGSERIAL PRIMARY KEY,
+    reward_id BIGINT NOT NULL REFERENCES rewards(id) ON DELETE CASCADE,
+    reward_type_id BIGINT NOT NULL REFERENCES reward_types(id),
+    boost_id BIGINT REFERENCES boosts(id),
+    amount INT NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX idx_reward_bundle_items_reward_id ON reward_bundle_items(reward_id);
+
+INSERT INTO reward_types (slug, name, created_at, updated_at)
+VALUES ('BUNDLE', 'Bundle', NOW(), NOW())
+ON CONFLICT (slug) DO NOTHING;
+
+COMMIT;
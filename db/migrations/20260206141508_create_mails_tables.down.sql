@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS user_mails;
+DROP TABLE IF EXISTS mail_attachments;
+DROP TABLE IF EXISTS mails;
+
+COMMIT;
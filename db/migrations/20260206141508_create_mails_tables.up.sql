@@ -0,0 +1,38 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS mails (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    body TEXT DEFAULT '' NOT NULL,
+    audience VARCHAR(20) NOT NULL CHECK (audience IN ('users', 'everyone')),
+    expires_at TIMESTAMPTZ,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS mail_attachments (
+    id BIGSERIAL PRIMARY KEY,
+    mail_id BIGINT NOT NULL REFERENCES mails(id) ON DELETE CASCADE,
+    reward_id BIGINT NOT NULL REFERENCES rewards(id),
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+-- Mails to a list of users get a row per recipient when they are sent. Mails to everyone only get a
+-- row once the player reads or claims them, so sending to everyone is a single insert
+CREATE TABLE IF NOT EXISTS user_mails (
+    id BIGSERIAL PRIMARY KEY,
+    mail_id BIGINT NOT NULL REFERENCES mails(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    read_at TIMESTAMPTZ,
+    claimed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    UNIQUE (mail_id, user_id)
+);
+
+CREATE INDEX idx_mail_attachments_mail_id ON mail_attachments(mail_id);
+CREATE INDEX idx_user_mails_user_id ON user_mails(user_id);
+CREATE INDEX idx_mails_everyone_created_at ON mails(created_at) WHERE audience = 'everyone';
+
+COMMIT;
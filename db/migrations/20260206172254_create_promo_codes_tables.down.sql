@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS promo_code_redemptions;
+DROP TABLE IF EXISTS promo_codes;
+DROP TABLE IF EXISTS promo_code_batches;
+
+COMMIT;
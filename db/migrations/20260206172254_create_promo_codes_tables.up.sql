@@ -0,0 +1,44 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS promo_code_batches (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(16) DEFAULT '' NOT NULL,
+    size INT NOT NULL CHECK (size > 0),
+    reward_id BIGINT NOT NULL REFERENCES rewards(id),
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+-- A NULL max_redemptions is a code without a global cap. redemptions is counted on the code so the
+-- cap is checked on the locked row, without counting promo_code_redemptions
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    batch_id BIGINT REFERENCES promo_code_batches(id) ON DELETE CASCADE,
+    reward_id BIGINT NOT NULL REFERENCES rewards(id),
+    max_redemptions INT CHECK (max_redemptions > 0),
+    max_per_user INT DEFAULT 1 NOT NULL CHECK (max_per_user > 0),
+    redemptions INT DEFAULT 0 NOT NULL,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    is_active BOOLEAN DEFAULT TRUE NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE TABLE IF NOT EXISTS promo_code_redemptions (
+    id BIGSERIAL PRIMARY KEY,
+    promo_code_id BIGINT NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX idx_promo_codes_batch_id ON promo_codes(batch_id);
+CREATE INDEX idx_promo_code_redemptions_code_user ON promo_code_redemptions(promo_code_id, user_id);
+
+COMMIT;
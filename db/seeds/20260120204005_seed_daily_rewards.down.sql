@@ -1,7 +1,8 @@
 BEGIN;
 
 DELETE FROM daily_rewards
-WHERE day_number BETWEEN 1 AND 7;
+WHERE calendar_id = (SELECT id FROM daily_reward_calendars WHERE slug = 'default')
+  AND day_number BETWEEN 1 AND 7;
 
 DELETE FROM rewards
 WHERE slug LIKE 'COIN_%'
@@ -21,8 +21,9 @@ FROM reward_types rt
 WHERE rt.slug IN ('COIN', 'GEM', 'GOPAY_COIN')
 ON CONFLICT DO NOTHING;
 
-INSERT INTO daily_rewards (reward_id, day_number, is_active, description, created_at, updated_at)
+INSERT INTO daily_rewards (calendar_id, reward_id, day_number, is_active, description, created_at, updated_at)
 SELECT
+    (SELECT id FROM daily_reward_calendars WHERE slug = 'default'),
     -- Picks a random reward_id from the rewards table for each day
     (SELECT id FROM rewards ORDER BY random() LIMIT 1),
     s.day,
@@ -31,6 +32,6 @@ SELECT
     NOW(),
     NOW()
 FROM generate_series(1, 7) AS s(day)
-ON CONFLICT (day_number) DO NOTHING;
+ON CONFLICT (calendar_id, day_number) DO NOTHING;
 
 COMMIT;
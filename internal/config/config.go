@@ -6,17 +6,13 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 )
 
 const (
 	developmentConfigPath = "./config.yaml"
 )
 
-type JWTConfig struct {
-	SecretKey     string `yaml:"secretKey"`
-	TokenDuration int64  `yaml:"tokenDuration"`
-}
-
 type Config struct {
 	App struct {
 		Name string `yaml:"name"`
@@ -24,9 +20,12 @@ type Config struct {
 		Port int32  `yaml:"port"`
 	} `yaml:"app"`
 
-	Database Database    `yaml:"database"`
-	Redis    RedisConfig `yaml:"redis"`
-	JWT      JWTConfig   `yaml:"jwt"`
+	Database    Database          `yaml:"database"`
+	Redis       RedisConfig       `yaml:"redis"`
+	JWT         JWTConfig         `yaml:"jwt"`
+	Identity    IdentityConfig    `yaml:"identity"`
+	Payout      PayoutConfig      `yaml:"payout"`
+	DailyReward DailyRewardConfig `yaml:"dailyReward"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -89,9 +88,90 @@ func OverrideConfig(cfg *Config) {
 	if secretKey := os.Getenv("JWT_SECRET_KEY"); secretKey != "" {
 		cfg.JWT.SecretKey = secretKey
 	}
-	if duration := os.Getenv("JWT_TOKEN_DURATION"); duration != "" {
+	if activeKeyID := os.Getenv("JWT_ACTIVE_KEY_ID"); activeKeyID != "" {
+		cfg.JWT.ActiveKeyID = activeKeyID
+	}
+	if duration := os.Getenv("JWT_ACCESS_TOKEN_DURATION"); duration != "" {
 		if d, err := strconv.ParseInt(duration, 10, 64); err == nil {
-			cfg.JWT.TokenDuration = d
+			cfg.JWT.AccessTokenDuration = d
+		}
+	}
+	if duration := os.Getenv("JWT_REFRESH_TOKEN_DURATION"); duration != "" {
+		if d, err := strconv.ParseInt(duration, 10, 64); err == nil {
+			cfg.JWT.RefreshTokenDuration = d
+		}
+	}
+
+	if issuer := os.Getenv("IDP_ISSUER"); issuer != "" {
+		cfg.Identity.Issuer = issuer
+	}
+	if clientID := os.Getenv("IDP_CLIENT_ID"); clientID != "" {
+		cfg.Identity.ClientID = clientID
+	}
+	if clientSecret := os.Getenv("IDP_CLIENT_SECRET"); clientSecret != "" {
+		cfg.Identity.ClientSecret = clientSecret
+	}
+	if redirectURI := os.Getenv("IDP_REDIRECT_URI"); redirectURI != "" {
+		cfg.Identity.RedirectURI = redirectURI
+	}
+	if tokenEndpoint := os.Getenv("IDP_TOKEN_ENDPOINT"); tokenEndpoint != "" {
+		cfg.Identity.TokenEndpoint = tokenEndpoint
+	}
+	if jwksURI := os.Getenv("IDP_JWKS_URI"); jwksURI != "" {
+		cfg.Identity.JWKSURI = jwksURI
+	}
+	if timeout := os.Getenv("IDP_TIMEOUT"); timeout != "" {
+		if t, err := strconv.ParseInt(timeout, 10, 64); err == nil {
+			cfg.Identity.Timeout = t
 		}
 	}
+
+	if provider := os.Getenv("PAYOUT_PROVIDER"); provider != "" {
+		cfg.Payout.Provider = provider
+	}
+	if endpoint := os.Getenv("PAYOUT_ENDPOINT"); endpoint != "" {
+		cfg.Payout.Endpoint = endpoint
+	}
+	if apiKey := os.Getenv("PAYOUT_API_KEY"); apiKey != "" {
+		cfg.Payout.APIKey = apiKey
+	}
+	if timeout := os.Getenv("PAYOUT_TIMEOUT"); timeout != "" {
+		if t, err := strconv.ParseInt(timeout, 10, 64); err == nil {
+			cfg.Payout.Timeout = t
+		}
+	}
+	if interval := os.Getenv("PAYOUT_POLL_INTERVAL"); interval != "" {
+		if i, err := strconv.ParseInt(interval, 10, 64); err == nil {
+			cfg.Payout.PollInterval = i
+		}
+	}
+	if batchSize := os.Getenv("PAYOUT_BATCH_SIZE"); batchSize != "" {
+		if b, err := strconv.Atoi(batchSize); err == nil {
+			cfg.Payout.BatchSize = b
+		}
+	}
+
+	if resetHour := os.Getenv("DAILY_REWARD_RESET_HOUR"); resetHour != "" {
+		if h, err := strconv.Atoi(resetHour); err == nil {
+			cfg.DailyReward.ResetHour = h
+		}
+	}
+	if gemCost := os.Getenv("DAILY_REWARD_STREAK_FREEZE_GEM_COST"); gemCost != "" {
+		if c, err := strconv.ParseInt(gemCost, 10, 64); err == nil {
+			cfg.DailyReward.StreakFreeze.GemCost = c
+		}
+	}
+	if maxFreezes := os.Getenv("DAILY_REWARD_STREAK_FREEZE_MAX"); maxFreezes != "" {
+		if m, err := strconv.ParseInt(maxFreezes, 10, 64); err == nil {
+			cfg.DailyReward.StreakFreeze.Max = m
+		}
+	}
+	if afterDays := os.Getenv("DAILY_REWARD_WELCOME_BACK_AFTER_DAYS"); afterDays != "" {
+		if a, err := strconv.ParseInt(afterDays, 10, 64); err == nil {
+			cfg.DailyReward.WelcomeBack.AfterDays = a
+		}
+	}
+	if rewards := os.Getenv("DAILY_REWARD_WELCOME_BACK_REWARDS"); rewards != "" {
+		cfg.DailyReward.WelcomeBack.Rewards = strings.Split(rewards, ",")
+	}
 }
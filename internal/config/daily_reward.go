@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type DailyRewardConfig struct {
+	ResetHour int `yaml:"resetHour"` // hour of the day, in the timezone of the player, when the next daily reward unlocks
+
+	StreakFreeze struct {
+		GemCost int64 `yaml:"gemCost"` // 0 means streak freezes can only be granted as a reward
+		Max     int64 `yaml:"max"`     // how many a player can hold when buying one
+	} `yaml:"streakFreeze"`
+
+	WelcomeBack struct {
+		AfterDays int64    `yaml:"afterDays"` // 0 turns the welcome back rewards off
+		Rewards   []string `yaml:"rewards"`   // reward slugs
+	} `yaml:"welcomeBack"`
+}
+
+func (d *DailyRewardConfig) SetupSettings() (entities.DailyRewardSettings, error) {
+	if d.ResetHour < 0 || d.ResetHour > 23 {
+		return entities.DailyRewardSettings{}, fmt.Errorf("daily reward: reset hour must be between 0 and 23, got %d", d.ResetHour)
+	}
+
+	if d.StreakFreeze.GemCost < 0 || d.StreakFreeze.Max < 0 {
+		return entities.DailyRewardSettings{}, fmt.Errorf("daily reward: streak freeze gem cost and max can't be negative")
+	}
+
+	// A single day away is a regular next day claim
+	if d.WelcomeBack.AfterDays == 1 || d.WelcomeBack.AfterDays < 0 {
+		return entities.DailyRewardSettings{}, fmt.Errorf("daily reward: welcome back needs at least 2 days away, got %d", d.WelcomeBack.AfterDays)
+	}
+
+	return entities.DailyRewardSettings{
+		DayClock:             helper.DayClock{ResetHour: d.ResetHour},
+		StreakFreezeGemCost:  d.StreakFreeze.GemCost,
+		MaxStreakFreezes:     d.StreakFreeze.Max,
+		WelcomeBackAfterDays: d.WelcomeBack.AfterDays,
+		WelcomeBackRewards:   d.WelcomeBack.Rewards,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"time"
+
+	"github.com/winartodev/cat-cafe/pkg/identity"
+)
+
+type IdentityConfig struct {
+	Issuer        string `yaml:"issuer"`
+	ClientID      string `yaml:"clientId"`
+	ClientSecret  string `yaml:"clientSecret"`
+	RedirectURI   string `yaml:"redirectUri"`
+	TokenEndpoint string `yaml:"tokenEndpoint"`
+	JWKSURI       string `yaml:"jwksUri"`
+	Timeout       int64  `yaml:"timeout"` // in seconds
+}
+
+func (i *IdentityConfig) SetupProvider() (identity.Provider, error) {
+	return identity.NewOIDCProvider(identity.OIDCConfig{
+		Issuer:        i.Issuer,
+		ClientID:      i.ClientID,
+		ClientSecret:  i.ClientSecret,
+		RedirectURI:   i.RedirectURI,
+		TokenEndpoint: i.TokenEndpoint,
+		JWKSURI:       i.JWKSURI,
+		Timeout:       time.Duration(i.Timeout) * time.Second,
+	})
+}
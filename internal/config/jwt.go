@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/winartodev/cat-cafe/pkg/jwt"
+)
+
+type JWTKeyConfig struct {
+	ID             string `yaml:"id"`
+	Algorithm      string `yaml:"algorithm"` // HS256, RS256, ES256 or EdDSA
+	Secret         string `yaml:"secret"`
+	PrivateKeyPath string `yaml:"privateKeyPath"`
+	PublicKeyPath  string `yaml:"publicKeyPath"` // verification only keys
+	Retired        bool   `yaml:"retired"`
+}
+
+type JWTConfig struct {
+	SecretKey            string         `yaml:"secretKey"`            // legacy HS256 key, registered with the "default" kid
+	AccessTokenDuration  int64          `yaml:"accessTokenDuration"`  // in minutes
+	RefreshTokenDuration int64          `yaml:"refreshTokenDuration"` // in hours
+	ActiveKeyID          string         `yaml:"activeKeyId"`
+	Keys                 []JWTKeyConfig `yaml:"keys"`
+}
+
+func (j *JWTConfig) SetupJWT() (*jwt.JWT, error) {
+	var keys []*jwt.SigningKey
+
+	if j.SecretKey != "" {
+		key, err := jwt.NewHMACKey(jwt.DefaultKeyID, j.SecretKey, false)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	for _, keyConfig := range j.Keys {
+		key, err := keyConfig.load()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	activeKeyID := j.ActiveKeyID
+	if activeKeyID == "" {
+		activeKeyID = jwt.DefaultKeyID
+	}
+
+	keySet, err := jwt.NewKeySet(activeKeyID, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	return jwt.NewJWT(keySet, j.AccessTokenDuration, j.RefreshTokenDuration), nil
+}
+
+func (k *JWTKeyConfig) load() (*jwt.SigningKey, error) {
+	if k.ID == "" {
+		return nil, fmt.Errorf("jwt key id is required")
+	}
+
+	if k.Algorithm == "HS256" {
+		return jwt.NewHMACKey(k.ID, k.Secret, k.Retired)
+	}
+
+	var privateKeyPEM, publicKeyPEM []byte
+	var err error
+
+	if k.PrivateKeyPath != "" {
+		privateKeyPEM, err = os.ReadFile(k.PrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwt key %s: %w", k.ID, err)
+		}
+	} else if k.PublicKeyPath != "" {
+		publicKeyPEM, err = os.ReadFile(k.PublicKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwt key %s: %w", k.ID, err)
+		}
+	}
+
+	return jwt.NewAsymmetricKey(k.ID, k.Algorithm, privateKeyPEM, publicKeyPEM, k.Retired)
+}
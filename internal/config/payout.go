@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/winartodev/cat-cafe/pkg/payout"
+)
+
+const (
+	payoutProviderHTTP = "http"
+	payoutProviderFake = "fake"
+)
+
+type PayoutConfig struct {
+	Provider     string `yaml:"provider"` // http or fake
+	Endpoint     string `yaml:"endpoint"`
+	APIKey       string `yaml:"apiKey"`
+	Timeout      int64  `yaml:"timeout"`      // in seconds
+	PollInterval int64  `yaml:"pollInterval"` // in seconds
+	BatchSize    int    `yaml:"batchSize"`
+}
+
+func (p *PayoutConfig) SetupProvider() (payout.Provider, error) {
+	switch p.Provider {
+	case payoutProviderFake:
+		return payout.NewFakeProvider(0), nil
+	case payoutProviderHTTP, "":
+		return payout.NewHTTPProvider(payout.HTTPConfig{
+			Endpoint: p.Endpoint,
+			APIKey:   p.APIKey,
+			Timeout:  time.Duration(p.Timeout) * time.Second,
+		})
+	default:
+		return nil, fmt.Errorf("payout: unknown provider %q", p.Provider)
+	}
+}
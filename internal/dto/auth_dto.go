@@ -1,21 +1,80 @@
 package dto
 
-import "github.com/winartodev/cat-cafe/internal/entities"
+import (
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+)
 
 type LoginRequest struct {
 	AuthCode string `json:"auth_code"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 type LoginResponse struct {
-	User      *UserResponse  `json:"user"`
-	GameData  *entities.Game `json:"game_data,omitempty"`
-	AuthToken *string        `json:"auth_token"`
+	User                  *UserResponse  `json:"user"`
+	GameData              *entities.Game `json:"game_data,omitempty"`
+	AuthToken             *string        `json:"auth_token"`
+	AuthTokenExpiresAt    time.Time      `json:"auth_token_expires_at"`
+	RefreshToken          string         `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time      `json:"refresh_token_expires_at"`
+}
+
+type RefreshTokenResponse struct {
+	AuthToken             string    `json:"auth_token"`
+	AuthTokenExpiresAt    time.Time `json:"auth_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+type SessionResponse struct {
+	ID         int64     `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	IsCurrent  bool      `json:"is_current"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
-func ToLoginResponse(authToken *string, user *entities.User, gameData *entities.Game) *LoginResponse {
+func ToLoginResponse(tokens *entities.AuthTokens, user *entities.User, gameData *entities.Game) *LoginResponse {
 	return &LoginResponse{
-		User:      ToUserResponse(user),
-		GameData:  gameData,
-		AuthToken: authToken,
+		User:                  ToUserResponse(user),
+		GameData:              gameData,
+		AuthToken:             &tokens.AccessToken,
+		AuthTokenExpiresAt:    tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
 	}
 }
+
+func ToRefreshTokenResponse(tokens *entities.AuthTokens) *RefreshTokenResponse {
+	return &RefreshTokenResponse{
+		AuthToken:             tokens.AccessToken,
+		AuthTokenExpiresAt:    tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+}
+
+func ToSessionsResponse(sessions []entities.UserSession, currentSessionID int64) []SessionResponse {
+	res := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, SessionResponse{
+			ID:         session.ID,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			IsCurrent:  session.ID == currentSessionID,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			CreatedAt:  session.CreatedAt,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,164 @@
+package dto
+
+import (
+	"math"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+type BoostRequestDTO struct {
+	Slug            string                     `json:"slug"`
+	Name            string                     `json:"name"`
+	Description     string                     `json:"description"`
+	EffectType      entities.BoostEffectType   `json:"effect_type"`
+	EffectValue     float64                    `json:"effect_value"`
+	DurationSeconds int64                      `json:"duration_seconds"`
+	StackingRule    entities.BoostStackingRule `json:"stacking_rule"`
+	MaxStacks       int64                      `json:"max_stacks"`
+	GemCost         int64                      `json:"gem_cost"`
+	Reward          string                     `json:"reward"`
+	IsActive        bool                       `json:"is_active"`
+}
+
+type BoostResponseDTO struct {
+	ID              int64                      `json:"id"`
+	Slug            string                     `json:"slug"`
+	Name            string                     `json:"name"`
+	Description     string                     `json:"description"`
+	EffectType      entities.BoostEffectType   `json:"effect_type"`
+	EffectValue     float64                    `json:"effect_value"`
+	DurationSeconds int64                      `json:"duration_seconds"`
+	StackingRule    entities.BoostStackingRule `json:"stacking_rule"`
+	MaxStacks       int64                      `json:"max_stacks"`
+	GemCost         int64                      `json:"gem_cost"`
+	Reward          string                     `json:"reward,omitempty"`
+	IsActive        bool                       `json:"is_active"`
+}
+
+type UserBoostResponse struct {
+	Slug             string                   `json:"slug"`
+	Name             string                   `json:"name"`
+	EffectType       entities.BoostEffectType `json:"effect_type"`
+	EffectValue      float64                  `json:"effect_value"`
+	StartedAt        time.Time                `json:"started_at"`
+	EndsAt           time.Time                `json:"ends_at"`
+	RemainingSeconds int64                    `json:"remaining_seconds"`
+}
+
+type PurchaseBoostResponse struct {
+	Boost   *UserBoostResponse   `json:"boost"`
+	Balance *UserBalanceResponse `json:"balance,omitempty"`
+}
+
+func (b *BoostRequestDTO) ValidateRequest() error {
+	if b.Slug == "" || b.Name == "" {
+		return apperror.ErrorInvalidRequest("slug and name are required")
+	}
+
+	if !b.EffectType.IsValid() {
+		return apperror.ErrorInvalidRequest("effect type:", b.EffectType.String())
+	}
+
+	if b.EffectType == entities.BoostEffectTypeProfit && b.EffectValue <= 1 {
+		return apperror.ErrorInvalidRequest("profit boost value must be greater than 1")
+	}
+
+	if !b.StackingRule.IsValid() {
+		return apperror.ErrorInvalidRequest("stacking rule:", b.StackingRule.String())
+	}
+
+	if b.DurationSeconds <= 0 {
+		return apperror.ErrorInvalidRequest("duration must be greater than 0")
+	}
+
+	if b.MaxStacks < 1 {
+		return apperror.ErrorInvalidRequest("max stacks must be at least 1")
+	}
+
+	if b.GemCost < 0 {
+		return apperror.ErrorInvalidRequest("gem cost can't be negative")
+	}
+
+	return nil
+}
+
+func (b *BoostRequestDTO) ToEntity() entities.Boost {
+	return entities.Boost{
+		Slug:         b.Slug,
+		Name:         b.Name,
+		Description:  b.Description,
+		EffectType:   b.EffectType,
+		EffectValue:  b.EffectValue,
+		Duration:     time.Duration(b.DurationSeconds) * time.Second,
+		StackingRule: b.StackingRule,
+		MaxStacks:    b.MaxStacks,
+		GemCost:      b.GemCost,
+		RewardSlug:   b.Reward,
+		IsActive:     b.IsActive,
+	}
+}
+
+func ToBoostResponseDTO(e *entities.Boost) *BoostResponseDTO {
+	if e == nil {
+		return nil
+	}
+
+	return &BoostResponseDTO{
+		ID:              e.ID,
+		Slug:            e.Slug,
+		Name:            e.Name,
+		Description:     e.Description,
+		EffectType:      e.EffectType,
+		EffectValue:     e.EffectValue,
+		DurationSeconds: int64(e.Duration.Seconds()),
+		StackingRule:    e.StackingRule,
+		MaxStacks:       e.MaxStacks,
+		GemCost:         e.GemCost,
+		Reward:          e.RewardSlug,
+		IsActive:        e.IsActive,
+	}
+}
+
+func ToBoostsResponseDTO(e []entities.Boost) []BoostResponseDTO {
+	res := make([]BoostResponseDTO, 0, len(e))
+	for i := range e {
+		res = append(res, *ToBoostResponseDTO(&e[i]))
+	}
+
+	return res
+}
+
+// ToUserBoostResponse counts the remaining time of the boost from now
+func ToUserBoostResponse(e *entities.UserBoost, now time.Time) *UserBoostResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &UserBoostResponse{
+		Slug:             e.Boost.Slug,
+		Name:             e.Boost.Name,
+		EffectType:       e.Boost.EffectType,
+		EffectValue:      e.Boost.EffectValue,
+		StartedAt:        e.StartedAt,
+		EndsAt:           e.EndsAt,
+		RemainingSeconds: int64(math.Ceil(e.RemainingAt(now).Seconds())),
+	}
+}
+
+func ToUserBoostsResponse(e []entities.UserBoost, now time.Time) []UserBoostResponse {
+	res := make([]UserBoostResponse, 0, len(e))
+	for i := range e {
+		res = append(res, *ToUserBoostResponse(&e[i], now))
+	}
+
+	return res
+}
+
+func ToPurchaseBoostResponse(boost *entities.UserBoost, balance *entities.UserBalance, now time.Time) *PurchaseBoostResponse {
+	return &PurchaseBoostResponse{
+		Boost:   ToUserBoostResponse(boost, now),
+		Balance: toStageGrantBalanceResponse(balance),
+	}
+}
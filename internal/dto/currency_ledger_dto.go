@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+)
+
+type CurrencyLedgerEntryResponse struct {
+	ID           int64     `json:"id"`
+	Currency     string    `json:"currency"`
+	SourceType   string    `json:"source_type"`
+	SourceRef    string    `json:"source_ref"`
+	Delta        int64     `json:"delta"`
+	BalanceAfter int64     `json:"balance_after"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CurrencyLedgerMismatchResponse struct {
+	UserID          int64     `json:"user_id"`
+	Currency        string    `json:"currency"`
+	Balance         int64     `json:"balance"`
+	LedgerSum       int64     `json:"ledger_sum"`
+	Difference      int64     `json:"difference"`
+	FirstDetectedAt time.Time `json:"first_detected_at"`
+	LastDetectedAt  time.Time `json:"last_detected_at"`
+}
+
+func ToCurrencyLedgerEntriesResponse(data []entities.CurrencyLedgerEntry) []CurrencyLedgerEntryResponse {
+	res := make([]CurrencyLedgerEntryResponse, 0)
+	for _, e := range data {
+		res = append(res, CurrencyLedgerEntryResponse{
+			ID:           e.ID,
+			Currency:     e.Currency.String(),
+			SourceType:   e.SourceType.String(),
+			SourceRef:    e.SourceRef,
+			Delta:        e.Delta,
+			BalanceAfter: e.BalanceAfter,
+			CreatedAt:    e.CreatedAt,
+		})
+	}
+
+	return res
+}
+
+func ToCurrencyLedgerMismatchesResponse(data []entities.CurrencyLedgerMismatch) []CurrencyLedgerMismatchResponse {
+	res := make([]CurrencyLedgerMismatchResponse, 0)
+	for _, e := range data {
+		res = append(res, CurrencyLedgerMismatchResponse{
+			UserID:          e.UserID,
+			Currency:        e.Currency.String(),
+			Balance:         e.Balance,
+			LedgerSum:       e.LedgerSum,
+			Difference:      e.Balance - e.LedgerSum,
+			FirstDetectedAt: e.FirstDetectedAt,
+			LastDetectedAt:  e.LastDetectedAt,
+		})
+	}
+
+	return res
+}
@@ -1,7 +1,10 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
 )
 
 type CreateRewardTypeRequest struct {
@@ -20,11 +23,18 @@ type RewardTypeResponse struct {
 }
 
 type CreateRewardRequest struct {
-	Slug       string `json:"slug"`
-	Name       string `json:"name"`
+	Slug       string                    `json:"slug"`
+	Name       string                    `json:"name"`
+	RewardType string                    `json:"reward_type"`
+	Amount     int64                     `json:"amount"`
+	IsActive   bool                      `json:"is_active"`
+	Items      []RewardBundleItemRequest `json:"items"`
+}
+
+type RewardBundleItemRequest struct {
 	RewardType string `json:"reward_type"`
 	Amount     int64  `json:"amount"`
-	IsActive   bool   `json:"is_active"`
+	Boost      string `json:"boost"`
 }
 
 type UpdateRewardRequest struct {
@@ -35,15 +45,23 @@ type UpdateRewardRequest struct {
 }
 
 type RewardResponse struct {
-	ID         *int64               `json:"id,omitempty"`
-	Slug       string               `json:"slug"`
-	Name       string               `json:"name"`
-	Amount     int64                `json:"amount"`
-	IsActive   bool                 `json:"is_active"`
-	RewardType *entities.RewardType `json:"reward_type"`
+	ID         *int64                     `json:"id,omitempty"`
+	Slug       string                     `json:"slug"`
+	Name       string                     `json:"name"`
+	Amount     int64                      `json:"amount"`
+	IsActive   bool                       `json:"is_active"`
+	RewardType *entities.RewardType       `json:"reward_type"`
+	Items      []RewardBundleItemResponse `json:"items,omitempty"`
+}
+
+type RewardBundleItemResponse struct {
+	RewardType string `json:"reward_type"`
+	Amount     int64  `json:"amount"`
+	Boost      string `json:"boost,omitempty"`
 }
 
 type DailyRewardRequest struct {
+	Calendar    string `json:"calendar"`
 	DayNumber   int64  `json:"day_number"`
 	Reward      string `json:"reward"`
 	IsActive    bool   `json:"is_active"`
@@ -52,6 +70,7 @@ type DailyRewardRequest struct {
 
 type DailyRewardResponse struct {
 	ID          int64                 `json:"id"`
+	Calendar    string                `json:"calendar,omitempty"`
 	DayNumber   int64                 `json:"day_number"`
 	IsActive    bool                  `json:"is_active"`
 	Status      entities.RewardStatus `json:"status,omitempty"`
@@ -60,14 +79,58 @@ type DailyRewardResponse struct {
 }
 
 type DailyRewardStatus struct {
-	CurrentDailyRewardIdx int64                 `json:"current_daily_reward_idx"`
-	IsNewDay              bool                  `json:"is_new_day"`
-	Rewards               []DailyRewardResponse `json:"rewards"`
+	Calendar              *DailyRewardCalendarResponse `json:"calendar"`
+	CurrentDailyRewardIdx int64                        `json:"current_daily_reward_idx"`
+	IsNewDay              bool                         `json:"is_new_day"`
+	CanClaim              bool                         `json:"can_claim"`
+	NextResetAt           time.Time                    `json:"next_reset_at"`
+	ResetsAt              *time.Time                   `json:"resets_at,omitempty"`
+	Streak                DailyRewardStreakResponse    `json:"streak"`
+	WelcomeBackRewards    []RewardResponse             `json:"welcome_back_rewards,omitempty"`
+	Rewards               []DailyRewardResponse        `json:"rewards"`
+}
+
+type DailyRewardStreakResponse struct {
+	CurrentStreak       int64 `json:"current_streak"`
+	LongestStreak       int64 `json:"longest_streak"`
+	StreakFreezes       int64 `json:"streak_freezes"`
+	StreakFreezesToUse  int64 `json:"streak_freezes_to_use"`
+	DaysAway            int64 `json:"days_away"`
+	IsStreakLost        bool  `json:"is_streak_lost"`
+	IsWelcomeBack       bool  `json:"is_welcome_back"`
+	StreakFreezeGemCost int64 `json:"streak_freeze_gem_cost"`
+	MaxStreakFreezes    int64 `json:"max_streak_freezes"`
+}
+
+type DailyRewardCalendarRequest struct {
+	Slug     string                           `json:"slug"`
+	Name     string                           `json:"name"`
+	Type     entities.DailyRewardCalendarType `json:"type"`
+	StartsAt time.Time                        `json:"starts_at"`
+}
+
+type DailyRewardCalendarResponse struct {
+	ID       int64                            `json:"id"`
+	Slug     string                           `json:"slug"`
+	Name     string                           `json:"name"`
+	Type     entities.DailyRewardCalendarType `json:"type"`
+	StartsAt time.Time                        `json:"starts_at"`
+	IsActive bool                             `json:"is_active"`
 }
 
 type ClaimDailyRewardResponse struct {
-	Reward  *DailyRewardResponse `json:"reward"`
-	Balance *UserBalanceResponse `json:"balance,omitempty"`
+	Reward             *DailyRewardResponse `json:"reward"`
+	WelcomeBackRewards []RewardResponse     `json:"welcome_back_rewards,omitempty"`
+	CurrentStreak      int64                `json:"current_streak"`
+	LongestStreak      int64                `json:"longest_streak"`
+	StreakFreezes      int64                `json:"streak_freezes"`
+	StreakFreezesUsed  int64                `json:"streak_freezes_used"`
+	Balance            *UserBalanceResponse `json:"balance,omitempty"`
+}
+
+type PurchaseStreakFreezeResponse struct {
+	StreakFreezes int64                `json:"streak_freezes"`
+	Balance       *UserBalanceResponse `json:"balance,omitempty"`
 }
 
 func (e *CreateRewardTypeRequest) ToEntity() *entities.RewardType {
@@ -113,7 +176,47 @@ func (e *CreateRewardRequest) ToEntity() entities.Reward {
 		RewardType: &entities.RewardType{
 			Slug: e.RewardType,
 		},
+		Items: toRewardBundleItems(e.Items),
+	}
+}
+
+func toRewardBundleItems(items []RewardBundleItemRequest) []entities.RewardBundleItem {
+	if len(items) == 0 {
+		return nil
+	}
+
+	res := make([]entities.RewardBundleItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, entities.RewardBundleItem{
+			RewardType: &entities.RewardType{Slug: item.RewardType},
+			Amount:     item.Amount,
+			BoostSlug:  item.Boost,
+		})
+	}
+
+	return res
+}
+
+func ToRewardBundleItemResponses(items []entities.RewardBundleItem) []RewardBundleItemResponse {
+	if len(items) == 0 {
+		return nil
+	}
+
+	res := make([]RewardBundleItemResponse, 0, len(items))
+	for _, item := range items {
+		var rewardType string
+		if item.RewardType != nil {
+			rewardType = item.RewardType.Slug
+		}
+
+		res = append(res, RewardBundleItemResponse{
+			RewardType: rewardType,
+			Amount:     item.Amount,
+			Boost:      item.BoostSlug,
+		})
 	}
+
+	return res
 }
 
 func ToRewardResponse(data *entities.Reward) RewardResponse {
@@ -133,6 +236,7 @@ func ToRewardResponse(data *entities.Reward) RewardResponse {
 		Amount:     data.Amount,
 		IsActive:   data.IsActive,
 		RewardType: rewardType,
+		Items:      ToRewardBundleItemResponses(data.Items),
 	}
 }
 
@@ -163,6 +267,7 @@ func ToDailyRewardResponse(dailyReward *entities.DailyReward) *DailyRewardRespon
 
 	return &DailyRewardResponse{
 		ID:          dailyReward.ID,
+		Calendar:    dailyReward.CalendarSlug,
 		Reward:      &reward,
 		DayNumber:   dailyReward.DayNumber,
 		IsActive:    dailyReward.IsActive,
@@ -180,16 +285,81 @@ func ToDailyRewardResponses(entities []entities.DailyReward) []DailyRewardRespon
 	return res
 }
 
-func ToDailyRewardStatus(rewards []entities.DailyReward, dailyRewardIdx *int64, isNewDay *bool) DailyRewardStatus {
+func ToDailyRewardStatus(status *entities.DailyRewardStatus) DailyRewardStatus {
 	return DailyRewardStatus{
-		CurrentDailyRewardIdx: *dailyRewardIdx,
-		IsNewDay:              *isNewDay,
-		Rewards:               ToDailyRewardResponses(rewards),
+		Calendar:              ToDailyRewardCalendarResponse(status.Calendar),
+		CurrentDailyRewardIdx: status.CurrentIdx,
+		IsNewDay:              status.IsNewDay,
+		CanClaim:              status.CanClaim,
+		NextResetAt:           status.NextResetAt,
+		ResetsAt:              status.ResetsAt,
+		Streak: DailyRewardStreakResponse{
+			CurrentStreak:       status.Streak.CurrentStreak,
+			LongestStreak:       status.Streak.LongestStreak,
+			StreakFreezes:       status.Streak.StreakFreezes,
+			StreakFreezesToUse:  status.Streak.StreakFreezesToUse,
+			DaysAway:            status.Streak.DaysAway,
+			IsStreakLost:        status.Streak.IsStreakLost,
+			IsWelcomeBack:       status.Streak.IsWelcomeBack,
+			StreakFreezeGemCost: status.StreakFreezeGemCost,
+			MaxStreakFreezes:    status.MaxStreakFreezes,
+		},
+		WelcomeBackRewards: ToRewardsResponse(status.WelcomeBackRewards),
+		Rewards:            ToDailyRewardResponses(status.Rewards),
 	}
 }
 
-func ToClaimDailyRewardResponse(reward *entities.DailyReward, balance *entities.UserBalance) *ClaimDailyRewardResponse {
-	if reward == nil {
+func (e *DailyRewardCalendarRequest) ValidateRequest() error {
+	if e.Slug == "" || e.Name == "" {
+		return apperror.ErrorInvalidRequest("slug and name are required")
+	}
+
+	if !e.Type.IsValid() {
+		return apperror.ErrorInvalidRequest("daily reward calendar type:", e.Type.String())
+	}
+
+	if e.StartsAt.IsZero() {
+		return apperror.ErrorInvalidRequest("starts_at is required")
+	}
+
+	return nil
+}
+
+func (e *DailyRewardCalendarRequest) ToEntity() entities.DailyRewardCalendar {
+	return entities.DailyRewardCalendar{
+		Slug:     e.Slug,
+		Name:     e.Name,
+		Type:     e.Type,
+		StartsAt: e.StartsAt.UTC(),
+	}
+}
+
+func ToDailyRewardCalendarResponse(e *entities.DailyRewardCalendar) *DailyRewardCalendarResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &DailyRewardCalendarResponse{
+		ID:       e.ID,
+		Slug:     e.Slug,
+		Name:     e.Name,
+		Type:     e.Type,
+		StartsAt: e.StartsAt,
+		IsActive: e.IsActive,
+	}
+}
+
+func ToDailyRewardCalendarResponses(e []entities.DailyRewardCalendar) []DailyRewardCalendarResponse {
+	res := make([]DailyRewardCalendarResponse, 0, len(e))
+	for i := range e {
+		res = append(res, *ToDailyRewardCalendarResponse(&e[i]))
+	}
+
+	return res
+}
+
+func ToClaimDailyRewardResponse(claim *entities.DailyRewardClaim, balance *entities.UserBalance) *ClaimDailyRewardResponse {
+	if claim == nil {
 		return nil
 	}
 
@@ -202,7 +372,19 @@ func ToClaimDailyRewardResponse(reward *entities.DailyReward, balance *entities.
 	}
 
 	return &ClaimDailyRewardResponse{
-		Reward:  ToDailyRewardResponse(reward),
-		Balance: userBalance,
+		Reward:             ToDailyRewardResponse(claim.Reward),
+		WelcomeBackRewards: ToRewardsResponse(claim.WelcomeBackRewards),
+		CurrentStreak:      claim.CurrentStreak,
+		LongestStreak:      claim.LongestStreak,
+		StreakFreezes:      claim.StreakFreezes,
+		StreakFreezesUsed:  claim.StreakFreezesUsed,
+		Balance:            userBalance,
+	}
+}
+
+func ToPurchaseStreakFreezeResponse(streakFreezes int64, balance *entities.UserBalance) *PurchaseStreakFreezeResponse {
+	return &PurchaseStreakFreezeResponse{
+		StreakFreezes: streakFreezes,
+		Balance:       toStageGrantBalanceResponse(balance),
 	}
 }
@@ -36,7 +36,17 @@ type UserStageUpgradeResponse struct {
 	Cost        int64                    `json:"cost"`
 	CostType    entities.UpgradeCostType `json:"cost_type"`
 
-	IsPurchased bool `json:"is_purchased"`
+	IsPurchased bool                   `json:"is_purchased"`
+	IsLocked    bool                   `json:"is_locked"`
+	LockReasons []UpgradeLockReasonDTO `json:"lock_reasons,omitempty"`
+}
+
+type UpgradeLockReasonDTO struct {
+	Type    string `json:"type"`
+	Upgrade string `json:"upgrade,omitempty"`
+	Station string `json:"station,omitempty"`
+	Target  int64  `json:"target,omitempty"`
+	Current int64  `json:"current"`
 }
 
 type UserPurchasedStageUpgradeResponse struct {
@@ -55,18 +65,61 @@ type UserDetailGameStageResponse struct {
 	Customer        *CustomerConfigDTO  `json:"customer_config,omitempty"`
 	Staff           *StaffConfigDTO     `json:"staff_config,omitempty"`
 	KitchenStations []KitchenStationDTO `json:"kitchen_stations,omitempty"`
+	KitchenUpgrades *StationUpgradeDTO  `json:"kitchen_upgrades,omitempty"`
 	Kitchen         *KitchenConfigDTO   `json:"kitchen_config,omitempty"`
 	Camera          *CameraConfigDTO    `json:"camera_config,omitempty"`
 
-	NextStage *entities.UserNextGameStageInfo `json:"next_stage,omitempty"`
+	NextStage    *entities.UserNextGameStageInfo `json:"next_stage,omitempty"`
+	Requirements []StageRequirementProgressDTO   `json:"completion_requirements,omitempty"`
+}
+
+type StageRequirementProgressDTO struct {
+	Type    string `json:"type"`
+	Target  int64  `json:"target"`
+	Current int64  `json:"current"`
+	IsMet   bool   `json:"is_met"`
+}
+
+type StartGameStageResponse struct {
+	*UserDetailGameStageResponse
+	StartingCoinGranted int64                   `json:"starting_coin_granted"`
+	Balance             *UserBalanceResponse    `json:"balance,omitempty"`
+	OfflineEarning      *OfflineEarningResponse `json:"offline_earning,omitempty"`
 }
 
+type OfflineEarningResponse struct {
+	ID             int64   `json:"id"`
+	Coins          int64   `json:"coins"`
+	CoinsPerSecond float64 `json:"coins_per_second"`
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+	IsCapped       bool    `json:"is_capped"`
+	BonusCoins     int64   `json:"bonus_coins"`
+	IsBoosted      bool    `json:"is_boosted"`
+}
+
+type BoostOfflineEarningResponse struct {
+	*OfflineEarningResponse
+	Balance *UserBalanceResponse `json:"balance,omitempty"`
+}
+
+type CompleteGameStageResponse struct {
+	PrizeGranted int64                `json:"prize_granted"`
+	Balance      *UserBalanceResponse `json:"balance,omitempty"`
+}
+
+const (
+	StationUpgradeModeMax = "max"
+)
+
 type UserUpgradeKitchenResponse struct {
-	Name           string               `json:"name"`
-	Slug           string               `json:"slug"`
-	CurrentLevel   *currentStationLevel `json:"current_level,omitempty"`
-	NextLevel      *nextStationLevel    `json:"next_level,omitempty"`
-	GrantedRewards []kitchenPhaseReward `json:"granted_rewards,omitempty"`
+	Name             string                         `json:"name"`
+	Slug             string                         `json:"slug"`
+	LevelsUpgraded   int64                          `json:"levels_upgraded"`
+	CoinsSpent       int64                          `json:"coins_spent"`
+	CurrentLevel     *currentStationLevel           `json:"current_level,omitempty"`
+	NextLevel        *nextStationLevel              `json:"next_level,omitempty"`
+	PhaseTransitions []entities.PhaseTransitionInfo `json:"phase_transitions,omitempty"`
+	GrantedRewards   []kitchenPhaseReward           `json:"granted_rewards,omitempty"`
 }
 
 type UserUnlockKitchenResponse struct {
@@ -125,8 +178,10 @@ func ToUserUpgradeKitchenResponse(data *entities.UpgradeKitchenStation) *UserUpg
 	}
 
 	return &UserUpgradeKitchenResponse{
-		Name: data.Name,
-		Slug: data.Slug,
+		Name:           data.Name,
+		Slug:           data.Slug,
+		LevelsUpgraded: data.LevelsUpgraded,
+		CoinsSpent:     data.CoinsSpent,
 		CurrentLevel: &currentStationLevel{
 			Level:          data.CurrentLevel,
 			Profit:         data.CurrentProfit,
@@ -134,8 +189,54 @@ func ToUserUpgradeKitchenResponse(data *entities.UpgradeKitchenStation) *UserUpg
 			CompletedPhase: data.CompletedPhase,
 			Reward:         rewards,
 		},
-		NextLevel:      nextLevel,
-		GrantedRewards: grantedRewards,
+		NextLevel:        nextLevel,
+		PhaseTransitions: data.PhaseTransitions,
+		GrantedRewards:   grantedRewards,
+	}
+}
+
+type StationUpgradePreviewResponse struct {
+	Name         string                       `json:"name"`
+	Slug         string                       `json:"slug"`
+	CurrentLevel int64                        `json:"current_level"`
+	MaxLevel     int64                        `json:"max_level"`
+	Levels       []StationUpgradePreviewLevel `json:"levels"`
+}
+
+type StationUpgradePreviewLevel struct {
+	Level          int64   `json:"level"`
+	Cost           int64   `json:"cost"`
+	CumulativeCost int64   `json:"cumulative_cost"`
+	Profit         int64   `json:"profit"`
+	CookingTime    float64 `json:"cooking_time"`
+	Phase          int64   `json:"phase"`
+	TableCount     int64   `json:"table_count"`
+}
+
+func ToStationUpgradePreviewResponse(data *entities.StationUpgradePreview) *StationUpgradePreviewResponse {
+	if data == nil {
+		return nil
+	}
+
+	levels := make([]StationUpgradePreviewLevel, 0, len(data.Levels))
+	for _, v := range data.Levels {
+		levels = append(levels, StationUpgradePreviewLevel{
+			Level:          v.Level,
+			Cost:           v.Cost,
+			CumulativeCost: v.CumulativeCost,
+			Profit:         v.Profit,
+			CookingTime:    v.PreparationTime,
+			Phase:          v.Phase,
+			TableCount:     v.TableCount,
+		})
+	}
+
+	return &StationUpgradePreviewResponse{
+		Name:         data.Name,
+		Slug:         data.Slug,
+		CurrentLevel: data.CurrentLevel,
+		MaxLevel:     data.MaxLevel,
+		Levels:       levels,
 	}
 }
 
@@ -211,9 +312,102 @@ func ToUserDetailGameStageResponse(
 		Customer:        toCustomerConfigDTO(config.CustomerConfig),
 		Staff:           toStaffConfigDTO(config.StaffConfig),
 		KitchenStations: toKitchenStationsDTOWithProgress(config.KitchenStations, config.UserProgress),
+		KitchenUpgrades: toKitchenUpgradesDTO(config.UserProgress),
 		Kitchen:         toKitchenConfigDTO(config.KitchenConfig, config.KitchenPhaseReward),
 		Camera:          toCameraConfigDTO(config.CameraConfig),
 		NextStage:       nextStage,
+		Requirements:    toStageRequirementProgressDTO(config.Requirements),
+	}
+}
+
+func toStageRequirementProgressDTO(data []entities.StageRequirementProgress) []StageRequirementProgressDTO {
+	if len(data) == 0 {
+		return nil
+	}
+
+	res := make([]StageRequirementProgressDTO, 0, len(data))
+	for _, r := range data {
+		res = append(res, StageRequirementProgressDTO{
+			Type:    r.Type.String(),
+			Target:  r.Target,
+			Current: r.Current,
+			IsMet:   r.IsMet,
+		})
+	}
+
+	return res
+}
+
+func ToStartGameStageResponse(
+	data *entities.GameStage,
+	config *entities.GameStageConfig,
+	nextStage *entities.UserNextGameStageInfo,
+	grant *entities.StageCoinGrant,
+) *StartGameStageResponse {
+	detail := ToUserDetailGameStageResponse(data, config, nextStage)
+	if detail == nil {
+		return nil
+	}
+
+	res := &StartGameStageResponse{
+		UserDetailGameStageResponse: detail,
+	}
+
+	if grant != nil {
+		res.StartingCoinGranted = grant.Granted
+		res.Balance = toStageGrantBalanceResponse(grant.Balance)
+		res.OfflineEarning = ToOfflineEarningResponse(grant.OfflineEarning)
+	}
+
+	return res
+}
+
+func ToOfflineEarningResponse(data *entities.OfflineEarning) *OfflineEarningResponse {
+	if data == nil {
+		return nil
+	}
+
+	return &OfflineEarningResponse{
+		ID:             data.ID,
+		Coins:          data.Coins,
+		CoinsPerSecond: data.CoinsPerSecond,
+		ElapsedSeconds: data.ElapsedSeconds,
+		IsCapped:       data.IsCapped,
+		BonusCoins:     data.BonusCoins,
+		IsBoosted:      data.IsBonusGranted(),
+	}
+}
+
+func ToBoostOfflineEarningResponse(data *entities.OfflineEarning, balance *entities.UserBalance) *BoostOfflineEarningResponse {
+	if data == nil {
+		return nil
+	}
+
+	return &BoostOfflineEarningResponse{
+		OfflineEarningResponse: ToOfflineEarningResponse(data),
+		Balance:                toStageGrantBalanceResponse(balance),
+	}
+}
+
+func ToCompleteGameStageResponse(grant *entities.StageCoinGrant) *CompleteGameStageResponse {
+	if grant == nil {
+		return nil
+	}
+
+	return &CompleteGameStageResponse{
+		PrizeGranted: grant.Granted,
+		Balance:      toStageGrantBalanceResponse(grant.Balance),
+	}
+}
+
+func toStageGrantBalanceResponse(balance *entities.UserBalance) *UserBalanceResponse {
+	if balance == nil {
+		return nil
+	}
+
+	return &UserBalanceResponse{
+		Coin: balance.Coin,
+		Gem:  balance.Gem,
 	}
 }
 
@@ -232,12 +426,33 @@ func ToUserStageUpgradesResponse(items []entities.UserStageUpgrade) []UserStageU
 			Cost:        upgrade.Cost,
 			CostType:    upgrade.CostType,
 			IsPurchased: item.IsPurchased,
+			IsLocked:    item.IsLocked,
+			LockReasons: toUpgradeLockReasonsDTO(item.LockReasons),
 		})
 	}
 
 	return data
 }
 
+func toUpgradeLockReasonsDTO(data []entities.UpgradeLockReason) []UpgradeLockReasonDTO {
+	if len(data) == 0 {
+		return nil
+	}
+
+	res := make([]UpgradeLockReasonDTO, 0, len(data))
+	for _, r := range data {
+		res = append(res, UpgradeLockReasonDTO{
+			Type:    r.Type.String(),
+			Upgrade: r.Upgrade,
+			Station: r.Station,
+			Target:  r.Target,
+			Current: r.Current,
+		})
+	}
+
+	return res
+}
+
 func ToUserPurchasedStageUpgradeResponse(data *entities.Upgrade) *UserPurchasedStageUpgradeResponse {
 	if data == nil {
 		return nil
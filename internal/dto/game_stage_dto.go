@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
 )
 
 type BaseGameStageRequest struct {
@@ -100,6 +101,65 @@ type BaseStageUpgradeRequest struct {
 	Upgrades []string `json:"upgrades"`
 }
 
+type StageRequirementDTO struct {
+	Type   string `json:"type"`
+	Target int64  `json:"target"`
+}
+
+type UpdateStageRequirementsRequest struct {
+	Requirements []StageRequirementDTO `json:"requirements"`
+}
+
+type StageRequirementsResponse struct {
+	StageID      int64                 `json:"stage_id"`
+	Requirements []StageRequirementDTO `json:"requirements"`
+}
+
+// ToEntities an empty list removes every requirement of the stage
+func (d *UpdateStageRequirementsRequest) ToEntities(stageID int64) ([]entities.StageCompletionRequirement, error) {
+	res := make([]entities.StageCompletionRequirement, 0, len(d.Requirements))
+	seen := make(map[entities.StageRequirementType]bool)
+
+	for _, r := range d.Requirements {
+		requirementType, err := entities.ParseStageRequirementType(r.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[requirementType] {
+			return nil, apperror.ErrorInvalidRequest("duplicate stage requirement type:", r.Type)
+		}
+		seen[requirementType] = true
+
+		if r.Target <= 0 {
+			return nil, apperror.ErrorInvalidRequest("stage requirement target must be greater than 0")
+		}
+
+		res = append(res, entities.StageCompletionRequirement{
+			StageID: stageID,
+			Type:    requirementType,
+			Target:  r.Target,
+		})
+	}
+
+	return res, nil
+}
+
+func ToStageRequirementsResponse(stageID int64, data []entities.StageCompletionRequirement) *StageRequirementsResponse {
+	requirements := make([]StageRequirementDTO, 0, len(data))
+	for _, r := range data {
+		requirements = append(requirements, StageRequirementDTO{
+			Type:   r.Type.String(),
+			Target: r.Target,
+		})
+	}
+
+	return &StageRequirementsResponse{
+		StageID:      stageID,
+		Requirements: requirements,
+	}
+}
+
 func ToUpgradeStageResponse(stage string, upgrades []string) BaseStageUpgradeRequest {
 	return BaseStageUpgradeRequest{
 		Stage:    stage,
@@ -261,6 +321,12 @@ func (d *BaseGameStageRequest) toEntitiesCommon() (*entities.GameStageConfig, er
 		data := entities.KitchenStation{
 			FoodItemSlug: kitchenStation.FoodItemSlug,
 			AutoUnlock:   kitchenStation.AutoUnlock,
+			UnlockPhase:  kitchenStation.UnlockPhase,
+		}
+
+		// Stations without a phase are available from the first phase
+		if data.UnlockPhase <= 0 {
+			data.UnlockPhase = 1
 		}
 
 		kitchenStations = append(kitchenStations, data)
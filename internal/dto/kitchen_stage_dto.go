@@ -19,11 +19,20 @@ type KitchenStationDTO struct {
 	FoodItemSlug string `json:"slug"`
 	FoodName     string `json:"name"`
 	AutoUnlock   bool   `json:"auto_unlock"`
+	UnlockPhase  int64  `json:"unlock_phase"`
 	IsLocked     bool   `json:"is_locked"`
 
 	// User progression data
 	CurrentLevel *currentStationLevel `json:"current_level,omitempty"`
 	NextLevel    *nextStationLevel    `json:"next_level,omitempty"`
+	Upgrades     *StationUpgradeDTO   `json:"upgrades,omitempty"`
+}
+
+type StationUpgradeDTO struct {
+	ProfitBonus       float64 `json:"profit_bonus"`
+	ReduceCookingTime float64 `json:"reduce_cooking_time"`
+	HelperCount       int64   `json:"helper_count"`
+	CustomerCount     int64   `json:"customer_count"`
 }
 
 type KitchenConfigDTO struct {
@@ -53,6 +62,7 @@ func toKitchenStationDTO(data *entities.KitchenStation) *KitchenStationDTO {
 		FoodItemSlug: data.FoodItemSlug,
 		FoodName:     data.FoodName,
 		AutoUnlock:   data.AutoUnlock,
+		UnlockPhase:  data.UnlockPhase,
 		IsLocked:     true,
 	}
 }
@@ -108,6 +118,11 @@ func toKitchenStationsDTOWithProgress(
 				}
 			}
 
+			// Station upgrades including the all food upgrades of the kitchen
+			if upgrade := userProgress.EffectiveStationUpgrade(station.FoodItemSlug); upgrade != (entities.UserStationUpgrade{}) {
+				dto.Upgrades = toStationUpgradeDTO(upgrade)
+			}
+
 			// Add next level data if available
 			if userProgress.NextLevelStats != nil {
 				if nextLevel, exists := userProgress.NextLevelStats[station.FoodItemSlug]; exists {
@@ -126,6 +141,23 @@ func toKitchenStationsDTOWithProgress(
 	return kitchenStations
 }
 
+func toStationUpgradeDTO(data entities.UserStationUpgrade) *StationUpgradeDTO {
+	return &StationUpgradeDTO{
+		ProfitBonus:       data.ProfitBonus,
+		ReduceCookingTime: data.ReduceCookingTime,
+		HelperCount:       data.HelperCount,
+		CustomerCount:     data.CustomerCount,
+	}
+}
+
+func toKitchenUpgradesDTO(progress *entities.UserKitchenStageProgression) *StationUpgradeDTO {
+	if progress == nil || progress.KitchenUpgrades == (entities.UserStationUpgrade{}) {
+		return nil
+	}
+
+	return toStationUpgradeDTO(progress.KitchenUpgrades)
+}
+
 func toKitchenConfigDTO(data *entities.StageKitchenConfig, kitchenPhaseReward []entities.KitchenPhaseCompletionRewards) *KitchenConfigDTO {
 	if data == nil {
 		return nil
@@ -0,0 +1,141 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+type SendMailRequest struct {
+	Title     string                `json:"title"`
+	Body      string                `json:"body"`
+	Audience  entities.MailAudience `json:"audience"`
+	UserIDs   []int64               `json:"user_ids"`
+	Rewards   []string              `json:"rewards"`
+	ExpiresAt *time.Time            `json:"expires_at"`
+}
+
+type MailResponse struct {
+	ID          int64            `json:"id"`
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	Audience    string           `json:"audience"`
+	Attachments []RewardResponse `json:"attachments"`
+	ExpiresAt   *time.Time       `json:"expires_at"`
+	CreatedBy   *int64           `json:"created_by"`
+	Recipients  int64            `json:"recipients"`
+	Claims      int64            `json:"claims"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type UserMailResponse struct {
+	ID          int64            `json:"id"`
+	Title       string           `json:"title"`
+	Body        string           `json:"body"`
+	Attachments []RewardResponse `json:"attachments"`
+	ExpiresAt   *time.Time       `json:"expires_at"`
+	IsRead      bool             `json:"is_read"`
+	IsClaimable bool             `json:"is_claimable"`
+	ReadAt      *time.Time       `json:"read_at"`
+	ClaimedAt   *time.Time       `json:"claimed_at"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type UserMailboxResponse struct {
+	Unread int64              `json:"unread"`
+	Mails  []UserMailResponse `json:"mails"`
+}
+
+type ClaimUserMailResponse struct {
+	Mail    *UserMailResponse    `json:"mail"`
+	Balance *UserBalanceResponse `json:"balance,omitempty"`
+}
+
+func (m *SendMailRequest) ValidateRequest() error {
+	if m.Title == "" {
+		return apperror.ErrorInvalidRequest("title is required")
+	}
+
+	if !m.Audience.IsValid() {
+		return apperror.ErrorInvalidRequest("audience:", m.Audience.String())
+	}
+
+	if m.Audience == entities.MailAudienceUsers && len(m.UserIDs) == 0 {
+		return apperror.ErrorInvalidRequest("user_ids are required when sending to users")
+	}
+
+	if m.Audience == entities.MailAudienceEveryone && len(m.UserIDs) > 0 {
+		return apperror.ErrorInvalidRequest("user_ids can not be set when sending to everyone")
+	}
+
+	return nil
+}
+
+func (m *SendMailRequest) ToEntity() entities.Mail {
+	return entities.Mail{
+		Title:     m.Title,
+		Body:      m.Body,
+		Audience:  m.Audience,
+		UserIDs:   m.UserIDs,
+		ExpiresAt: m.ExpiresAt,
+	}
+}
+
+func ToMailResponse(data *entities.Mail) *MailResponse {
+	return &MailResponse{
+		ID:          data.ID,
+		Title:       data.Title,
+		Body:        data.Body,
+		Audience:    data.Audience.String(),
+		Attachments: ToRewardsResponse(data.Attachments),
+		ExpiresAt:   data.ExpiresAt,
+		CreatedBy:   data.CreatedBy,
+		Recipients:  data.Recipients,
+		Claims:      data.Claims,
+		CreatedAt:   data.CreatedAt,
+	}
+}
+
+func ToMailsResponse(data []entities.Mail) []MailResponse {
+	res := make([]MailResponse, 0)
+	for i := range data {
+		res = append(res, *ToMailResponse(&data[i]))
+	}
+
+	return res
+}
+
+func ToUserMailResponse(data *entities.UserMail) *UserMailResponse {
+	return &UserMailResponse{
+		ID:          data.ID,
+		Title:       data.Title,
+		Body:        data.Body,
+		Attachments: ToRewardsResponse(data.Attachments),
+		ExpiresAt:   data.ExpiresAt,
+		IsRead:      data.ReadAt != nil,
+		IsClaimable: data.IsClaimable(),
+		ReadAt:      data.ReadAt,
+		ClaimedAt:   data.ClaimedAt,
+		CreatedAt:   data.CreatedAt,
+	}
+}
+
+func ToUserMailboxResponse(data []entities.UserMail, unread int64) *UserMailboxResponse {
+	mails := make([]UserMailResponse, 0)
+	for i := range data {
+		mails = append(mails, *ToUserMailResponse(&data[i]))
+	}
+
+	return &UserMailboxResponse{
+		Unread: unread,
+		Mails:  mails,
+	}
+}
+
+func ToClaimUserMailResponse(data *entities.UserMail, balance *entities.UserBalance) *ClaimUserMailResponse {
+	return &ClaimUserMailResponse{
+		Mail:    ToUserMailResponse(data),
+		Balance: toStageGrantBalanceResponse(balance),
+	}
+}
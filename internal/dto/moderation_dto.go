@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+)
+
+type SuspendUserRequest struct {
+	Type      string     `json:"type"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type UserSuspensionResponse struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	Type      string     `json:"type"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedBy *int64     `json:"created_by"`
+	LiftedAt  *time.Time `json:"lifted_at"`
+	LiftedBy  *int64     `json:"lifted_by"`
+	IsActive  bool       `json:"is_active"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (r *SuspendUserRequest) ToEntity() (*entities.UserSuspension, error) {
+	suspensionType, err := entities.ParseSuspensionType(r.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.UserSuspension{
+		Type:      suspensionType,
+		Reason:    r.Reason,
+		ExpiresAt: r.ExpiresAt,
+	}, nil
+}
+
+func ToUserSuspensionResponse(s *entities.UserSuspension, now time.Time) *UserSuspensionResponse {
+	return &UserSuspensionResponse{
+		ID:        s.ID,
+		UserID:    s.UserID,
+		Type:      s.Type.String(),
+		Reason:    s.Reason,
+		ExpiresAt: s.ExpiresAt,
+		CreatedBy: s.CreatedBy,
+		LiftedAt:  s.LiftedAt,
+		LiftedBy:  s.LiftedBy,
+		IsActive:  s.IsActive(now),
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+func ToUserSuspensionsResponse(data []entities.UserSuspension, now time.Time) []UserSuspensionResponse {
+	res := make([]UserSuspensionResponse, 0)
+	for i := range data {
+		res = append(res, *ToUserSuspensionResponse(&data[i], now))
+	}
+
+	return res
+}
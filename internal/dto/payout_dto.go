@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+)
+
+type PayoutResponse struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	Reward         string     `json:"reward"`
+	Currency       string     `json:"currency"`
+	Amount         int64      `json:"amount"`
+	SourceType     string     `json:"source_type"`
+	SourceRef      string     `json:"source_ref"`
+	Status         string     `json:"status"`
+	Attempts       int64      `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastError      string     `json:"last_error,omitempty"`
+	IdempotencyKey string     `json:"idempotency_key"`
+	ProviderRef    string     `json:"provider_ref,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func ToPayoutResponse(e *entities.Payout) *PayoutResponse {
+	if e == nil {
+		return nil
+	}
+
+	return &PayoutResponse{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		Reward:         e.RewardSlug,
+		Currency:       e.Currency,
+		Amount:         e.Amount,
+		SourceType:     e.Source.Type.String(),
+		SourceRef:      e.Source.Ref,
+		Status:         e.Status.String(),
+		Attempts:       e.Attempts,
+		NextAttemptAt:  e.NextAttemptAt,
+		LastError:      e.LastError,
+		IdempotencyKey: e.IdempotencyKey(),
+		ProviderRef:    e.ProviderRef,
+		CompletedAt:    e.CompletedAt,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+func ToPayoutsResponse(data []entities.Payout) []PayoutResponse {
+	res := make([]PayoutResponse, 0)
+	for i := range data {
+		res = append(res, *ToPayoutResponse(&data[i]))
+	}
+
+	return res
+}
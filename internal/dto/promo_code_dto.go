@@ -0,0 +1,196 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+type CreatePromoCodeRequest struct {
+	Code           string     `json:"code"`
+	Reward         string     `json:"reward"`
+	MaxRedemptions *int64     `json:"max_redemptions"`
+	MaxPerUser     *int64     `json:"max_per_user"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	IsActive       *bool      `json:"is_active"`
+}
+
+type CreatePromoCodeBatchRequest struct {
+	Name     string     `json:"name"`
+	Prefix   string     `json:"prefix"`
+	Size     int64      `json:"size"`
+	Reward   string     `json:"reward"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+type RedeemPromoCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type PromoCodeResponse struct {
+	ID             int64      `json:"id"`
+	Code           string     `json:"code"`
+	BatchID        *int64     `json:"batch_id"`
+	Reward         string     `json:"reward"`
+	MaxRedemptions *int64     `json:"max_redemptions"`
+	MaxPerUser     int64      `json:"max_per_user"`
+	Redemptions    int64      `json:"redemptions"`
+	IsSingleUse    bool       `json:"is_single_use"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	IsActive       bool       `json:"is_active"`
+	CreatedBy      *int64     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type PromoCodeBatchResponse struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Size      int64      `json:"size"`
+	Reward    string     `json:"reward"`
+	StartsAt  *time.Time `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+	CreatedBy *int64     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type RedeemPromoCodeResponse struct {
+	Code    string               `json:"code"`
+	Reward  RewardResponse       `json:"reward"`
+	Balance *UserBalanceResponse `json:"balance,omitempty"`
+}
+
+func (p *CreatePromoCodeRequest) ValidateRequest() error {
+	if p.Code == "" || p.Reward == "" {
+		return apperror.ErrorInvalidRequest("code and reward are required")
+	}
+
+	return nil
+}
+
+// ToEntity a code is active and can be redeemed once per player unless the request says otherwise
+func (p *CreatePromoCodeRequest) ToEntity() entities.PromoCode {
+	maxPerUser := int64(1)
+	if p.MaxPerUser != nil {
+		maxPerUser = *p.MaxPerUser
+	}
+
+	isActive := true
+	if p.IsActive != nil {
+		isActive = *p.IsActive
+	}
+
+	return entities.PromoCode{
+		Code:           p.Code,
+		MaxRedemptions: p.MaxRedemptions,
+		MaxPerUser:     maxPerUser,
+		StartsAt:       p.StartsAt,
+		EndsAt:         p.EndsAt,
+		IsActive:       isActive,
+	}
+}
+
+func (p *CreatePromoCodeBatchRequest) ValidateRequest() error {
+	if p.Name == "" || p.Reward == "" {
+		return apperror.ErrorInvalidRequest("name and reward are required")
+	}
+
+	if p.Size <= 0 {
+		return apperror.ErrorInvalidRequest("size must be greater than 0")
+	}
+
+	return nil
+}
+
+func (p *CreatePromoCodeBatchRequest) ToEntity() entities.PromoCodeBatch {
+	return entities.PromoCodeBatch{
+		Name:     p.Name,
+		Prefix:   p.Prefix,
+		Size:     p.Size,
+		StartsAt: p.StartsAt,
+		EndsAt:   p.EndsAt,
+	}
+}
+
+func (p *RedeemPromoCodeRequest) ValidateRequest() error {
+	if p.Code == "" {
+		return apperror.ErrorInvalidRequest("code is required")
+	}
+
+	return nil
+}
+
+func ToPromoCodeResponse(data *entities.PromoCode) *PromoCodeResponse {
+	var reward string
+	if data.Reward != nil {
+		reward = data.Reward.Slug
+	}
+
+	return &PromoCodeResponse{
+		ID:             data.ID,
+		Code:           data.Code,
+		BatchID:        data.BatchID,
+		Reward:         reward,
+		MaxRedemptions: data.MaxRedemptions,
+		MaxPerUser:     data.MaxPerUser,
+		Redemptions:    data.Redemptions,
+		IsSingleUse:    data.IsSingleUse(),
+		StartsAt:       data.StartsAt,
+		EndsAt:         data.EndsAt,
+		IsActive:       data.IsActive,
+		CreatedBy:      data.CreatedBy,
+		CreatedAt:      data.CreatedAt,
+	}
+}
+
+func ToPromoCodesResponse(data []entities.PromoCode) []PromoCodeResponse {
+	res := make([]PromoCodeResponse, 0)
+	for i := range data {
+		res = append(res, *ToPromoCodeResponse(&data[i]))
+	}
+
+	return res
+}
+
+func ToPromoCodeBatchResponse(data *entities.PromoCodeBatch) *PromoCodeBatchResponse {
+	var reward string
+	if data.Reward != nil {
+		reward = data.Reward.Slug
+	}
+
+	return &PromoCodeBatchResponse{
+		ID:        data.ID,
+		Name:      data.Name,
+		Prefix:    data.Prefix,
+		Size:      data.Size,
+		Reward:    reward,
+		StartsAt:  data.StartsAt,
+		EndsAt:    data.EndsAt,
+		CreatedBy: data.CreatedBy,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+func ToPromoCodeBatchesResponse(data []entities.PromoCodeBatch) []PromoCodeBatchResponse {
+	res := make([]PromoCodeBatchResponse, 0)
+	for i := range data {
+		res = append(res, *ToPromoCodeBatchResponse(&data[i]))
+	}
+
+	return res
+}
+
+func ToRedeemPromoCodeResponse(data *entities.PromoCodeRedemption, balance *entities.UserBalance) *RedeemPromoCodeResponse {
+	reward := ToRewardResponse(data.Reward)
+	reward.ID = nil
+
+	return &RedeemPromoCodeResponse{
+		Code:    data.Code.Code,
+		Reward:  reward,
+		Balance: toStageGrantBalanceResponse(balance),
+	}
+}
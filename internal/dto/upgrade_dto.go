@@ -218,3 +218,95 @@ func (u *UpgradeEffectDTO) ValidateRequest() error {
 
 	return nil
 }
+
+type UpgradePrerequisiteDTO struct {
+	Type    string `json:"type"`
+	Upgrade string `json:"upgrade,omitempty"`
+	Station string `json:"station,omitempty"`
+	Target  int64  `json:"target,omitempty"`
+}
+
+type UpdateUpgradePrerequisitesRequest struct {
+	Prerequisites []UpgradePrerequisiteDTO `json:"prerequisites"`
+}
+
+type UpgradePrerequisitesResponse struct {
+	UpgradeID     int64                    `json:"upgrade_id"`
+	Prerequisites []UpgradePrerequisiteDTO `json:"prerequisites"`
+}
+
+// ToEntities an empty list removes every prerequisite of the upgrade, the slugs are resolved by the use case
+func (d *UpdateUpgradePrerequisitesRequest) ToEntities(upgradeID int64) ([]entities.UpgradePrerequisite, error) {
+	res := make([]entities.UpgradePrerequisite, 0, len(d.Prerequisites))
+	seen := make(map[string]bool)
+
+	for _, p := range d.Prerequisites {
+		prerequisiteType, err := entities.ParseUpgradePrerequisiteType(p.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		prerequisite := entities.UpgradePrerequisite{
+			UpgradeID: upgradeID,
+			Type:      prerequisiteType,
+		}
+
+		var key string
+		switch prerequisiteType {
+		case entities.UpgradePrerequisiteUpgrade:
+			if p.Upgrade == "" {
+				return nil, apperror.ErrorInvalidRequest("upgrade prerequisite requires an upgrade slug")
+			}
+
+			prerequisite.RequiredUpgradeSlug = p.Upgrade
+			key = p.Upgrade
+
+		case entities.UpgradePrerequisiteStationLevel:
+			if p.Station == "" {
+				return nil, apperror.ErrorInvalidRequest("station level prerequisite requires a station slug")
+			}
+
+			if p.Target <= 0 {
+				return nil, apperror.ErrorInvalidRequest("station level prerequisite target must be greater than 0")
+			}
+
+			prerequisite.FoodItemSlug = p.Station
+			prerequisite.Target = p.Target
+			key = p.Station
+
+		case entities.UpgradePrerequisiteKitchenPhase:
+			if p.Target <= 0 {
+				return nil, apperror.ErrorInvalidRequest("kitchen phase prerequisite target must be greater than 0")
+			}
+
+			prerequisite.Target = p.Target
+		}
+
+		key = prerequisiteType.String() + ":" + key
+		if seen[key] {
+			return nil, apperror.ErrorInvalidRequest("duplicate upgrade prerequisite:", key)
+		}
+		seen[key] = true
+
+		res = append(res, prerequisite)
+	}
+
+	return res, nil
+}
+
+func ToUpgradePrerequisitesResponse(upgradeID int64, data []entities.UpgradePrerequisite) *UpgradePrerequisitesResponse {
+	prerequisites := make([]UpgradePrerequisiteDTO, 0, len(data))
+	for _, p := range data {
+		prerequisites = append(prerequisites, UpgradePrerequisiteDTO{
+			Type:    p.Type.String(),
+			Upgrade: p.RequiredUpgradeSlug,
+			Station: p.FoodItemSlug,
+			Target:  p.Target,
+		})
+	}
+
+	return &UpgradePrerequisitesResponse{
+		UpgradeID:     upgradeID,
+		Prerequisites: prerequisites,
+	}
+}
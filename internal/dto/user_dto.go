@@ -8,19 +8,25 @@ type UserBalanceResponse struct {
 }
 
 type UserResponse struct {
-	ID         int64  `json:"id"`
-	ExternalID string `json:"external_id"`
-	Username   string `json:"username"`
-	Email      string `json:"email"`
-	IsActive   bool   `json:"is_active"`
+	ID          int64  `json:"id"`
+	ExternalID  string `json:"external_id"`
+	Username    string `json:"username"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"`
+	Timezone    string `json:"timezone"`
+	IsActive    bool   `json:"is_active"`
 }
 
 func ToUserResponse(user *entities.User) *UserResponse {
 	return &UserResponse{
-		ID:         user.ID,
-		ExternalID: user.ExternalID,
-		Username:   user.Username,
-		Email:      user.Email,
-		IsActive:   user.IsActive,
+		ID:          user.ID,
+		ExternalID:  user.ExternalID,
+		Username:    user.Username,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		AvatarURL:   user.AvatarURL,
+		Timezone:    user.Timezone,
+		IsActive:    user.IsActive,
 	}
 }
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+type AdminUser struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Role      AdminRole `json:"role"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (a *AdminUser) HasPermission(permission AdminPermission) bool {
+	if a == nil || !a.IsActive {
+		return false
+	}
+
+	return a.Role.HasPermission(permission)
+}
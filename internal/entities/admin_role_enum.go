@@ -0,0 +1,86 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+type AdminRole string
+
+const (
+	AdminRoleViewer   AdminRole = "viewer"
+	AdminRoleDesigner AdminRole = "designer"
+	AdminRoleLiveOps  AdminRole = "liveops"
+)
+
+func (r AdminRole) String() string {
+	return string(r)
+}
+
+func (r AdminRole) IsValid() bool {
+	switch r {
+	case AdminRoleViewer,
+		AdminRoleDesigner,
+		AdminRoleLiveOps:
+		return true
+	}
+	return false
+}
+
+func ParseAdminRole(s string) (AdminRole, error) {
+	role := AdminRole(s)
+	if !role.IsValid() {
+		return "", apperror.ErrorInvalidRequest("admin role:", s)
+	}
+	return role, nil
+}
+
+func AllAdminRole() []AdminRole {
+	return []AdminRole{
+		AdminRoleViewer,
+		AdminRoleDesigner,
+		AdminRoleLiveOps,
+	}
+}
+
+type AdminPermission string
+
+const (
+	// PermissionContentRead allows reading every internal resource
+	PermissionContentRead AdminPermission = "content:read"
+	// PermissionContentWrite allows changing game design data (stages, foods, upgrades, tutorials)
+	PermissionContentWrite AdminPermission = "content:write"
+	// PermissionLiveOpsWrite allows changing live operation data (rewards, daily rewards)
+	PermissionLiveOpsWrite AdminPermission = "liveops:write"
+	// PermissionPlayerModerate allows suspending and banning players
+	PermissionPlayerModerate AdminPermission = "player:moderate"
+)
+
+func (p AdminPermission) String() string {
+	return string(p)
+}
+
+var adminRolePermissions = map[AdminRole][]AdminPermission{
+	AdminRoleViewer: {
+		PermissionContentRead,
+	},
+	AdminRoleDesigner: {
+		PermissionContentRead,
+		PermissionContentWrite,
+	},
+	AdminRoleLiveOps: {
+		PermissionContentRead,
+		PermissionLiveOpsWrite,
+		PermissionPlayerModerate,
+	},
+}
+
+func (r AdminRole) Permissions() []AdminPermission {
+	return adminRolePermissions[r]
+}
+
+func (r AdminRole) HasPermission(permission AdminPermission) bool {
+	for _, p := range adminRolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
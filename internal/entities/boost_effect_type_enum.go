@@ -0,0 +1,41 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+type BoostEffectType string
+
+const (
+	// BoostEffectTypeProfit multiplies the profit of every station by the effect value
+	BoostEffectTypeProfit BoostEffectType = "profit"
+
+	// BoostEffectTypeInstantCook cooks every order in the minimum preparation time
+	BoostEffectTypeInstantCook BoostEffectType = "instant_cook"
+)
+
+func (t BoostEffectType) String() string {
+	return string(t)
+}
+
+func (t BoostEffectType) IsValid() bool {
+	switch t {
+	case BoostEffectTypeProfit,
+		BoostEffectTypeInstantCook:
+		return true
+	}
+	return false
+}
+
+func ParseBoostEffectType(s string) (BoostEffectType, error) {
+	effectType := BoostEffectType(s)
+	if !effectType.IsValid() {
+		return "", apperror.ErrorInvalidRequest("boost effect type:", s)
+	}
+	return effectType, nil
+}
+
+func AllBoostEffectType() []BoostEffectType {
+	return []BoostEffectType{
+		BoostEffectTypeProfit,
+		BoostEffectTypeInstantCook,
+	}
+}
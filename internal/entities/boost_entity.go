@@ -0,0 +1,78 @@
+package entities
+
+import "time"
+
+// Boost is a temporary effect on the kitchen, bought with gems or granted by a BOOST reward
+type Boost struct {
+	ID           int64             `json:"id"`
+	Slug         string            `json:"slug"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	EffectType   BoostEffectType   `json:"effect_type"`
+	EffectValue  float64           `json:"effect_value"`
+	Duration     time.Duration     `json:"duration"`
+	StackingRule BoostStackingRule `json:"stacking_rule"`
+	MaxStacks    int64             `json:"max_stacks"`
+	GemCost      int64             `json:"gem_cost"` // 0 means the boost can only be granted as a reward
+	RewardID     *int64            `json:"reward_id"`
+	RewardSlug   string            `json:"reward_slug"`
+	IsActive     bool              `json:"is_active"`
+	CreatedAt    time.Time         `json:"-"`
+	UpdatedAt    time.Time         `json:"-"`
+}
+
+// UserBoost is a boost of the player, it is active between StartedAt and EndsAt
+type UserBoost struct {
+	ID        int64                `json:"id"`
+	UserID    int64                `json:"user_id"`
+	BoostID   int64                `json:"boost_id"`
+	Boost     Boost                `json:"boost"`
+	Source    CurrencyLedgerSource `json:"source"`
+	StartedAt time.Time            `json:"started_at"`
+	EndsAt    time.Time            `json:"ends_at"`
+}
+
+func (b *UserBoost) IsActiveAt(t time.Time) bool {
+	return !t.Before(b.StartedAt) && t.Before(b.EndsAt)
+}
+
+func (b *UserBoost) RemainingAt(t time.Time) time.Duration {
+	if !t.Before(b.EndsAt) {
+		return 0
+	}
+
+	return b.EndsAt.Sub(t)
+}
+
+// BoostModifier is the combined effect of the boosts active at the same time
+type BoostModifier struct {
+	ProfitMultiplier float64 // 0 when no profit boost is active
+	InstantCook      bool
+}
+
+// NewBoostModifier combines the boosts active at t, profit boosts multiply each other
+func NewBoostModifier(boosts []UserBoost, t time.Time) BoostModifier {
+	var modifier BoostModifier
+	for _, b := range boosts {
+		if !b.IsActiveAt(t) {
+			continue
+		}
+
+		switch b.Boost.EffectType {
+		case BoostEffectTypeProfit:
+			if b.Boost.EffectValue <= 0 {
+				continue
+			}
+
+			if modifier.ProfitMultiplier == 0 {
+				modifier.ProfitMultiplier = 1
+			}
+			modifier.ProfitMultiplier *= b.Boost.EffectValue
+
+		case BoostEffectTypeInstantCook:
+			modifier.InstantCook = true
+		}
+	}
+
+	return modifier
+}
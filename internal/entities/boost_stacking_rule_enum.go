@@ -0,0 +1,46 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+type BoostStackingRule string
+
+const (
+	// BoostStackingExtend adds the duration to the end of the boost that is still active
+	BoostStackingExtend BoostStackingRule = "extend"
+
+	// BoostStackingRefresh restarts the boost that is still active with a full duration
+	BoostStackingRefresh BoostStackingRule = "refresh"
+
+	// BoostStackingStack runs the boosts side by side up to the max stacks, their effects multiply
+	BoostStackingStack BoostStackingRule = "stack"
+)
+
+func (r BoostStackingRule) String() string {
+	return string(r)
+}
+
+func (r BoostStackingRule) IsValid() bool {
+	switch r {
+	case BoostStackingExtend,
+		BoostStackingRefresh,
+		BoostStackingStack:
+		return true
+	}
+	return false
+}
+
+func ParseBoostStackingRule(s string) (BoostStackingRule, error) {
+	rule := BoostStackingRule(s)
+	if !rule.IsValid() {
+		return "", apperror.ErrorInvalidRequest("boost stacking rule:", s)
+	}
+	return rule, nil
+}
+
+func AllBoostStackingRule() []BoostStackingRule {
+	return []BoostStackingRule{
+		BoostStackingExtend,
+		BoostStackingRefresh,
+		BoostStackingStack,
+	}
+}
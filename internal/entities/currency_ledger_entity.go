@@ -0,0 +1,38 @@
+package entities
+
+import "time"
+
+// CurrencyLedgerSource describes why a balance changed
+type CurrencyLedgerSource struct {
+	Type CurrencySourceType
+	Ref  string
+}
+
+type CurrencyLedgerEntry struct {
+	ID           int64              `json:"id"`
+	UserID       int64              `json:"user_id"`
+	Currency     UserBalanceType    `json:"currency"`
+	SourceType   CurrencySourceType `json:"source_type"`
+	SourceRef    string             `json:"source_ref"`
+	Delta        int64              `json:"delta"`
+	BalanceAfter int64              `json:"balance_after"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+type CurrencyLedgerMismatch struct {
+	ID              int64           `json:"id"`
+	UserID          int64           `json:"user_id"`
+	Currency        UserBalanceType `json:"currency"`
+	Balance         int64           `json:"balance"`
+	LedgerSum       int64           `json:"ledger_sum"`
+	FirstDetectedAt time.Time       `json:"first_detected_at"`
+	LastDetectedAt  time.Time       `json:"last_detected_at"`
+	ResolvedAt      *time.Time      `json:"resolved_at"`
+}
+
+// CurrencyReconciliation is the summary of a reconciliation run
+type CurrencyReconciliation struct {
+	Flagged  int64     `json:"flagged"`
+	Resolved int64     `json:"resolved"`
+	RunAt    time.Time `json:"run_at"`
+}
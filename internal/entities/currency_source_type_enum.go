@@ -0,0 +1,80 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+type CurrencySourceType string
+
+const (
+	CurrencySourceOpeningBalance       CurrencySourceType = "opening_balance"
+	CurrencySourceSyncBalance          CurrencySourceType = "sync_balance"
+	CurrencySourceDailyReward          CurrencySourceType = "daily_reward"
+	CurrencySourcePhaseReward          CurrencySourceType = "phase_reward"
+	CurrencySourceStationUnlock        CurrencySourceType = "station_unlock"
+	CurrencySourceStationUpgrade       CurrencySourceType = "station_upgrade"
+	CurrencySourceStageUpgrade         CurrencySourceType = "stage_upgrade"
+	CurrencySourceStageStart           CurrencySourceType = "stage_starting_coin"
+	CurrencySourceStagePrize           CurrencySourceType = "stage_prize"
+	CurrencySourceOfflineEarning       CurrencySourceType = "offline_earning"
+	CurrencySourceOfflineBonus         CurrencySourceType = "offline_earning_bonus"
+	CurrencySourceBoostPurchase        CurrencySourceType = "boost_purchase"
+	CurrencySourceStreakFreezePurchase CurrencySourceType = "streak_freeze_purchase"
+	CurrencySourceWelcomeBack          CurrencySourceType = "welcome_back"
+	CurrencySourceMail                 CurrencySourceType = "mail"
+	CurrencySourcePromoCode            CurrencySourceType = "promo_code"
+)
+
+func (c CurrencySourceType) String() string {
+	return string(c)
+}
+
+func (c CurrencySourceType) IsValid() bool {
+	switch c {
+	case CurrencySourceOpeningBalance,
+		CurrencySourceSyncBalance,
+		CurrencySourceDailyReward,
+		CurrencySourcePhaseReward,
+		CurrencySourceStationUnlock,
+		CurrencySourceStationUpgrade,
+		CurrencySourceStageUpgrade,
+		CurrencySourceStageStart,
+		CurrencySourceStagePrize,
+		CurrencySourceOfflineEarning,
+		CurrencySourceOfflineBonus,
+		CurrencySourceBoostPurchase,
+		CurrencySourceStreakFreezePurchase,
+		CurrencySourceWelcomeBack,
+		CurrencySourceMail,
+		CurrencySourcePromoCode:
+		return true
+	}
+	return false
+}
+
+func ParseCurrencySourceType(s string) (CurrencySourceType, error) {
+	source := CurrencySourceType(s)
+	if !source.IsValid() {
+		return "", apperror.ErrorInvalidRequest("currency source type:", s)
+	}
+	return source, nil
+}
+
+func AllCurrencySourceType() []CurrencySourceType {
+	return []CurrencySourceType{
+		CurrencySourceOpeningBalance,
+		CurrencySourceSyncBalance,
+		CurrencySourceDailyReward,
+		CurrencySourcePhaseReward,
+		CurrencySourceStationUnlock,
+		CurrencySourceStationUpgrade,
+		CurrencySourceStageUpgrade,
+		CurrencySourceStageStart,
+		CurrencySourceStagePrize,
+		CurrencySourceOfflineEarning,
+		CurrencySourceOfflineBonus,
+		CurrencySourceBoostPurchase,
+		CurrencySourceStreakFreezePurchase,
+		CurrencySourceWelcomeBack,
+		CurrencySourceMail,
+		CurrencySourcePromoCode,
+	}
+}
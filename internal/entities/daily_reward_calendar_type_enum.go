@@ -0,0 +1,53 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+type DailyRewardCalendarType string
+
+const (
+	// DailyRewardCalendarLoop starts over from the first day after the last day is claimed
+	DailyRewardCalendarLoop DailyRewardCalendarType = "loop"
+
+	// DailyRewardCalendarMonthly starts over on the 1st of every month, once every day is claimed
+	// nothing can be claimed until then
+	DailyRewardCalendarMonthly DailyRewardCalendarType = "monthly"
+)
+
+// maxMonthlyCalendarDays is the most days a monthly calendar can give out
+const maxMonthlyCalendarDays = 31
+
+func (t DailyRewardCalendarType) String() string {
+	return string(t)
+}
+
+func (t DailyRewardCalendarType) IsValid() bool {
+	switch t {
+	case DailyRewardCalendarLoop,
+		DailyRewardCalendarMonthly:
+		return true
+	}
+	return false
+}
+
+// MaxDays is the highest day number the calendar type can hold, 0 means no limit
+func (t DailyRewardCalendarType) MaxDays() int64 {
+	if t == DailyRewardCalendarMonthly {
+		return maxMonthlyCalendarDays
+	}
+	return 0
+}
+
+func ParseDailyRewardCalendarType(s string) (DailyRewardCalendarType, error) {
+	calendarType := DailyRewardCalendarType(s)
+	if !calendarType.IsValid() {
+		return "", apperror.ErrorInvalidRequest("daily reward calendar type:", s)
+	}
+	return calendarType, nil
+}
+
+func AllDailyRewardCalendarType() []DailyRewardCalendarType {
+	return []DailyRewardCalendarType{
+		DailyRewardCalendarLoop,
+		DailyRewardCalendarMonthly,
+	}
+}
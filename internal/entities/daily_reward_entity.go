@@ -2,6 +2,8 @@ package entities
 
 import (
 	"time"
+
+	"github.com/winartodev/cat-cafe/pkg/helper"
 )
 
 type RewardType struct {
@@ -21,25 +23,138 @@ type Reward struct {
 	CreatedAt  time.Time   `json:"-"`
 	UpdatedAt  time.Time   `json:"-"`
 	RewardType *RewardType `json:"reward_type"`
+
+	// Items are what a BUNDLE reward grants, the amount of a bundle is not used
+	Items []RewardBundleItem `json:"items,omitempty"`
+}
+
+// RewardBundleItem is one entry of a bundle reward, a BOOST entry activates the boost Amount times
+type RewardBundleItem struct {
+	ID         int64       `json:"id,omitempty"`
+	RewardID   int64       `json:"reward_id"`
+	RewardType *RewardType `json:"reward_type"`
+	Amount     int64       `json:"amount"`
+	BoostID    *int64      `json:"boost_id,omitempty"`
+	BoostSlug  string      `json:"boost_slug,omitempty"`
+}
+
+// DailyRewardCalendar groups the days of a daily reward cycle.
+//
+// The calendar with the latest StartsAt that has passed is the active one, scheduling a
+// calendar with a later StartsAt switches every player over to it at that time
+type DailyRewardCalendar struct {
+	ID        int64                   `json:"id"`
+	Slug      string                  `json:"slug"`
+	Name      string                  `json:"name"`
+	Type      DailyRewardCalendarType `json:"type"`
+	StartsAt  time.Time               `json:"starts_at"`
+	IsActive  bool                    `json:"is_active"`
+	CreatedAt time.Time               `json:"-"`
+	UpdatedAt time.Time               `json:"-"`
 }
 
 type DailyReward struct {
-	ID          int64        `json:"id"`
-	DayNumber   int64        `json:"day_number"`
-	IsActive    bool         `json:"is_active"`
-	Description string       `json:"description"`
-	CreatedAt   time.Time    `json:"-"`
-	UpdatedAt   time.Time    `json:"-"`
-	Reward      *Reward      `json:"reward"`
-	Status      RewardStatus `json:"status"`
+	ID           int64        `json:"id"`
+	CalendarID   int64        `json:"calendar_id"`
+	CalendarSlug string       `json:"calendar_slug"`
+	DayNumber    int64        `json:"day_number"`
+	IsActive     bool         `json:"is_active"`
+	Description  string       `json:"description"`
+	CreatedAt    time.Time    `json:"-"`
+	UpdatedAt    time.Time    `json:"-"`
+	Reward       *Reward      `json:"reward"`
+	Status       RewardStatus `json:"status"`
 }
 
 type UserDailyReward struct {
 	ID            int64      `json:"id"`
 	UserID        int64      `json:"user_id"`
+	CalendarID    *int64     `json:"calendar_id"`
+	CycleClaims   int64      `json:"cycle_claims"`
 	LongestStreak int64      `json:"longest_streak"`
 	CurrentStreak int64      `json:"current_streak"`
 	LastClaimDate *time.Time `json:"last_claim_date"`
-	CreatedAt     time.Time  `json:"-"`
-	UpdatedAt     time.Time  `json:"-"`
+
+	// LastClaimTimezone is the timezone the last claim was made in, empty for claims made before it was kept
+	LastClaimTimezone string `json:"last_claim_timezone"`
+
+	StreakFreezes     int64      `json:"streak_freezes"`
+	StreakFreezesUsed int64      `json:"streak_freezes_used"`
+	LastWelcomeBackAt *time.Time `json:"last_welcome_back_at"`
+
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// DailyRewardSettings are the server settings of the daily rewards
+type DailyRewardSettings struct {
+	DayClock helper.DayClock
+
+	// StreakFreezeGemCost is the price of one streak freeze, 0 means they can only be granted as a reward
+	StreakFreezeGemCost int64
+
+	// MaxStreakFreezes is how many streak freezes a player can hold when buying one
+	MaxStreakFreezes int64
+
+	// WelcomeBackAfterDays is how many days a player has to be away to get the welcome back rewards, 0 turns them off
+	WelcomeBackAfterDays int64
+	WelcomeBackRewards   []string
+}
+
+// IsWelcomeBackEnabled reports whether returning players get a welcome back bundle
+func (s DailyRewardSettings) IsWelcomeBackEnabled() bool {
+	return s.WelcomeBackAfterDays > 0 && len(s.WelcomeBackRewards) > 0
+}
+
+// DailyRewardStreak is the streak the next claim of a user continues from
+type DailyRewardStreak struct {
+	CurrentStreak int64
+	LongestStreak int64
+	StreakFreezes int64
+
+	// DaysAway is how many days passed since the last claim, 0 when the user claimed today or never claimed
+	DaysAway int64
+
+	// StreakFreezesToUse is how many freezes the next claim uses to cover the missed days
+	StreakFreezesToUse int64
+
+	// IsStreakLost is set when days were missed and there were not enough freezes to cover them
+	IsStreakLost bool
+
+	IsWelcomeBack bool
+}
+
+// DailyRewardStatus is the progress of a user through the active calendar
+type DailyRewardStatus struct {
+	Calendar   *DailyRewardCalendar
+	Rewards    []DailyReward
+	CurrentIdx int64
+	IsNewDay   bool
+	CanClaim   bool
+
+	// NextResetAt is when the next game day starts for the user
+	NextResetAt time.Time
+
+	// ResetsAt is when a monthly calendar starts over, nil for a loop
+	ResetsAt *time.Time
+
+	Streak DailyRewardStreak
+
+	// StreakFreezeGemCost and MaxStreakFreezes are the shop settings, a gem cost of 0 means freezes can't be bought
+	StreakFreezeGemCost int64
+	MaxStreakFreezes    int64
+
+	// WelcomeBackRewards are granted with the next claim, empty unless the user is returning
+	WelcomeBackRewards []Reward
+}
+
+// DailyRewardClaim is the outcome of claiming the daily reward
+type DailyRewardClaim struct {
+	Reward             *DailyReward
+	WelcomeBackRewards []Reward
+
+	CurrentStreak     int64
+	LongestStreak     int64
+	StreakFreezes     int64
+	StreakFreezesUsed int64
 }
@@ -0,0 +1,87 @@
+package entities
+
+import "time"
+
+// EconomySimulationConfig is everything the economy simulator needs to know about a stage,
+// it can be loaded from the database or written by hand in a JSON/YAML file
+type EconomySimulationConfig struct {
+	StageSlug      string                          `json:"stage_slug"`
+	StartingCoin   int64                           `json:"starting_coin"`
+	KitchenConfig  *StageKitchenConfig             `json:"kitchen_config"`
+	CustomerConfig *StageCustomerConfig            `json:"customer_config"`
+	Stations       []SimulationStation             `json:"stations"`
+	PhaseRewards   []KitchenPhaseCompletionRewards `json:"phase_rewards"`
+}
+
+type SimulationStation struct {
+	Slug           string                  `json:"slug"`
+	Name           string                  `json:"name"`
+	InitialCost    int64                   `json:"initial_cost"`
+	InitialProfit  int64                   `json:"initial_profit"`
+	CookingTime    float64                 `json:"cooking_time"`
+	AutoUnlock     bool                    `json:"auto_unlock"`
+	UnlockPhase    int64                   `json:"unlock_phase"`
+	OverrideLevels []FoodItemOverrideLevel `json:"override_levels,omitempty"`
+}
+
+type EconomySimulationParams struct {
+	Strategy    SimulationStrategy
+	MaxDuration time.Duration // Simulated play time after which the run gives up
+	// CustomerLimited caps the earnings by the customer spawn rate like the offline earnings do,
+	// otherwise every table is assumed to always have a customer waiting
+	CustomerLimited bool
+}
+
+type EconomySimulationResult struct {
+	StageSlug             string                   `json:"stage_slug"`
+	Strategy              string                   `json:"strategy"`
+	ReachedMaxLevel       bool                     `json:"reached_max_level"`
+	TimeToMaxLevelSeconds *float64                 `json:"time_to_max_level_seconds"`
+	DurationSeconds       float64                  `json:"duration_seconds"`
+	StopReason            string                   `json:"stop_reason"`
+	TotalCoinsEarned      int64                    `json:"total_coins_earned"`
+	TotalCoinsSpent       int64                    `json:"total_coins_spent"`
+	FinalCoins            int64                    `json:"final_coins"`
+	Phases                []SimulationPhaseSummary `json:"phases"`
+	Rewards               []SimulationRewardGrant  `json:"rewards"`
+	Events                []SimulationEvent        `json:"events"`
+}
+
+type SimulationPhaseSummary struct {
+	Phase            int64   `json:"phase"`
+	ReachedAtSeconds float64 `json:"reached_at_seconds"`
+	DurationSeconds  float64 `json:"duration_seconds"`
+	CoinsEarned      int64   `json:"coins_earned"`
+	CoinsSpent       int64   `json:"coins_spent"`
+	Purchases        int64   `json:"purchases"`
+	CoinsPerSecond   float64 `json:"coins_per_second"` // Earning rate when the phase was reached
+}
+
+type SimulationRewardGrant struct {
+	Phase            int64   `json:"phase"`
+	RewardSlug       string  `json:"reward_slug"`
+	RewardType       string  `json:"reward_type"`
+	Amount           int64   `json:"amount"`
+	GrantedAtSeconds float64 `json:"granted_at_seconds"`
+}
+
+type SimulationEventType string
+
+const (
+	SimulationEventUnlock  SimulationEventType = "unlock"
+	SimulationEventUpgrade SimulationEventType = "upgrade"
+	SimulationEventPhase   SimulationEventType = "phase"
+	SimulationEventReward  SimulationEventType = "reward"
+)
+
+// SimulationEvent is a single point of the coin curve
+type SimulationEvent struct {
+	TimeSeconds    float64             `json:"time_seconds"`
+	Type           SimulationEventType `json:"type"`
+	Station        string              `json:"station,omitempty"`
+	Level          int64               `json:"level,omitempty"`
+	Phase          int64               `json:"phase"`
+	Amount         int64               `json:"amount"` // Paid for purchases, granted for rewards
+	Coins          int64               `json:"coins"`  // Balance after the event
+	CoinsPerSecond float64             `json:"coins_per_second"`
+}
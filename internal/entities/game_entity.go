@@ -9,8 +9,9 @@ const (
 )
 
 type Game struct {
-	DailyRewardAvailable bool         `json:"daily_reward_available"`
-	UserBalance          *UserBalance `json:"user_balance"`
+	DailyRewardAvailable bool            `json:"daily_reward_available"`
+	UserBalance          *UserBalance    `json:"user_balance"`
+	OfflineEarning       *OfflineEarning `json:"offline_earning,omitempty"`
 }
 
 type UserGameStage struct {
@@ -39,7 +40,7 @@ type UpgradeKitchenStation struct {
 	CurrentLevel      int64            `json:"current_level"`
 	CurrentProfit     int64            `json:"current_profit"`
 	CurrentPrepTime   float64          `json:"current_prep_time"`
-	ProfitPerSecond   float64          `json:"profit_per_second"`
+	ProfitPerSecond   float64          `json:"profit_per_second"` // includes the active boosts
 	CurrentTableCount int64            `json:"current_table_count"`
 	CurrentRewards    *PhaseRewardInfo `json:"current_reward"`
 
@@ -62,6 +63,37 @@ type UpgradeKitchenStation struct {
 
 	// Rewards
 	GrantedRewards []PhaseRewardInfo `json:"granted_rewards,omitempty"`
+
+	// Bulk upgrade
+	LevelsUpgraded   int64                 `json:"levels_upgraded"`
+	PhaseTransitions []PhaseTransitionInfo `json:"phase_transitions,omitempty"`
+}
+
+// StationUpgradePreview is the projected outcome of upgrading a station over a range of levels
+type StationUpgradePreview struct {
+	Name         string                `json:"name"`
+	Slug         string                `json:"slug"`
+	CurrentLevel int64                 `json:"current_level"`
+	MaxLevel     int64                 `json:"max_level"`
+	Levels       []UpgradePreviewLevel `json:"levels"`
+}
+
+type UpgradePreviewLevel struct {
+	Level           int64   `json:"level"`
+	Cost            int64   `json:"cost"`
+	CumulativeCost  int64   `json:"cumulative_cost"` // Cost of every upgrade from the current level up to this one
+	Profit          int64   `json:"profit"`
+	PreparationTime float64 `json:"preparation_time"`
+	Phase           int64   `json:"phase"`
+	TableCount      int64   `json:"table_count"`
+}
+
+// PhaseTransitionInfo is a kitchen phase change that happened while upgrading a station
+type PhaseTransitionInfo struct {
+	Level         int64 `json:"level"`
+	FromPhase     int64 `json:"from_phase"`
+	ToPhase       int64 `json:"to_phase"`
+	NewTableCount int64 `json:"new_table_count,omitempty"`
 }
 
 type UnlockKitchenStation struct {
@@ -71,7 +103,7 @@ type UnlockKitchenStation struct {
 	CurrentLevel      int64            `json:"current_level"`
 	CurrentProfit     int64            `json:"current_profit"`
 	CurrentPrepTime   float64          `json:"current_prep_time"`
-	ProfitPerSecond   float64          `json:"profit_per_second"`
+	ProfitPerSecond   float64          `json:"profit_per_second"` // includes the active boosts
 	CurrentTableCount int64            `json:"current_table_count"`
 	CurrentRewards    *PhaseRewardInfo `json:"current_reward"`
 
@@ -15,6 +15,31 @@ type GameStage struct {
 	UpdatedAt    time.Time `json:"-"`
 }
 
+// StageCoinGrant is the result of crediting the starting coins or the prize of a stage
+type StageCoinGrant struct {
+	Granted        int64           `json:"granted"` // 0 when it was already granted before
+	Balance        *UserBalance    `json:"balance"`
+	OfflineEarning *OfflineEarning `json:"offline_earning"` // Idle income of the previous session, nil when there was none
+}
+
+// StageCompletionRequirement is a condition the player has to meet before the stage can be completed
+type StageCompletionRequirement struct {
+	ID        int64                `json:"id"`
+	StageID   int64                `json:"stage_id"`
+	Type      StageRequirementType `json:"type"`
+	Target    int64                `json:"target"`
+	CreatedAt time.Time            `json:"-"`
+	UpdatedAt time.Time            `json:"-"`
+}
+
+// StageRequirementProgress is a completion requirement evaluated against the player progression
+type StageRequirementProgress struct {
+	Type    StageRequirementType `json:"type"`
+	Target  int64                `json:"target"`
+	Current int64                `json:"current"`
+	IsMet   bool                 `json:"is_met"`
+}
+
 type StageCustomerConfig struct {
 	ID                      int64     `json:"id"`
 	StageID                 int64     `json:"stage_id"`
@@ -65,4 +90,5 @@ type GameStageConfig struct {
 	CameraConfig       *StageCameraConfig
 	KitchenPhaseReward []KitchenPhaseCompletionRewards
 	UserProgress       *UserKitchenStageProgression
+	Requirements       []StageRequirementProgress
 }
@@ -0,0 +1,9 @@
+package entities
+
+// IdempotentResponse is the first response stored for an Idempotency-Key, replayed on retries
+type IdempotentResponse struct {
+	Fingerprint string `json:"fingerprint"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
@@ -3,10 +3,11 @@ package entities
 import "time"
 
 type KitchenStation struct {
-	ID         int64 `json:"id"`
-	StageID    int64 `json:"stage_id"`
-	FoodItemID int64 `json:"food_item_id"`
-	AutoUnlock bool  `json:"auto_unlock"`
+	ID          int64 `json:"id"`
+	StageID     int64 `json:"stage_id"`
+	FoodItemID  int64 `json:"food_item_id"`
+	AutoUnlock  bool  `json:"auto_unlock"`
+	UnlockPhase int64 `json:"unlock_phase"` // Kitchen phase the player has to reach before unlocking
 
 	// Additional field that didn't store into db
 	FoodItemSlug  string  `json:"food_item_slug"`
@@ -49,4 +50,7 @@ type PhaseRewardInfo struct {
 	RewardSlug  string `json:"reward_slug"`
 	RewardName  string `json:"reward_name"`
 	Amount      int64  `json:"amount"`
+
+	// Items are what a bundle reward granted
+	Items []RewardBundleItem `json:"items,omitempty"`
 }
@@ -0,0 +1,41 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+type MailAudience string
+
+const (
+	// MailAudienceUsers sends the mail to a list of users
+	MailAudienceUsers MailAudience = "users"
+
+	// MailAudienceEveryone sends the mail to every player that signed up before it was sent
+	MailAudienceEveryone MailAudience = "everyone"
+)
+
+func (a MailAudience) String() string {
+	return string(a)
+}
+
+func (a MailAudience) IsValid() bool {
+	switch a {
+	case MailAudienceUsers,
+		MailAudienceEveryone:
+		return true
+	}
+	return false
+}
+
+func ParseMailAudience(s string) (MailAudience, error) {
+	audience := MailAudience(s)
+	if !audience.IsValid() {
+		return "", apperror.ErrorInvalidRequest("mail audience:", s)
+	}
+	return audience, nil
+}
+
+func AllMailAudience() []MailAudience {
+	return []MailAudience{
+		MailAudienceUsers,
+		MailAudienceEveryone,
+	}
+}
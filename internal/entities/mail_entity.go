@@ -0,0 +1,41 @@
+package entities
+
+import "time"
+
+// Mail is a message from the team to players, the rewards attached to it are claimed by the player
+type Mail struct {
+	ID          int64        `json:"id"`
+	Title       string       `json:"title"`
+	Body        string       `json:"body"`
+	Audience    MailAudience `json:"audience"`
+	Attachments []Reward     `json:"attachments"`
+	ExpiresAt   *time.Time   `json:"expires_at"`
+	CreatedBy   *int64       `json:"created_by"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+
+	// UserIDs are the recipients when sending to a list of users
+	UserIDs []int64 `json:"-"`
+
+	// Recipients is how many users the mail was sent to, only counted for a list of users
+	Recipients int64 `json:"recipients"`
+	Claims     int64 `json:"claims"`
+}
+
+// IsExpiredAt reports whether the mail was removed from the mailboxes at t
+func (m *Mail) IsExpiredAt(t time.Time) bool {
+	return m.ExpiresAt != nil && !m.ExpiresAt.After(t)
+}
+
+// UserMail is a mail in the mailbox of a player
+type UserMail struct {
+	Mail
+
+	ReadAt    *time.Time `json:"read_at"`
+	ClaimedAt *time.Time `json:"claimed_at"`
+}
+
+// IsClaimable reports whether the mail has attachments that were not claimed yet
+func (m *UserMail) IsClaimable() bool {
+	return len(m.Attachments) > 0 && m.ClaimedAt == nil
+}
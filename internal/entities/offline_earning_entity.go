@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// OfflineEarning is the idle income granted for the time the player was away
+type OfflineEarning struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	StageID        *int64     `json:"stage_id"`
+	Coins          int64      `json:"coins"`
+	CoinsPerSecond float64    `json:"coins_per_second"`
+	ElapsedSeconds float64    `json:"elapsed_seconds"`
+	IsCapped       bool       `json:"is_capped"` // The player was away longer than the offline cap
+	BonusCoins     int64      `json:"bonus_coins"`
+	BonusGrantedAt *time.Time `json:"bonus_granted_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (o *OfflineEarning) IsBonusGranted() bool {
+	return o.BonusGrantedAt != nil
+}
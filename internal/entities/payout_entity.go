@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Payout is a reward paid outside of the game, e.g. GOPAY_COIN. It is written in the same
+// transaction as the claim and sent to the payout provider by the worker afterwards
+type Payout struct {
+	ID            int64                `json:"id"`
+	UserID        int64                `json:"user_id"`
+	RewardID      int64                `json:"reward_id"`
+	RewardSlug    string               `json:"reward_slug"`
+	Currency      string               `json:"currency"`
+	Amount        int64                `json:"amount"`
+	Source        CurrencyLedgerSource `json:"source"`
+	Status        PayoutStatus         `json:"status"`
+	Attempts      int64                `json:"attempts"`
+	NextAttemptAt time.Time            `json:"next_attempt_at"`
+	LastError     string               `json:"last_error"`
+	ProviderRef   string               `json:"provider_ref"`
+	CompletedAt   *time.Time           `json:"completed_at"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+// IdempotencyKey is sent with every attempt of the payout, so the provider pays it at most once
+func (p *Payout) IdempotencyKey() string {
+	return fmt.Sprintf("cat-cafe-payout-%d", p.ID)
+}
+
+// PayoutRun is the outcome of a single pass of the payout worker
+type PayoutRun struct {
+	Claimed   int `json:"claimed"`
+	Succeeded int `json:"succeeded"`
+	Retrying  int `json:"retrying"`
+	Dead      int `json:"dead"`
+}
@@ -0,0 +1,46 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+type PayoutStatus string
+
+const (
+	// PayoutStatusPending is waiting for the worker, including payouts scheduled for a retry
+	PayoutStatusPending PayoutStatus = "pending"
+
+	// PayoutStatusSucceeded was accepted by the provider
+	PayoutStatusSucceeded PayoutStatus = "succeeded"
+
+	// PayoutStatusDead was rejected by the provider or ran out of attempts, it is only retried manually
+	PayoutStatusDead PayoutStatus = "dead"
+)
+
+func (s PayoutStatus) String() string {
+	return string(s)
+}
+
+func (s PayoutStatus) IsValid() bool {
+	switch s {
+	case PayoutStatusPending,
+		PayoutStatusSucceeded,
+		PayoutStatusDead:
+		return true
+	}
+	return false
+}
+
+func ParsePayoutStatus(s string) (PayoutStatus, error) {
+	status := PayoutStatus(s)
+	if !status.IsValid() {
+		return "", apperror.ErrorInvalidRequest("payout status:", s)
+	}
+	return status, nil
+}
+
+func AllPayoutStatus() []PayoutStatus {
+	return []PayoutStatus{
+		PayoutStatusPending,
+		PayoutStatusSucceeded,
+		PayoutStatusDead,
+	}
+}
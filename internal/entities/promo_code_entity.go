@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	MinPromoCodeLength       = 4
+	MaxPromoCodeLength       = 64
+	MaxPromoCodePrefixLength = 16
+)
+
+// PromoCode is a code players redeem for a reward. A code without MaxRedemptions can be redeemed by
+// any number of players, each of them up to MaxPerUser times
+type PromoCode struct {
+	ID             int64      `json:"id"`
+	Code           string     `json:"code"`
+	BatchID        *int64     `json:"batch_id"`
+	RewardID       int64      `json:"reward_id"`
+	Reward         *Reward    `json:"reward"`
+	MaxRedemptions *int64     `json:"max_redemptions"`
+	MaxPerUser     int64      `json:"max_per_user"`
+	Redemptions    int64      `json:"redemptions"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	IsActive       bool       `json:"is_active"`
+	CreatedBy      *int64     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// IsSingleUse reports whether the code can be redeemed once in total
+func (p *PromoCode) IsSingleUse() bool {
+	return p.MaxRedemptions != nil && *p.MaxRedemptions == 1
+}
+
+// IsValidAt reports whether t is in the validity window of the code
+func (p *PromoCode) IsValidAt(t time.Time) bool {
+	if p.StartsAt != nil && t.Before(*p.StartsAt) {
+		return false
+	}
+
+	return p.EndsAt == nil || t.Before(*p.EndsAt)
+}
+
+// IsExhausted reports whether the global cap of the code was reached
+func (p *PromoCode) IsExhausted() bool {
+	return p.MaxRedemptions != nil && p.Redemptions >= *p.MaxRedemptions
+}
+
+// PromoCodeBatch is a batch of unique single-use codes generated at once for the same reward
+type PromoCodeBatch struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Size      int64      `json:"size"`
+	RewardID  int64      `json:"reward_id"`
+	Reward    *Reward    `json:"reward"`
+	StartsAt  *time.Time `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+	CreatedBy *int64     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PromoCodeRedemption is the outcome of a player redeeming a code
+type PromoCodeRedemption struct {
+	Code   *PromoCode `json:"code"`
+	Reward *Reward    `json:"reward"`
+}
+
+// NormalizePromoCode codes are stored in upper case, players can type them in any case
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsValidPromoCode reports whether the normalized code only has letters, digits and dashes
+func IsValidPromoCode(code string) bool {
+	if len(code) < MinPromoCodeLength || len(code) > MaxPromoCodeLength {
+		return false
+	}
+
+	return isPromoCodeCharacters(code)
+}
+
+// IsValidPromoCodePrefix reports whether the normalized prefix of a batch only has letters, digits and dashes
+func IsValidPromoCodePrefix(prefix string) bool {
+	return len(prefix) <= MaxPromoCodePrefixLength && isPromoCodeCharacters(prefix)
+}
+
+func isPromoCodeCharacters(s string) bool {
+	for _, c := range s {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -5,14 +5,17 @@ import "fmt"
 type RewardTypeSlug string
 
 const (
-	RewardTypeGoPayCoin RewardTypeSlug = "GOPAY_COIN"
-	RewardTypeCoin      RewardTypeSlug = "COIN"
-	RewardTypeGem       RewardTypeSlug = "GEM"
+	RewardTypeGoPayCoin    RewardTypeSlug = "GOPAY_COIN"
+	RewardTypeCoin         RewardTypeSlug = "COIN"
+	RewardTypeGem          RewardTypeSlug = "GEM"
+	RewardTypeBoost        RewardTypeSlug = "BOOST"
+	RewardTypeStreakFreeze RewardTypeSlug = "STREAK_FREEZE"
+	RewardTypeBundle       RewardTypeSlug = "BUNDLE"
 )
 
 func ToRewardType(s string) (RewardTypeSlug, error) {
 	switch RewardTypeSlug(s) {
-	case RewardTypeGoPayCoin, RewardTypeCoin, RewardTypeGem:
+	case RewardTypeGoPayCoin, RewardTypeCoin, RewardTypeGem, RewardTypeBoost, RewardTypeStreakFreeze, RewardTypeBundle:
 		return RewardTypeSlug(s), nil
 	default:
 		return "", fmt.Errorf("invalid reward type: %s", s)
@@ -22,7 +25,7 @@ func ToRewardType(s string) (RewardTypeSlug, error) {
 // IsValid checks if the reward type is valid
 func (e RewardTypeSlug) IsValid() bool {
 	switch e {
-	case RewardTypeGoPayCoin, RewardTypeCoin, RewardTypeGem:
+	case RewardTypeGoPayCoin, RewardTypeCoin, RewardTypeGem, RewardTypeBoost, RewardTypeStreakFreeze, RewardTypeBundle:
 		return true
 	default:
 		return false
@@ -44,6 +47,21 @@ func (e RewardTypeSlug) IsSentExternally() bool {
 	return e == RewardTypeGoPayCoin
 }
 
+// IsBoost return true if the reward type activates a boost, the boost is linked to the reward
+func (e RewardTypeSlug) IsBoost() bool {
+	return e == RewardTypeBoost
+}
+
+// IsStreakFreeze return true if the reward type grants streak freezes, the reward amount is how many
+func (e RewardTypeSlug) IsStreakFreeze() bool {
+	return e == RewardTypeStreakFreeze
+}
+
+// IsBundle return true if the reward type grants the items of the bundle instead of an amount
+func (e RewardTypeSlug) IsBundle() bool {
+	return e == RewardTypeBundle
+}
+
 // ToUserBalance returns the user balance type
 func (e RewardTypeSlug) ToUserBalance() UserBalanceType {
 	switch e {
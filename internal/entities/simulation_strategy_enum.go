@@ -0,0 +1,39 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+// SimulationStrategy decides what the simulated player buys next
+type SimulationStrategy string
+
+const (
+	SimulationStrategyCheapestFirst SimulationStrategy = "cheapest_first"
+	SimulationStrategyBestROI       SimulationStrategy = "best_roi"
+)
+
+func (s SimulationStrategy) String() string {
+	return string(s)
+}
+
+func (s SimulationStrategy) IsValid() bool {
+	switch s {
+	case SimulationStrategyCheapestFirst,
+		SimulationStrategyBestROI:
+		return true
+	}
+	return false
+}
+
+func ParseSimulationStrategy(s string) (SimulationStrategy, error) {
+	strategy := SimulationStrategy(s)
+	if !strategy.IsValid() {
+		return "", apperror.ErrorInvalidRequest("simulation strategy:", s)
+	}
+	return strategy, nil
+}
+
+func AllSimulationStrategy() []SimulationStrategy {
+	return []SimulationStrategy{
+		SimulationStrategyCheapestFirst,
+		SimulationStrategyBestROI,
+	}
+}
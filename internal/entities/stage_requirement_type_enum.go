@@ -0,0 +1,41 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+type StageRequirementType string
+
+const (
+	StageRequirementAllStationsLevel  StageRequirementType = "all_stations_level"
+	StageRequirementKitchenPhase      StageRequirementType = "kitchen_phase"
+	StageRequirementUpgradesPurchased StageRequirementType = "upgrades_purchased"
+)
+
+func (t StageRequirementType) String() string {
+	return string(t)
+}
+
+func (t StageRequirementType) IsValid() bool {
+	switch t {
+	case StageRequirementAllStationsLevel,
+		StageRequirementKitchenPhase,
+		StageRequirementUpgradesPurchased:
+		return true
+	}
+	return false
+}
+
+func ParseStageRequirementType(s string) (StageRequirementType, error) {
+	requirementType := StageRequirementType(s)
+	if !requirementType.IsValid() {
+		return "", apperror.ErrorInvalidRequest("stage requirement type:", s)
+	}
+	return requirementType, nil
+}
+
+func AllStageRequirementType() []StageRequirementType {
+	return []StageRequirementType{
+		StageRequirementAllStationsLevel,
+		StageRequirementKitchenPhase,
+		StageRequirementUpgradesPurchased,
+	}
+}
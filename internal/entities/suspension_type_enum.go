@@ -0,0 +1,38 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+type SuspensionType string
+
+const (
+	SuspensionTypeSuspension SuspensionType = "suspension"
+	SuspensionTypeBan        SuspensionType = "ban"
+)
+
+func (t SuspensionType) String() string {
+	return string(t)
+}
+
+func (t SuspensionType) IsValid() bool {
+	switch t {
+	case SuspensionTypeSuspension,
+		SuspensionTypeBan:
+		return true
+	}
+	return false
+}
+
+func ParseSuspensionType(s string) (SuspensionType, error) {
+	suspensionType := SuspensionType(s)
+	if !suspensionType.IsValid() {
+		return "", apperror.ErrorInvalidRequest("suspension type:", s)
+	}
+	return suspensionType, nil
+}
+
+func AllSuspensionType() []SuspensionType {
+	return []SuspensionType{
+		SuspensionTypeSuspension,
+		SuspensionTypeBan,
+	}
+}
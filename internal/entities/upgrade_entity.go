@@ -25,6 +25,30 @@ type UpgradeEffect struct {
 	TargetName string
 }
 
+// UpgradePrerequisite is a condition the player has to meet before the upgrade can be purchased.
+// Depending on the type it needs another upgrade purchased, a station level or a kitchen phase
+type UpgradePrerequisite struct {
+	ID                  int64                   `json:"id"`
+	UpgradeID           int64                   `json:"upgrade_id"`
+	Type                UpgradePrerequisiteType `json:"type"`
+	RequiredUpgradeID   int64                   `json:"required_upgrade_id"`
+	RequiredUpgradeSlug string                  `json:"required_upgrade_slug"`
+	FoodItemID          int64                   `json:"food_item_id"`
+	FoodItemSlug        string                  `json:"food_item_slug"`
+	Target              int64                   `json:"target"`
+	CreatedAt           time.Time               `json:"-"`
+	UpdatedAt           time.Time               `json:"-"`
+}
+
+// UpgradeLockReason is an unmet prerequisite of an upgrade evaluated against the player progression
+type UpgradeLockReason struct {
+	Type    UpgradePrerequisiteType `json:"type"`
+	Upgrade string                  `json:"upgrade,omitempty"` // Slug of the upgrade to purchase first
+	Station string                  `json:"station,omitempty"` // Slug of the station to level up
+	Target  int64                   `json:"target,omitempty"`
+	Current int64                   `json:"current,omitempty"`
+}
+
 func (e *UpgradeEffect) CalculateNewValue(current float64) float64 {
 	isReduction := e.Type == UpgradeEffectTypeReduceCookingTime
 
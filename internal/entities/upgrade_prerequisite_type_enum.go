@@ -0,0 +1,41 @@
+package entities
+
+import "github.com/winartodev/cat-cafe/pkg/apperror"
+
+type UpgradePrerequisiteType string
+
+const (
+	UpgradePrerequisiteUpgrade      UpgradePrerequisiteType = "upgrade"
+	UpgradePrerequisiteStationLevel UpgradePrerequisiteType = "station_level"
+	UpgradePrerequisiteKitchenPhase UpgradePrerequisiteType = "kitchen_phase"
+)
+
+func (t UpgradePrerequisiteType) String() string {
+	return string(t)
+}
+
+func (t UpgradePrerequisiteType) IsValid() bool {
+	switch t {
+	case UpgradePrerequisiteUpgrade,
+		UpgradePrerequisiteStationLevel,
+		UpgradePrerequisiteKitchenPhase:
+		return true
+	}
+	return false
+}
+
+func ParseUpgradePrerequisiteType(s string) (UpgradePrerequisiteType, error) {
+	prerequisiteType := UpgradePrerequisiteType(s)
+	if !prerequisiteType.IsValid() {
+		return "", apperror.ErrorInvalidRequest("upgrade prerequisite type:", s)
+	}
+	return prerequisiteType, nil
+}
+
+func AllUpgradePrerequisiteType() []UpgradePrerequisiteType {
+	return []UpgradePrerequisiteType{
+		UpgradePrerequisiteUpgrade,
+		UpgradePrerequisiteStationLevel,
+		UpgradePrerequisiteKitchenPhase,
+	}
+}
@@ -9,9 +9,13 @@ type User struct {
 	ExternalID   string       `json:"external_id"`
 	Username     string       `json:"username"`
 	Email        string       `json:"email"`
+	DisplayName  string       `json:"display_name"`
+	AvatarURL    string       `json:"avatar_url"`
+	Timezone     string       `json:"timezone"`
 	PasswordHash string       `json:"-"`
 	IsActive     bool         `json:"is_active"`
 	UserBalance  *UserBalance `json:"balance"`
+	LastLoginAt  *time.Time   `json:"-"`
 	CreatedAt    time.Time    `json:"-"`
 	UpdatedAt    time.Time    `json:"-"`
 }
@@ -22,12 +26,15 @@ type UserBalance struct {
 }
 
 type UserCache struct {
-	UserID     int64             `json:"user_id"`
-	ExternalID string            `json:"external_id"`
-	Username   string            `json:"username"`
-	Email      string            `json:"email"`
-	IsActive   bool              `json:"is_active"`
-	Balance    *UserBalanceCache `json:"balance,omitempty"`
+	UserID      int64             `json:"user_id"`
+	ExternalID  string            `json:"external_id"`
+	Username    string            `json:"username"`
+	Email       string            `json:"email"`
+	DisplayName string            `json:"display_name"`
+	AvatarURL   string            `json:"avatar_url"`
+	Timezone    string            `json:"timezone"`
+	IsActive    bool              `json:"is_active"`
+	Balance     *UserBalanceCache `json:"balance,omitempty"`
 }
 
 type UserBalanceCache struct {
@@ -52,6 +59,7 @@ type UserKitchenStageProgression struct {
 	StationLevels    map[string]UserStationLevel   `json:"station_levels"`
 	UnlockedStations []string                      `json:"unlocked_stations"`
 	StationUpgrades  map[string]UserStationUpgrade `json:"station_upgrades"`
+	KitchenUpgrades  UserStationUpgrade            `json:"kitchen_upgrades"` // Stage-wide helpers, customers and all food multipliers
 
 	NextLevelStats map[string]UserStationLevel `json:"next_level_stats,omitempty"` // Calculated, not stored in DB
 }
@@ -88,23 +96,55 @@ type UserStationUpgrade struct {
 	CustomerCount     int64   `json:"customer_count"`
 }
 
+// EffectiveStationUpgrade combines the upgrades of the station with the all food multipliers of the kitchen.
+// Stage-wide helpers and customers are not included, they belong to the kitchen and not to every station
+func (p *UserKitchenStageProgression) EffectiveStationUpgrade(slug string) UserStationUpgrade {
+	if p == nil {
+		return UserStationUpgrade{}
+	}
+
+	upgrade := p.StationUpgrades[slug]
+	upgrade.ProfitBonus = combineUpgradeMultiplier(upgrade.ProfitBonus, p.KitchenUpgrades.ProfitBonus)
+	upgrade.ReduceCookingTime = combineUpgradeMultiplier(upgrade.ReduceCookingTime, p.KitchenUpgrades.ReduceCookingTime)
+
+	return upgrade
+}
+
+// combineUpgradeMultiplier multiplies two upgrade multipliers, 0 means the multiplier was never set
+func combineUpgradeMultiplier(a float64, b float64) float64 {
+	if a == 0 {
+		return b
+	}
+
+	if b == 0 {
+		return a
+	}
+
+	return a * b
+}
+
 type UserStageUpgrade struct {
 	UserID             int64      `json:"user_id"`
 	StageID            int64      `json:"stage_id"`
 	GameStageUpgradeID int64      `json:"game_stage_upgrade_id"`
 	PurchasedAt        *time.Time `json:"purchased_at"`
 
-	IsPurchased bool    `json:"is_purchased"`
-	Upgrade     Upgrade `json:"upgrade"`
+	IsPurchased bool                `json:"is_purchased"`
+	IsLocked    bool                `json:"is_locked"`
+	LockReasons []UpgradeLockReason `json:"lock_reasons"`
+	Upgrade     Upgrade             `json:"upgrade"`
 }
 
 func (u *User) ToCache() *UserCache {
 	cache := &UserCache{
-		UserID:     u.ID,
-		ExternalID: u.ExternalID,
-		Username:   u.Username,
-		Email:      u.Email,
-		IsActive:   u.IsActive,
+		UserID:      u.ID,
+		ExternalID:  u.ExternalID,
+		Username:    u.Username,
+		Email:       u.Email,
+		DisplayName: u.DisplayName,
+		AvatarURL:   u.AvatarURL,
+		Timezone:    u.Timezone,
+		IsActive:    u.IsActive,
 	}
 
 	if u.UserBalance != nil {
@@ -119,11 +159,14 @@ func (u *User) ToCache() *UserCache {
 
 func UserFromCache(cache *UserCache) *User {
 	user := &User{
-		ID:         cache.UserID,
-		ExternalID: cache.ExternalID,
-		Username:   cache.Username,
-		Email:      cache.Email,
-		IsActive:   cache.IsActive,
+		ID:          cache.UserID,
+		ExternalID:  cache.ExternalID,
+		Username:    cache.Username,
+		Email:       cache.Email,
+		DisplayName: cache.DisplayName,
+		AvatarURL:   cache.AvatarURL,
+		Timezone:    cache.Timezone,
+		IsActive:    cache.IsActive,
 	}
 
 	if cache.Balance != nil {
@@ -135,3 +178,16 @@ func UserFromCache(cache *UserCache) *User {
 
 	return user
 }
+
+// BalanceSyncDiscrepancy records a sync where the client claimed more coins than the server allows
+type BalanceSyncDiscrepancy struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	StageID        *int64     `json:"stage_id"`
+	CoinsClaimed   int64      `json:"coins_claimed"`
+	CoinsAllowed   int64      `json:"coins_allowed"`
+	CoinsPerSecond float64    `json:"coins_per_second"`
+	ElapsedSeconds float64    `json:"elapsed_seconds"`
+	LastSyncAt     *time.Time `json:"last_sync_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
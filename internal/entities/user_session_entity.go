@@ -0,0 +1,63 @@
+package entities
+
+import "time"
+
+const (
+	SessionRevokedByLogout     = "logout"
+	SessionRevokedByUser       = "user_revoked"
+	SessionRevokedByTokenReuse = "refresh_token_reused"
+	SessionRevokedBySuspension = "suspended"
+	maxSessionDeviceNameLength = 100
+)
+
+// SessionDevice describes the client a session was created from
+type SessionDevice struct {
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+}
+
+// UserSession is one logged-in device of a player
+type UserSession struct {
+	ID           int64      `json:"id"`
+	UserID       int64      `json:"user_id"`
+	DeviceName   string     `json:"device_name"`
+	UserAgent    string     `json:"user_agent"`
+	IPAddress    string     `json:"ip_address"`
+	LastUsedAt   time.Time  `json:"last_used_at"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	RevokeReason string     `json:"revoke_reason"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// UserSessionRefreshToken only keeps the hash, the raw token is returned to the client once
+type UserSessionRefreshToken struct {
+	ID        int64      `json:"id"`
+	SessionID int64      `json:"session_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// AuthTokens is the access and refresh token pair returned on login and refresh
+type AuthTokens struct {
+	SessionID             int64     `json:"session_id"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+func (s *UserSession) IsActive(now time.Time) bool {
+	return s != nil && s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+func (d SessionDevice) Normalize() SessionDevice {
+	if len(d.DeviceName) > maxSessionDeviceNameLength {
+		d.DeviceName = d.DeviceName[:maxSessionDeviceNameLength]
+	}
+
+	return d
+}
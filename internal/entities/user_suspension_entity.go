@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+// UserSuspension blocks a player from the game until it expires or is lifted, no expiry means permanent
+type UserSuspension struct {
+	ID        int64          `json:"id"`
+	UserID    int64          `json:"user_id"`
+	Type      SuspensionType `json:"type"`
+	Reason    string         `json:"reason"`
+	ExpiresAt *time.Time     `json:"expires_at"`
+	CreatedBy *int64         `json:"created_by"`
+	LiftedAt  *time.Time     `json:"lifted_at"`
+	LiftedBy  *int64         `json:"lifted_by"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+func (s *UserSuspension) IsActive(now time.Time) bool {
+	return s != nil && s.LiftedAt == nil && (s.ExpiresAt == nil || now.Before(*s.ExpiresAt))
+}
+
+// Err returns the error sent to the suspended player
+func (s *UserSuspension) Err() error {
+	if s.Type == SuspensionTypeBan {
+		return apperror.ErrorAccountBanned(s.Reason, s.ExpiresAt)
+	}
+
+	return apperror.ErrorAccountSuspended(s.Reason, s.ExpiresAt)
+}
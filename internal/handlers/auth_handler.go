@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"context"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/internal/usecase"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
 	"github.com/winartodev/cat-cafe/pkg/helper"
@@ -27,12 +30,75 @@ func (a *AuthHandler) Login(c *fiber.Ctx) error {
 		return response.FailedResponse(c, a.errorHandler, apperror.ErrBadRequest)
 	}
 
-	token, user, gameData, err := a.AuthUseCase.Login(c.Context(), authCode)
+	tokens, user, gameData, err := a.AuthUseCase.Login(c.Context(), authCode, c.Query("timezone"), a.sessionDevice(c))
+	if err != nil {
+		return response.FailedResponse(c, a.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Login Success", dto.ToLoginResponse(tokens, user, gameData), nil)
+}
+
+func (a *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var request dto.RefreshTokenRequest
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, a.errorHandler, apperror.ErrBadRequest)
+	}
+
+	tokens, err := a.AuthUseCase.Refresh(c.Context(), request.RefreshToken, a.sessionDevice(c))
 	if err != nil {
 		return response.FailedResponse(c, a.errorHandler, err)
 	}
 
-	return response.SuccessResponse(c, fiber.StatusOK, "Login Success", dto.ToLoginResponse(token, user, gameData), nil)
+	return response.SuccessResponse(c, fiber.StatusOK, "Token Successfully Refreshed", dto.ToRefreshTokenResponse(tokens), nil)
+}
+
+// GetJWKS publishes the public keys so other services can validate access tokens
+func (a *AuthHandler) GetJWKS(c *fiber.Ctx) error {
+	res, err := a.AuthUseCase.GetJWKS(c.Context())
+	if err != nil {
+		return response.FailedResponse(c, a.errorHandler, err)
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+	return c.Status(fiber.StatusOK).JSON(res)
+}
+
+func (a *AuthHandler) GetSessions(c *fiber.Ctx) error {
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	res, err := a.AuthUseCase.GetSessions(ctx)
+	if err != nil {
+		return response.FailedResponse(c, a.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Sessions Successfully Retrieved", dto.ToSessionsResponse(res, helper.GetSessionID(c)), nil)
+}
+
+func (a *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, a.errorHandler, err)
+	}
+
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	if err := a.AuthUseCase.RevokeSession(ctx, id); err != nil {
+		return response.FailedResponse(c, a.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Session Successfully Revoked", nil, nil)
+}
+
+// sessionDevice the optional X-Device-Name header lets the client label the session
+func (a *AuthHandler) sessionDevice(c *fiber.Ctx) entities.SessionDevice {
+	return entities.SessionDevice{
+		DeviceName: c.Get("X-Device-Name"),
+		UserAgent:  c.Get(fiber.HeaderUserAgent),
+		IPAddress:  c.IP(),
+	}
 }
 
 func (a *AuthHandler) Logout(c *fiber.Ctx) error {
@@ -60,10 +126,13 @@ func (a *AuthHandler) GetUserData(c *fiber.Ctx) error {
 func (a *AuthHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
 	openAuth := open.Group("/auth")
 	openAuth.Post("/login", a.Login)
+	openAuth.Post("/refresh", a.Refresh)
 
 	userAuthGroup := userAuth.Group("/auth")
 	userAuthGroup.Post("/logout", a.Logout)
 	userAuthGroup.Get("/me", a.GetUserData)
+	userAuthGroup.Get("/sessions", a.GetSessions)
+	userAuthGroup.Delete("/sessions/:id", a.RevokeSession)
 
 	return nil
 }
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
+	"github.com/winartodev/cat-cafe/internal/usecase"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/response"
+)
+
+type BoostHandler struct {
+	errorHandler *apperror.ErrorHandler
+	boostUC      usecase.BoostUseCase
+}
+
+func NewBoostHandler(boostUC usecase.BoostUseCase) *BoostHandler {
+	return &BoostHandler{
+		errorHandler: apperror.NewErrorHandler(),
+		boostUC:      boostUC,
+	}
+}
+
+func (h *BoostHandler) CreateBoost(c *fiber.Ctx) error {
+	var request dto.BoostRequestDTO
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	if err := request.ValidateRequest(); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	boost, err := h.boostUC.CreateBoost(c.Context(), request.ToEntity())
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Boost Successfully Created", dto.ToBoostResponseDTO(boost), nil)
+}
+
+func (h *BoostHandler) GetBoosts(c *fiber.Ctx) error {
+	params := helper.GetPaginationParams(c)
+
+	boosts, totalRows, err := h.boostUC.GetBoosts(c.Context(), params.Limit, params.Offset)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	data := dto.ToBoostsResponseDTO(boosts)
+	meta := helper.CreatePaginationMeta(params.Page, params.Limit, totalRows)
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Boosts Successfully Retrieved", data, meta)
+}
+
+func (h *BoostHandler) GetBoostByID(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	boost, err := h.boostUC.GetBoostByID(c.Context(), id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Boost Successfully Retrieved", dto.ToBoostResponseDTO(boost), nil)
+}
+
+func (h *BoostHandler) UpdateBoost(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	var request dto.BoostRequestDTO
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	if err := request.ValidateRequest(); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	boost, err := h.boostUC.UpdateBoost(c.Context(), id, request.ToEntity())
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Boost Successfully Updated", dto.ToBoostResponseDTO(boost), nil)
+}
+
+func (h *BoostHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
+	boost := internalAuth.Group("/boosts")
+
+	boost.Post("/", middleware.RequirePermission(entities.PermissionContentWrite), h.CreateBoost)
+	boost.Get("/", middleware.RequirePermission(entities.PermissionContentRead), h.GetBoosts)
+	boost.Get("/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetBoostByID)
+	boost.Put("/:id", middleware.RequirePermission(entities.PermissionContentWrite), h.UpdateBoost)
+
+	return nil
+}
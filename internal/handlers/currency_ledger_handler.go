@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
+	"github.com/winartodev/cat-cafe/internal/usecase"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/response"
+)
+
+// CurrencyLedgerHandler exposes the coin and gem history
+//
+// included: player history, internal history per user, reconciliation
+type CurrencyLedgerHandler struct {
+	CurrencyLedgerUseCase usecase.CurrencyLedgerUseCase
+	errorHandler          *apperror.ErrorHandler
+}
+
+func NewCurrencyLedgerHandler(currencyLedgerUseCase usecase.CurrencyLedgerUseCase) *CurrencyLedgerHandler {
+	return &CurrencyLedgerHandler{
+		CurrencyLedgerUseCase: currencyLedgerUseCase,
+		errorHandler:          apperror.NewErrorHandler(),
+	}
+}
+
+func (h *CurrencyLedgerHandler) GetPlayerLedger(c *fiber.Ctx) error {
+	params := helper.GetPaginationParams(c)
+
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	res, totalRows, err := h.CurrencyLedgerUseCase.GetUserLedger(ctx, params.Limit, params.Offset)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	data := dto.ToCurrencyLedgerEntriesResponse(res)
+	meta := helper.CreatePaginationMeta(params.Page, params.Limit, totalRows)
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Currency Ledger Successfully Retrieved", data, meta)
+}
+
+func (h *CurrencyLedgerHandler) GetUserLedger(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	params := helper.GetPaginationParams(c)
+
+	res, totalRows, err := h.CurrencyLedgerUseCase.GetLedgerByUserID(c.Context(), id, params.Limit, params.Offset)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	data := dto.ToCurrencyLedgerEntriesResponse(res)
+	meta := helper.CreatePaginationMeta(params.Page, params.Limit, totalRows)
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Currency Ledger Successfully Retrieved", data, meta)
+}
+
+func (h *CurrencyLedgerHandler) GetMismatches(c *fiber.Ctx) error {
+	params := helper.GetPaginationParams(c)
+
+	res, totalRows, err := h.CurrencyLedgerUseCase.GetLedgerMismatches(c.Context(), params.Limit, params.Offset)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	data := dto.ToCurrencyLedgerMismatchesResponse(res)
+	meta := helper.CreatePaginationMeta(params.Page, params.Limit, totalRows)
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Currency Ledger Mismatches Successfully Retrieved", data, meta)
+}
+
+func (h *CurrencyLedgerHandler) Reconcile(c *fiber.Ctx) error {
+	res, err := h.CurrencyLedgerUseCase.ReconcileBalances(c.Context())
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Currency Ledger Successfully Reconciled", res, nil)
+}
+
+func (h *CurrencyLedgerHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
+	game := userAuth.Group("/game")
+	game.Get("/ledger", h.GetPlayerLedger)
+
+	ledger := internalAuth.Group("/ledger")
+	ledger.Get("/users/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetUserLedger)
+	ledger.Get("/mismatches", middleware.RequirePermission(entities.PermissionContentRead), h.GetMismatches)
+	ledger.Post("/reconcile", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.Reconcile)
+
+	return nil
+}
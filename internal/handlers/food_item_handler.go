@@ -5,6 +5,8 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
 	"github.com/winartodev/cat-cafe/internal/usecase"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
 	"github.com/winartodev/cat-cafe/pkg/helper"
@@ -111,10 +113,10 @@ func (h *FoodItemHandler) UpdateFood(c *fiber.Ctx) error {
 func (h *FoodItemHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
 	foodItem := internalAuth.Group("/foods")
 
-	foodItem.Post("/", h.CreateFood)
-	foodItem.Get("/", h.GetFoods)
-	foodItem.Get("/:id", h.GetFood)
-	foodItem.Put("/:id", h.UpdateFood)
+	foodItem.Post("/", middleware.RequirePermission(entities.PermissionContentWrite), h.CreateFood)
+	foodItem.Get("/", middleware.RequirePermission(entities.PermissionContentRead), h.GetFoods)
+	foodItem.Get("/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetFood)
+	foodItem.Put("/:id", middleware.RequirePermission(entities.PermissionContentWrite), h.UpdateFood)
 
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/middleware"
 	"github.com/winartodev/cat-cafe/internal/usecase"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
 	"github.com/winartodev/cat-cafe/pkg/helper"
@@ -16,13 +17,21 @@ import (
 type GameHandler struct {
 	GameUseCase        usecase.GameUseCase
 	DailyRewardUseCase usecase.DailyRewardUseCase
+	BoostUseCase       usecase.BoostUseCase
+	MailUseCase        usecase.MailUseCase
+	PromoCodeUseCase   usecase.PromoCodeUseCase
+	middleware         middleware.Middleware
 	errorHandler       *apperror.ErrorHandler
 }
 
-func NewGameHandler(gameUc usecase.GameUseCase, dailyRewardUc usecase.DailyRewardUseCase) *GameHandler {
+func NewGameHandler(gameUc usecase.GameUseCase, dailyRewardUc usecase.DailyRewardUseCase, boostUc usecase.BoostUseCase, mailUc usecase.MailUseCase, promoCodeUc usecase.PromoCodeUseCase, middleware middleware.Middleware) *GameHandler {
 	return &GameHandler{
 		GameUseCase:        gameUc,
 		DailyRewardUseCase: dailyRewardUc,
+		BoostUseCase:       boostUc,
+		MailUseCase:        mailUc,
+		PromoCodeUseCase:   promoCodeUc,
+		middleware:         middleware,
 		errorHandler:       apperror.NewErrorHandler(),
 	}
 }
@@ -48,7 +57,7 @@ func (h *GameHandler) GetDailyRewardStatus(c *fiber.Ctx) error {
 	userID := helper.GetUserID(c)
 	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
 
-	rewards, dailyRewardIdx, isNewDay, err := h.DailyRewardUseCase.GetDailyRewardStatus(ctx)
+	status, err := h.DailyRewardUseCase.GetDailyRewardStatus(ctx)
 	if err != nil {
 		if errors.Is(err, apperror.ErrRecordNotFound) {
 			return response.FailedResponse(c, h.errorHandler, err)
@@ -57,19 +66,31 @@ func (h *GameHandler) GetDailyRewardStatus(c *fiber.Ctx) error {
 		return response.FailedResponse(c, h.errorHandler, err)
 	}
 
-	return response.SuccessResponse(c, fiber.StatusOK, "Daily Reward Status Successfully Retrieved", dto.ToDailyRewardStatus(rewards, dailyRewardIdx, isNewDay), nil)
+	return response.SuccessResponse(c, fiber.StatusOK, "Daily Reward Status Successfully Retrieved", dto.ToDailyRewardStatus(status), nil)
 }
 
 func (h *GameHandler) ClaimReward(c *fiber.Ctx) error {
 	userID := helper.GetUserID(c)
 	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
 
-	reward, newBalance, err := h.DailyRewardUseCase.ClaimDailyReward(ctx)
+	claim, newBalance, err := h.DailyRewardUseCase.ClaimDailyReward(ctx)
 	if err != nil {
 		return response.FailedResponse(c, h.errorHandler, err)
 	}
 
-	return response.SuccessResponse(c, fiber.StatusOK, "Daily Reward Claimed Successfully", dto.ToClaimDailyRewardResponse(reward, newBalance), nil)
+	return response.SuccessResponse(c, fiber.StatusOK, "Daily Reward Claimed Successfully", dto.ToClaimDailyRewardResponse(claim, newBalance), nil)
+}
+
+func (h *GameHandler) PurchaseStreakFreeze(c *fiber.Ctx) error {
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	streakFreezes, newBalance, err := h.DailyRewardUseCase.PurchaseStreakFreeze(ctx)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Streak Freeze Purchased Successfully", dto.ToPurchaseStreakFreezeResponse(streakFreezes, newBalance), nil)
 }
 
 func (h *GameHandler) GetAllStages(c *fiber.Ctx) error {
@@ -105,12 +126,12 @@ func (h *GameHandler) StartGameStage(c *fiber.Ctx) error {
 	userID := helper.GetUserID(c)
 	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
 
-	gameStage, config, nextStage, err := h.GameUseCase.StartGameStage(ctx, slug)
+	gameStage, config, nextStage, grant, err := h.GameUseCase.StartGameStage(ctx, slug)
 	if err != nil {
 		return response.FailedResponse(c, h.errorHandler, err)
 	}
 
-	return response.SuccessResponse(c, fiber.StatusOK, "Game Stage Successfully Started", dto.ToUserDetailGameStageResponse(gameStage, config, nextStage), nil)
+	return response.SuccessResponse(c, fiber.StatusOK, "Game Stage Successfully Started", dto.ToStartGameStageResponse(gameStage, config, nextStage, grant), nil)
 }
 
 func (h *GameHandler) CompleteGameStage(c *fiber.Ctx) error {
@@ -122,12 +143,12 @@ func (h *GameHandler) CompleteGameStage(c *fiber.Ctx) error {
 	userID := helper.GetUserID(c)
 	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
 
-	err = h.GameUseCase.CompleteGameStage(ctx, slug)
+	grant, err := h.GameUseCase.CompleteGameStage(ctx, slug)
 	if err != nil {
 		return response.FailedResponse(c, h.errorHandler, err)
 	}
 
-	return response.SuccessResponse(c, fiber.StatusOK, "Game Stage Successfully Started", nil, nil)
+	return response.SuccessResponse(c, fiber.StatusOK, "Game Stage Successfully Completed", dto.ToCompleteGameStageResponse(grant), nil)
 }
 
 func (h *GameHandler) UpgradeKitchenStation(c *fiber.Ctx) error {
@@ -139,7 +160,11 @@ func (h *GameHandler) UpgradeKitchenStation(c *fiber.Ctx) error {
 	userID := helper.GetUserID(c)
 	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
 
-	res, err := h.GameUseCase.UpgradeKitchenStation(ctx, slug)
+	// ?count=10 upgrades ten levels at once, ?mode=max as many levels as the balance allows
+	count := int64(c.QueryInt("count", 1))
+	toMax := c.Query("mode") == dto.StationUpgradeModeMax
+
+	res, err := h.GameUseCase.UpgradeKitchenStation(ctx, slug, count, toMax)
 	if err != nil {
 		return response.FailedResponse(c, h.errorHandler, err)
 	}
@@ -147,6 +172,26 @@ func (h *GameHandler) UpgradeKitchenStation(c *fiber.Ctx) error {
 	return response.SuccessResponse(c, fiber.StatusOK, "Kitchen Station Successfully Upgraded", dto.ToUserUpgradeKitchenResponse(res), nil)
 }
 
+func (h *GameHandler) PreviewStationUpgrade(c *fiber.Ctx) error {
+	slug, err := helper.GetParam[string](c, "slug")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, apperror.ErrInvalidParam)
+	}
+
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	fromLevel := int64(c.QueryInt("from", 0))
+	toLevel := int64(c.QueryInt("to", 0))
+
+	res, err := h.GameUseCase.PreviewStationUpgrade(ctx, slug, fromLevel, toLevel)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Station Upgrade Preview Successfully Retrieved", dto.ToStationUpgradePreviewResponse(res), nil)
+}
+
 func (h *GameHandler) PurchaseKitchenStation(c *fiber.Ctx) error {
 	slug, err := helper.GetParam[string](c, "slug")
 	if err != nil {
@@ -192,30 +237,168 @@ func (h *GameHandler) PurchaseStageUpgrade(c *fiber.Ctx) error {
 	return response.SuccessResponse(c, fiber.StatusOK, "Upgrade Successfully Purchased", dto.ToUserPurchasedStageUpgradeResponse(res), nil)
 }
 
+func (h *GameHandler) BoostOfflineEarnings(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, apperror.ErrInvalidParam)
+	}
+
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	earning, balance, err := h.GameUseCase.BoostOfflineEarnings(ctx, id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Offline Earnings Successfully Boosted", dto.ToBoostOfflineEarningResponse(earning, balance), nil)
+}
+
+func (h *GameHandler) GetActiveBoosts(c *fiber.Ctx) error {
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	res, err := h.BoostUseCase.GetActiveBoosts(ctx)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Boosts Successfully Retrieved", dto.ToUserBoostsResponse(res, helper.NowUTC()), nil)
+}
+
+func (h *GameHandler) PurchaseBoost(c *fiber.Ctx) error {
+	slug, err := helper.GetParam[string](c, "slug")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, apperror.ErrInvalidParam)
+	}
+
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	boost, balance, err := h.BoostUseCase.PurchaseBoost(ctx, slug)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Boost Successfully Purchased", dto.ToPurchaseBoostResponse(boost, balance, helper.NowUTC()), nil)
+}
+
+func (h *GameHandler) GetUserMails(c *fiber.Ctx) error {
+	params := helper.GetPaginationParams(c)
+
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	mails, totalRows, unread, err := h.MailUseCase.GetUserMails(ctx, params.Limit, params.Offset)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	data := dto.ToUserMailboxResponse(mails, unread)
+	meta := helper.CreatePaginationMeta(params.Page, params.Limit, totalRows)
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Mails Successfully Retrieved", data, meta)
+}
+
+func (h *GameHandler) ReadUserMail(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	mail, err := h.MailUseCase.ReadUserMail(ctx, id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Mail Successfully Retrieved", dto.ToUserMailResponse(mail), nil)
+}
+
+func (h *GameHandler) ClaimUserMail(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	mail, balance, err := h.MailUseCase.ClaimUserMail(ctx, id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Mail Successfully Claimed", dto.ToClaimUserMailResponse(mail, balance), nil)
+}
+
+func (h *GameHandler) RedeemPromoCode(c *fiber.Ctx) error {
+	var request dto.RedeemPromoCodeRequest
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, h.errorHandler, apperror.ErrBadRequest)
+	}
+
+	if err := request.ValidateRequest(); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	userID := helper.GetUserID(c)
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, userID)
+
+	redemption, balance, err := h.PromoCodeUseCase.RedeemPromoCode(ctx, request.Code)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Promo Code Successfully Redeemed", dto.ToRedeemPromoCodeResponse(redemption, balance), nil)
+}
+
 func (h *GameHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
 	game := userAuth.Group("/game")
 
+	// Retried requests with the same Idempotency-Key replay the first response
+	idempotent := h.middleware.WithIdempotency()
+
 	// Player game stages
 	stages := game.Group("/stages")
 	stages.Get("/", h.GetAllStages)
 	stages.Get("/current", h.GetCurrentStage)
-	stages.Post("/:slug/start", h.StartGameStage)
-	stages.Post("/:slug/complete", h.CompleteGameStage)
+	stages.Post("/:slug/start", idempotent, h.StartGameStage)
+	stages.Post("/:slug/complete", idempotent, h.CompleteGameStage)
 
 	// Player Kitchen Station
 	stations := game.Group("/stations")
-	stations.Post("/:slug/purchase", h.PurchaseKitchenStation)
-	stations.Post("/:slug/upgrade", h.UpgradeKitchenStation)
+	stations.Post("/:slug/purchase", idempotent, h.PurchaseKitchenStation)
+	stations.Post("/:slug/upgrade", idempotent, h.UpgradeKitchenStation)
+	stations.Get("/:slug/upgrade-preview", h.PreviewStationUpgrade)
 
 	// Player Upgrade
 	upgrades := game.Group("/upgrades")
 	upgrades.Get("/", h.GetStageUpgrades)
-	upgrades.Post("/:slug/purchase", h.PurchaseStageUpgrade)
+	upgrades.Post("/:slug/purchase", idempotent, h.PurchaseStageUpgrade)
+
+	// Player Boosts
+	boosts := game.Group("/boosts")
+	boosts.Get("/", h.GetActiveBoosts)
+	boosts.Post("/:slug/purchase", idempotent, h.PurchaseBoost)
 
 	// Player Economy & Rewards
-	game.Post("/sync-balance", h.SyncBalance)
+	game.Post("/sync-balance", idempotent, h.SyncBalance)
+	game.Post("/offline-earnings/:id/boost", idempotent, h.BoostOfflineEarnings)
 	game.Get("/daily-reward/status", h.GetDailyRewardStatus)
-	game.Post("/daily-reward/claim", h.ClaimReward)
+	game.Post("/daily-reward/claim", idempotent, h.ClaimReward)
+	game.Post("/daily-reward/streak-freezes/purchase", idempotent, h.PurchaseStreakFreeze)
+
+	// Player Mailbox
+	mails := game.Group("/mails")
+	mails.Get("/", h.GetUserMails)
+	mails.Get("/:id", h.ReadUserMail)
+	mails.Post("/:id/claim", idempotent, h.ClaimUserMail)
+
+	// Player Promo Codes
+	game.Post("/redeem", idempotent, h.RedeemPromoCode)
 
 	return nil
 }
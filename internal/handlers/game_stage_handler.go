@@ -3,6 +3,8 @@ package handlers
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
 	"github.com/winartodev/cat-cafe/internal/usecase"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
 	"github.com/winartodev/cat-cafe/pkg/helper"
@@ -154,19 +156,63 @@ func (h *GameStageHandler) UpdateStageUpgrade(c *fiber.Ctx) error {
 	return response.SuccessResponse(c, fiber.StatusOK, "Stage Upgrade Successfully Updated", dto.ToUpgradeStageResponse(slug, req.Upgrades), nil)
 }
 
+func (h *GameStageHandler) GetStageRequirements(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	ctx := c.Context()
+
+	requirements, err := h.GameStageUseCase.GetStageRequirements(ctx, id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Stage Requirements Successfully Retrieved", dto.ToStageRequirementsResponse(id, requirements), nil)
+}
+
+func (h *GameStageHandler) UpdateStageRequirements(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	var req dto.UpdateStageRequirementsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	requirements, err := req.ToEntities(id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	ctx := c.Context()
+
+	requirements, err = h.GameStageUseCase.UpdateStageRequirements(ctx, id, requirements)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Stage Requirements Successfully Updated", dto.ToStageRequirementsResponse(id, requirements), nil)
+}
+
 func (h *GameStageHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
 	gameStages := internalAuth.Group("/game-stages")
 
-	gameStages.Post("/", h.CreateGameStage)
-	gameStages.Put("/:id", h.UpdateGameStage)
-	gameStages.Get("/", h.GetGameStages)
-	gameStages.Get("/:id", h.GetGameStage)
+	gameStages.Post("/", middleware.RequirePermission(entities.PermissionContentWrite), h.CreateGameStage)
+	gameStages.Put("/:id", middleware.RequirePermission(entities.PermissionContentWrite), h.UpdateGameStage)
+	gameStages.Get("/", middleware.RequirePermission(entities.PermissionContentRead), h.GetGameStages)
+	gameStages.Get("/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetGameStage)
+	gameStages.Get("/:id/requirements", middleware.RequirePermission(entities.PermissionContentRead), h.GetStageRequirements)
+	gameStages.Put("/:id/requirements", middleware.RequirePermission(entities.PermissionContentWrite), h.UpdateStageRequirements)
 
 	stageUpgrade := internalAuth.Group("/stage-upgrades")
 
-	stageUpgrade.Post("/", h.CreateStageUpgrade)
-	stageUpgrade.Get("/:slug", h.GetGameStageUpgrades)
-	stageUpgrade.Put("/:slug", h.UpdateStageUpgrade)
+	stageUpgrade.Post("/", middleware.RequirePermission(entities.PermissionContentWrite), h.CreateStageUpgrade)
+	stageUpgrade.Get("/:slug", middleware.RequirePermission(entities.PermissionContentRead), h.GetGameStageUpgrades)
+	stageUpgrade.Put("/:slug", middleware.RequirePermission(entities.PermissionContentWrite), h.UpdateStageUpgrade)
 
 	return nil
 }
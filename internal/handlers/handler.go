@@ -43,6 +43,10 @@ func SetupHandler(app *fiber.App, uc usecase.UseCase, middleware middleware.Midd
 	gameHandler := NewGameHandler(
 		uc.GameUseCase,
 		uc.DailyRewardUseCase,
+		uc.BoostUseCase,
+		uc.MailUseCase,
+		uc.PromoCodeUseCase,
+		middleware,
 	)
 
 	upgradeHandler := NewUpgradeHandler(
@@ -53,9 +57,36 @@ func SetupHandler(app *fiber.App, uc usecase.UseCase, middleware middleware.Midd
 		uc.TutorialUseCase,
 	)
 
+	currencyLedgerHandler := NewCurrencyLedgerHandler(
+		uc.CurrencyLedgerUseCase,
+	)
+
+	moderationHandler := NewModerationHandler(
+		uc.ModerationUseCase,
+	)
+
+	boostHandler := NewBoostHandler(
+		uc.BoostUseCase,
+	)
+
+	payoutHandler := NewPayoutHandler(
+		uc.PayoutUseCase,
+	)
+
+	mailHandler := NewMailHandler(
+		uc.MailUseCase,
+	)
+
+	promoCodeHandler := NewPromoCodeHandler(
+		uc.PromoCodeUseCase,
+	)
+
+	// JWKS follows the well-known path so it is served outside of the api group
+	app.Get("/.well-known/jwks.json", authHandler.GetJWKS)
+
 	api := app.Group("/api")
 	userAuth := api.Group("/v1", middleware.WithUserAuth())
-	internalAuth := api.Group("/internal")
+	internalAuth := api.Group("/internal", middleware.WithAdminAuth())
 
 	if err := register(api, userAuth, internalAuth,
 		rewardHandler,
@@ -65,6 +96,12 @@ func SetupHandler(app *fiber.App, uc usecase.UseCase, middleware middleware.Midd
 		gameStageHandler,
 		upgradeHandler,
 		tutorialHandler,
+		currencyLedgerHandler,
+		moderationHandler,
+		boostHandler,
+		payoutHandler,
+		mailHandler,
+		promoCodeHandler,
 	); err != nil {
 		panic(err)
 	}
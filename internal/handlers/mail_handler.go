@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
+	"github.com/winartodev/cat-cafe/internal/usecase"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/response"
+)
+
+// MailHandler lets admins send mails to the mailbox of players
+type MailHandler struct {
+	MailUseCase  usecase.MailUseCase
+	errorHandler *apperror.ErrorHandler
+}
+
+func NewMailHandler(mailUseCase usecase.MailUseCase) *MailHandler {
+	return &MailHandler{
+		MailUseCase:  mailUseCase,
+		errorHandler: apperror.NewErrorHandler(),
+	}
+}
+
+func (h *MailHandler) SendMail(c *fiber.Ctx) error {
+	var request dto.SendMailRequest
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, h.errorHandler, apperror.ErrBadRequest)
+	}
+
+	if err := request.ValidateRequest(); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, helper.GetUserID(c))
+
+	res, err := h.MailUseCase.SendMail(ctx, request.ToEntity(), request.Rewards)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusCreated, "Mail Successfully Sent", dto.ToMailResponse(res), nil)
+}
+
+func (h *MailHandler) GetMails(c *fiber.Ctx) error {
+	params := helper.GetPaginationParams(c)
+
+	res, totalRows, err := h.MailUseCase.GetMails(c.Context(), params.Limit, params.Offset)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	data := dto.ToMailsResponse(res)
+	meta := helper.CreatePaginationMeta(params.Page, params.Limit, totalRows)
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Mails Successfully Retrieved", data, meta)
+}
+
+func (h *MailHandler) GetMailByID(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	res, err := h.MailUseCase.GetMailByID(c.Context(), id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Mail Successfully Retrieved", dto.ToMailResponse(res), nil)
+}
+
+func (h *MailHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
+	mails := internalAuth.Group("/mails")
+	mails.Post("/", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.SendMail)
+	mails.Get("/", middleware.RequirePermission(entities.PermissionContentRead), h.GetMails)
+	mails.Get("/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetMailByID)
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
+	"github.com/winartodev/cat-cafe/internal/usecase"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/response"
+)
+
+// ModerationHandler lets admins suspend and ban players
+type ModerationHandler struct {
+	ModerationUseCase usecase.ModerationUseCase
+	errorHandler      *apperror.ErrorHandler
+}
+
+func NewModerationHandler(moderationUseCase usecase.ModerationUseCase) *ModerationHandler {
+	return &ModerationHandler{
+		ModerationUseCase: moderationUseCase,
+		errorHandler:      apperror.NewErrorHandler(),
+	}
+}
+
+func (h *ModerationHandler) SuspendUser(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	var request dto.SuspendUserRequest
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, h.errorHandler, apperror.ErrBadRequest)
+	}
+
+	data, err := request.ToEntity()
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, helper.GetUserID(c))
+
+	res, err := h.ModerationUseCase.SuspendUser(ctx, id, *data)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusCreated, "User Successfully Suspended", dto.ToUserSuspensionResponse(res, helper.NowUTC()), nil)
+}
+
+func (h *ModerationHandler) LiftSuspension(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, helper.GetUserID(c))
+
+	if err := h.ModerationUseCase.LiftSuspension(ctx, id); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "User Suspension Successfully Lifted", nil, nil)
+}
+
+func (h *ModerationHandler) GetUserSuspensions(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	res, err := h.ModerationUseCase.GetUserSuspensions(c.Context(), id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "User Suspensions Successfully Retrieved", dto.ToUserSuspensionsResponse(res, helper.NowUTC()), nil)
+}
+
+func (h *ModerationHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
+	users := internalAuth.Group("/users")
+	users.Get("/:id/suspensions", middleware.RequirePermission(entities.PermissionContentRead), h.GetUserSuspensions)
+	users.Post("/:id/suspensions", middleware.RequirePermission(entities.PermissionPlayerModerate), h.SuspendUser)
+	users.Delete("/:id/suspensions", middleware.RequirePermission(entities.PermissionPlayerModerate), h.LiftSuspension)
+
+	return nil
+}
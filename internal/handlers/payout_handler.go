@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
+	"github.com/winartodev/cat-cafe/internal/usecase"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/response"
+)
+
+// PayoutHandler exposes the external reward payouts queued for the payout worker
+type PayoutHandler struct {
+	PayoutUseCase usecase.PayoutUseCase
+	errorHandler  *apperror.ErrorHandler
+}
+
+func NewPayoutHandler(payoutUseCase usecase.PayoutUseCase) *PayoutHandler {
+	return &PayoutHandler{
+		PayoutUseCase: payoutUseCase,
+		errorHandler:  apperror.NewErrorHandler(),
+	}
+}
+
+func (h *PayoutHandler) GetPayouts(c *fiber.Ctx) error {
+	params := helper.GetPaginationParams(c)
+	status := entities.PayoutStatus(c.Query("status"))
+
+	res, totalRows, err := h.PayoutUseCase.GetPayouts(c.Context(), status, params.Limit, params.Offset)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	data := dto.ToPayoutsResponse(res)
+	meta := helper.CreatePaginationMeta(params.Page, params.Limit, totalRows)
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Payouts Successfully Retrieved", data, meta)
+}
+
+func (h *PayoutHandler) GetPayoutByID(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	res, err := h.PayoutUseCase.GetPayoutByID(c.Context(), id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Payout Successfully Retrieved", dto.ToPayoutResponse(res), nil)
+}
+
+func (h *PayoutHandler) RetryPayout(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	res, err := h.PayoutUseCase.RetryPayout(c.Context(), id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Payout Successfully Retried", dto.ToPayoutResponse(res), nil)
+}
+
+func (h *PayoutHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
+	payouts := internalAuth.Group("/payouts")
+	payouts.Get("/", middleware.RequirePermission(entities.PermissionContentRead), h.GetPayouts)
+	payouts.Get("/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetPayoutByID)
+	payouts.Post("/:id/retry", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.RetryPayout)
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
+	"github.com/winartodev/cat-cafe/internal/usecase"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/response"
+)
+
+// PromoCodeHandler lets admins create promo codes and export the batches generated for marketing
+type PromoCodeHandler struct {
+	PromoCodeUseCase usecase.PromoCodeUseCase
+	errorHandler     *apperror.ErrorHandler
+}
+
+func NewPromoCodeHandler(promoCodeUseCase usecase.PromoCodeUseCase) *PromoCodeHandler {
+	return &PromoCodeHandler{
+		PromoCodeUseCase: promoCodeUseCase,
+		errorHandler:     apperror.NewErrorHandler(),
+	}
+}
+
+func (h *PromoCodeHandler) CreatePromoCode(c *fiber.Ctx) error {
+	var request dto.CreatePromoCodeRequest
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, h.errorHandler, apperror.ErrBadRequest)
+	}
+
+	if err := request.ValidateRequest(); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, helper.GetUserID(c))
+
+	res, err := h.PromoCodeUseCase.CreatePromoCode(ctx, request.ToEntity(), request.Reward)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusCreated, "Promo Code Successfully Created", dto.ToPromoCodeResponse(res), nil)
+}
+
+func (h *PromoCodeHandler) GetPromoCodes(c *fiber.Ctx) error {
+	params := helper.GetPaginationParams(c)
+
+	var batchID *int64
+	if id := int64(c.QueryInt("batch_id", 0)); id > 0 {
+		batchID = &id
+	}
+
+	res, totalRows, err := h.PromoCodeUseCase.GetPromoCodes(c.Context(), batchID, params.Limit, params.Offset)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	data := dto.ToPromoCodesResponse(res)
+	meta := helper.CreatePaginationMeta(params.Page, params.Limit, totalRows)
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Promo Codes Successfully Retrieved", data, meta)
+}
+
+func (h *PromoCodeHandler) GetPromoCodeByID(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	res, err := h.PromoCodeUseCase.GetPromoCodeByID(c.Context(), id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Promo Code Successfully Retrieved", dto.ToPromoCodeResponse(res), nil)
+}
+
+func (h *PromoCodeHandler) CreatePromoCodeBatch(c *fiber.Ctx) error {
+	var request dto.CreatePromoCodeBatchRequest
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, h.errorHandler, apperror.ErrBadRequest)
+	}
+
+	if err := request.ValidateRequest(); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	ctx := context.WithValue(c.Context(), helper.ContextUserIDKey, helper.GetUserID(c))
+
+	res, err := h.PromoCodeUseCase.CreatePromoCodeBatch(ctx, request.ToEntity(), request.Reward)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusCreated, "Promo Code Batch Successfully Created", dto.ToPromoCodeBatchResponse(res), nil)
+}
+
+func (h *PromoCodeHandler) GetPromoCodeBatches(c *fiber.Ctx) error {
+	params := helper.GetPaginationParams(c)
+
+	res, totalRows, err := h.PromoCodeUseCase.GetPromoCodeBatches(c.Context(), params.Limit, params.Offset)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	data := dto.ToPromoCodeBatchesResponse(res)
+	meta := helper.CreatePaginationMeta(params.Page, params.Limit, totalRows)
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Promo Code Batches Successfully Retrieved", data, meta)
+}
+
+func (h *PromoCodeHandler) GetPromoCodeBatchByID(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	res, err := h.PromoCodeUseCase.GetPromoCodeBatchByID(c.Context(), id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Promo Code Batch Successfully Retrieved", dto.ToPromoCodeBatchResponse(res), nil)
+}
+
+// ExportPromoCodeBatch sends the codes of the batch as a CSV file, one code per row
+func (h *PromoCodeHandler) ExportPromoCodeBatch(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	batch, codes, err := h.PromoCodeUseCase.GetPromoCodeBatchCodes(c.Context(), id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	c.Attachment(fmt.Sprintf("promo-code-batch-%d.csv", batch.ID))
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+
+	w := csv.NewWriter(c)
+	_ = w.Write([]string{"code", "reward", "starts_at", "ends_at", "redeemed"})
+	for _, code := range codes {
+		_ = w.Write([]string{
+			code.Code,
+			batch.Reward.Slug,
+			formatCSVTime(code.StartsAt),
+			formatCSVTime(code.EndsAt),
+			strconv.FormatBool(code.Redemptions > 0),
+		})
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (h *PromoCodeHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
+	// Code values can be redeemed, so only liveops admins can read them
+	promoCodes := internalAuth.Group("/promo-codes")
+	promoCodes.Post("/", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.CreatePromoCode)
+	promoCodes.Get("/", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.GetPromoCodes)
+	promoCodes.Post("/batches", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.CreatePromoCodeBatch)
+	promoCodes.Get("/batches", middleware.RequirePermission(entities.PermissionContentRead), h.GetPromoCodeBatches)
+	promoCodes.Get("/batches/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetPromoCodeBatchByID)
+	promoCodes.Get("/batches/:id/export", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.ExportPromoCodeBatch)
+	promoCodes.Get("/:id", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.GetPromoCodeByID)
+
+	return nil
+}
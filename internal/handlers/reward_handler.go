@@ -4,6 +4,8 @@ import (
 	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
 	"github.com/winartodev/cat-cafe/internal/usecase"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
 	"github.com/winartodev/cat-cafe/pkg/helper"
@@ -12,7 +14,7 @@ import (
 
 // RewardHandler is used for manage rewards
 //
-// included: reward types, rewards, daily rewards
+// included: reward types, rewards, daily rewards, daily reward calendars
 type RewardHandler struct {
 	RewardUseCase      usecase.RewardUseCase
 	DailyRewardUseCase usecase.DailyRewardUseCase
@@ -138,7 +140,7 @@ func (h *RewardHandler) CreateDailyReward(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	res, err := h.DailyRewardUseCase.CreateDailyReward(ctx, *request.ToEntity(), request.Reward)
+	res, err := h.DailyRewardUseCase.CreateDailyReward(ctx, *request.ToEntity(), request.Reward, request.Calendar)
 	if err != nil {
 		return response.FailedResponse(c, h.errorHandler, err)
 	}
@@ -150,7 +152,7 @@ func (h *RewardHandler) GetDailyRewards(c *fiber.Ctx) error {
 	ctx := c.Context()
 	params := helper.GetPaginationParams(c)
 
-	res, totalRow, err := h.DailyRewardUseCase.GetDailyRewards(ctx, params.Limit, params.Offset)
+	res, totalRow, err := h.DailyRewardUseCase.GetDailyRewards(ctx, c.Query("calendar"), params.Limit, params.Offset)
 	if err != nil {
 		return response.FailedResponse(c, h.errorHandler, err)
 	}
@@ -193,7 +195,7 @@ func (h *RewardHandler) UpdateDailyReward(c *fiber.Ctx) error {
 	}
 
 	ctx := c.Context()
-	res, err := h.DailyRewardUseCase.UpdateDailyReward(ctx, id, *request.ToEntity(), request.Reward)
+	res, err := h.DailyRewardUseCase.UpdateDailyReward(ctx, id, *request.ToEntity(), request.Reward, request.Calendar)
 	if err != nil {
 		if errors.Is(err, apperror.ErrRecordNotFound) {
 			return response.FailedResponse(c, h.errorHandler, err)
@@ -205,6 +207,75 @@ func (h *RewardHandler) UpdateDailyReward(c *fiber.Ctx) error {
 	return response.SuccessResponse(c, fiber.StatusOK, "Daily Reward Successfully Updated", dto.ToDailyRewardResponse(res), nil)
 }
 
+func (h *RewardHandler) CreateDailyRewardCalendar(c *fiber.Ctx) error {
+	var request dto.DailyRewardCalendarRequest
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	if err := request.ValidateRequest(); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	res, err := h.DailyRewardUseCase.CreateDailyRewardCalendar(c.Context(), request.ToEntity())
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusCreated, "Daily Reward Calendar Successfully Created", dto.ToDailyRewardCalendarResponse(res), nil)
+}
+
+func (h *RewardHandler) GetDailyRewardCalendars(c *fiber.Ctx) error {
+	params := helper.GetPaginationParams(c)
+
+	res, totalRows, err := h.DailyRewardUseCase.GetDailyRewardCalendars(c.Context(), params.Limit, params.Offset)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	data := dto.ToDailyRewardCalendarResponses(res)
+	meta := helper.CreatePaginationMeta(params.Page, params.Limit, totalRows)
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Daily Reward Calendars Successfully Retrieved", data, meta)
+}
+
+func (h *RewardHandler) GetDailyRewardCalendarByID(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	res, err := h.DailyRewardUseCase.GetDailyRewardCalendarByID(c.Context(), id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Daily Reward Calendar Successfully Retrieved", dto.ToDailyRewardCalendarResponse(res), nil)
+}
+
+func (h *RewardHandler) UpdateDailyRewardCalendar(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	var request dto.DailyRewardCalendarRequest
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	if err := request.ValidateRequest(); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	res, err := h.DailyRewardUseCase.UpdateDailyRewardCalendar(c.Context(), id, request.ToEntity())
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Daily Reward Calendar Successfully Updated", dto.ToDailyRewardCalendarResponse(res), nil)
+}
+
 func (h *RewardHandler) UpdateReward(c *fiber.Ctx) error {
 	// TODO: UPDATE REWARD HERE !!!
 	return response.SuccessResponse(c, fiber.StatusOK, "Reward Successfully Updated", nil, nil)
@@ -227,24 +298,30 @@ func (h *RewardHandler) Route(open fiber.Router, userAuth fiber.Router, internal
 	reward := internalAuth.Group("/rewards")
 
 	// Reward Types Management
-	reward.Post("/types", h.CreateRewardType)
-	reward.Get("/types", h.GetRewardTypes)
-	reward.Get("/types/:id", h.GetRewardTypeByID)
-	reward.Put("/types/:id", h.UpdateRewardType)
+	reward.Post("/types", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.CreateRewardType)
+	reward.Get("/types", middleware.RequirePermission(entities.PermissionContentRead), h.GetRewardTypes)
+	reward.Get("/types/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetRewardTypeByID)
+	reward.Put("/types/:id", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.UpdateRewardType)
+
+	// Daily Reward Calendars Management
+	reward.Post("/daily/calendars", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.CreateDailyRewardCalendar)
+	reward.Get("/daily/calendars", middleware.RequirePermission(entities.PermissionContentRead), h.GetDailyRewardCalendars)
+	reward.Get("/daily/calendars/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetDailyRewardCalendarByID)
+	reward.Put("/daily/calendars/:id", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.UpdateDailyRewardCalendar)
 
 	// Daily Rewards Management
-	reward.Post("/daily", h.CreateDailyReward)
-	reward.Get("/daily", h.GetDailyRewards)
-	reward.Get("/daily/:id", h.GetDailyRewardByID)
-	reward.Put("/daily/:id", h.UpdateDailyReward)
-	reward.Patch("/daily/:id/status", h.ToggleStatusDailyReward)
+	reward.Post("/daily", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.CreateDailyReward)
+	reward.Get("/daily", middleware.RequirePermission(entities.PermissionContentRead), h.GetDailyRewards)
+	reward.Get("/daily/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetDailyRewardByID)
+	reward.Put("/daily/:id", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.UpdateDailyReward)
+	reward.Patch("/daily/:id/status", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.ToggleStatusDailyReward)
 
 	// General Rewards Management
-	reward.Post("/", h.CreateReward)
-	reward.Get("/", h.GetRewards)
-	reward.Get("/:id", h.GetRewardByID)
-	reward.Put("/:id", h.UpdateReward)
-	reward.Patch("/:id/status", h.ToggleStatusReward)
+	reward.Post("/", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.CreateReward)
+	reward.Get("/", middleware.RequirePermission(entities.PermissionContentRead), h.GetRewards)
+	reward.Get("/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetRewardByID)
+	reward.Put("/:id", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.UpdateReward)
+	reward.Patch("/:id/status", middleware.RequirePermission(entities.PermissionLiveOpsWrite), h.ToggleStatusReward)
 
 	return nil
 }
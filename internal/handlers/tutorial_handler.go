@@ -3,6 +3,8 @@ package handlers
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
 	"github.com/winartodev/cat-cafe/internal/usecase"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
 	"github.com/winartodev/cat-cafe/pkg/helper"
@@ -91,10 +93,10 @@ func (t *TutorialHandler) UpdateTutorial(c *fiber.Ctx) error {
 func (t *TutorialHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
 	tutorials := internalAuth.Group("/tutorials")
 
-	tutorials.Post("/", t.CreateTutorials)
-	tutorials.Get("/", t.GetTutorials)
-	tutorials.Get("/:key/translations", t.GetTranslations)
-	tutorials.Get("/:key/translations/:id", t.GetTranslationByID)
-	tutorials.Put("/:key/translations/:id", t.GetTranslationByID)
+	tutorials.Post("/", middleware.RequirePermission(entities.PermissionContentWrite), t.CreateTutorials)
+	tutorials.Get("/", middleware.RequirePermission(entities.PermissionContentRead), t.GetTutorials)
+	tutorials.Get("/:key/translations", middleware.RequirePermission(entities.PermissionContentRead), t.GetTranslations)
+	tutorials.Get("/:key/translations/:id", middleware.RequirePermission(entities.PermissionContentRead), t.GetTranslationByID)
+	tutorials.Put("/:key/translations/:id", middleware.RequirePermission(entities.PermissionContentWrite), t.GetTranslationByID)
 	return nil
 }
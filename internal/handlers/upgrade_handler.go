@@ -5,6 +5,8 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/winartodev/cat-cafe/internal/dto"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/middleware"
 	"github.com/winartodev/cat-cafe/internal/usecase"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
 	"github.com/winartodev/cat-cafe/pkg/helper"
@@ -98,13 +100,53 @@ func (h *UpgradeHandler) UpdateUpgrade(c *fiber.Ctx) error {
 	return response.SuccessResponse(c, fiber.StatusOK, "Upgrade Successfully Updated", data, nil)
 }
 
+func (h *UpgradeHandler) GetUpgradePrerequisites(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	prerequisites, err := h.upgradeUC.GetUpgradePrerequisites(c.Context(), id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Upgrade Prerequisites Successfully Retrieved", dto.ToUpgradePrerequisitesResponse(id, prerequisites), nil)
+}
+
+func (h *UpgradeHandler) UpdateUpgradePrerequisites(c *fiber.Ctx) error {
+	id, err := helper.GetParam[int64](c, "id")
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	var request dto.UpdateUpgradePrerequisitesRequest
+	if err := c.BodyParser(&request); err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	prerequisites, err := request.ToEntities(id)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	prerequisites, err = h.upgradeUC.UpdateUpgradePrerequisites(c.Context(), id, prerequisites)
+	if err != nil {
+		return response.FailedResponse(c, h.errorHandler, err)
+	}
+
+	return response.SuccessResponse(c, fiber.StatusOK, "Upgrade Prerequisites Successfully Updated", dto.ToUpgradePrerequisitesResponse(id, prerequisites), nil)
+}
+
 func (h *UpgradeHandler) Route(open fiber.Router, userAuth fiber.Router, internalAuth fiber.Router) error {
 	upgrade := internalAuth.Group("/upgrades")
 
-	upgrade.Post("/", h.CreateUpgrade)
-	upgrade.Get("/", h.GetUpgrades)
-	upgrade.Get("/:id", h.GetUpgradeByID)
-	upgrade.Put("/:id", h.UpdateUpgrade)
+	upgrade.Post("/", middleware.RequirePermission(entities.PermissionContentWrite), h.CreateUpgrade)
+	upgrade.Get("/", middleware.RequirePermission(entities.PermissionContentRead), h.GetUpgrades)
+	upgrade.Get("/:id", middleware.RequirePermission(entities.PermissionContentRead), h.GetUpgradeByID)
+	upgrade.Put("/:id", middleware.RequirePermission(entities.PermissionContentWrite), h.UpdateUpgrade)
+	upgrade.Get("/:id/prerequisites", middleware.RequirePermission(entities.PermissionContentRead), h.GetUpgradePrerequisites)
+	upgrade.Put("/:id/prerequisites", middleware.RequirePermission(entities.PermissionContentWrite), h.UpdateUpgradePrerequisites)
 
 	return nil
 }
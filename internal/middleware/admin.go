@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/response"
+)
+
+// WithAdminAuth authenticates the bearer token like WithUserAuth and
+// additionally requires the user to be an active admin principal
+func (m *middleware) WithAdminAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := m.authenticate(c); err != nil {
+			return response.FailedResponse(c, m.errorHandler, err)
+		}
+
+		admin, err := m.adminRepository.GetAdminUserByUserIDDB(c.Context(), helper.GetUserID(c))
+		if err != nil {
+			return response.FailedResponse(c, m.errorHandler, apperror.ErrInternalServer.WithError(err))
+		}
+
+		if admin == nil || !admin.IsActive {
+			return response.FailedResponse(c, m.errorHandler, apperror.ErrAccessDenied)
+		}
+
+		c.Locals(helper.ContextAdminKey, admin)
+
+		return c.Next()
+	}
+}
+
+// RequirePermission rejects the request when the authenticated admin role
+// doesn't grant the given permission. It must run after WithAdminAuth
+func RequirePermission(permission entities.AdminPermission) fiber.Handler {
+	errorHandler := apperror.NewErrorHandler()
+
+	return func(c *fiber.Ctx) error {
+		admin, ok := c.Locals(helper.ContextAdminKey).(*entities.AdminUser)
+		if !ok || !admin.HasPermission(permission) {
+			return response.FailedResponse(c, errorHandler, apperror.ErrAccessDenied)
+		}
+
+		return c.Next()
+	}
+}
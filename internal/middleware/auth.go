@@ -13,65 +13,128 @@ import (
 
 type Middleware interface {
 	WithUserAuth() fiber.Handler
+	WithAdminAuth() fiber.Handler
+	WithIdempotency() fiber.Handler
 }
 
 type middleware struct {
-	jwtManager     *jwt.JWT
-	userRepository repositories.UserRepository
-	errorHandler   *apperror.ErrorHandler
+	jwtManager            *jwt.JWT
+	userRepository        repositories.UserRepository
+	adminRepository       repositories.AdminRepository
+	idempotencyRepository repositories.IdempotencyRepository
+	sessionRepository     repositories.SessionRepository
+	moderationRepository  repositories.ModerationRepository
+	errorHandler          *apperror.ErrorHandler
 }
 
-func NewMiddleware(jwtManager *jwt.JWT, userRepository repositories.UserRepository) Middleware {
+func NewMiddleware(
+	jwtManager *jwt.JWT,
+	userRepository repositories.UserRepository,
+	adminRepository repositories.AdminRepository,
+	idempotencyRepository repositories.IdempotencyRepository,
+	sessionRepository repositories.SessionRepository,
+	moderationRepository repositories.ModerationRepository,
+) Middleware {
 	return &middleware{
-		jwtManager:     jwtManager,
-		userRepository: userRepository,
-		errorHandler:   apperror.NewErrorHandler(),
+		jwtManager:            jwtManager,
+		userRepository:        userRepository,
+		adminRepository:       adminRepository,
+		idempotencyRepository: idempotencyRepository,
+		sessionRepository:     sessionRepository,
+		moderationRepository:  moderationRepository,
+		errorHandler:          apperror.NewErrorHandler(),
 	}
 }
 
 func (m *middleware) WithUserAuth() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := c.Context()
-		authHeader := c.Get("Authorization")
-		if authHeader == "" {
-			return response.FailedResponse(c, m.errorHandler, apperror.ErrMissingAuthHeader)
+		if err := m.authenticate(c); err != nil {
+			return response.FailedResponse(c, m.errorHandler, err)
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		if tokenString == authHeader {
-			return response.FailedResponse(c, m.errorHandler, apperror.ErrInvalidToken)
-		}
+		return c.Next()
+	}
+}
 
-		// Check blacklist
-		if m.userRepository.IsTokenBlacklisted(ctx, tokenString) {
-			return response.FailedResponse(c, m.errorHandler, apperror.ErrTokenRevoked)
-		}
+// authenticate validates the bearer token and stores the user identity in fiber locals
+func (m *middleware) authenticate(c *fiber.Ctx) error {
+	ctx := c.Context()
+	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+		return apperror.ErrMissingAuthHeader
+	}
 
-		// Validate token
-		claims, err := m.jwtManager.ValidateToken(tokenString)
-		if err != nil {
-			if errors.Is(err, apperror.ErrTokenExpired) {
-				return response.FailedResponse(c, m.errorHandler, apperror.ErrTokenExpired)
-			}
-			return response.FailedResponse(c, m.errorHandler, apperror.ErrInvalidToken)
-		}
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == authHeader {
+		return apperror.ErrInvalidToken
+	}
 
-		userCache, err := m.userRepository.GetUserByIDDB(ctx, claims.UserID)
-		if userCache == nil {
-			return response.FailedResponse(c, m.errorHandler, apperror.ErrInvalidToken)
-		}
+	// Check blacklist
+	if m.userRepository.IsTokenBlacklisted(ctx, tokenString) {
+		return apperror.ErrTokenRevoked
+	}
 
-		if err == nil {
-			c.Locals(helper.ContextUserKey, userCache)
-			c.Locals(helper.ContextUserIDKey, userCache.ID)
-			c.Locals(helper.ContextEmailKey, userCache.Email)
-		} else {
-			c.Locals(helper.ContextUserIDKey, claims.UserID)
-			c.Locals(helper.ContextEmailKey, claims.Email)
+	// Validate token
+	claims, err := m.jwtManager.ValidateToken(tokenString)
+	if err != nil {
+		if errors.Is(err, apperror.ErrTokenExpired) {
+			return apperror.ErrTokenExpired
 		}
+		return apperror.ErrInvalidToken
+	}
+
+	// Access tokens are bound to a device session, revoking the session revokes its tokens
+	if claims.SessionID == 0 {
+		return apperror.ErrInvalidToken
+	}
 
-		c.Locals(helper.ContextTokenKey, tokenString)
+	if m.sessionRepository.IsSessionRevokedRedis(ctx, claims.SessionID) {
+		return apperror.ErrTokenRevoked
+	}
 
-		return c.Next()
+	// The redis marker is best effort, the session row stays the source of truth when the marker is missing
+	session, err := m.sessionRepository.GetSessionByIDDB(ctx, claims.SessionID)
+	if err != nil {
+		return apperror.ErrInternalServer.WithError(err)
 	}
+
+	if session == nil || session.UserID != claims.UserID {
+		return apperror.ErrInvalidToken
+	}
+
+	if session.RevokedAt != nil {
+		return apperror.ErrTokenRevoked
+	}
+
+	userCache, err := m.userRepository.GetUserByIDDB(ctx, claims.UserID)
+	if userCache == nil {
+		return apperror.ErrInvalidToken
+	}
+
+	if !userCache.IsActive {
+		return apperror.ErrAccountDisabled
+	}
+
+	suspension, suspensionErr := m.moderationRepository.GetActiveSuspensionByUserIDDB(ctx, userCache.ID, helper.NowUTC())
+	if suspensionErr != nil {
+		return apperror.ErrInternalServer.WithError(suspensionErr)
+	}
+
+	if suspension != nil {
+		return suspension.Err()
+	}
+
+	if err == nil {
+		c.Locals(helper.ContextUserKey, userCache)
+		c.Locals(helper.ContextUserIDKey, userCache.ID)
+		c.Locals(helper.ContextEmailKey, userCache.Email)
+	} else {
+		c.Locals(helper.ContextUserIDKey, claims.UserID)
+		c.Locals(helper.ContextEmailKey, claims.Email)
+	}
+
+	c.Locals(helper.ContextTokenKey, tokenString)
+	c.Locals(helper.ContextSessionIDKey, claims.SessionID)
+
+	return nil
 }
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/response"
+	"time"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyLockDuration   = 30 * time.Second
+	idempotencyResultDuration = 24 * time.Hour
+)
+
+// WithIdempotency stores the first response per (user, Idempotency-Key) and replays it on retries.
+// A retry that arrives while the first request is still running is rejected with 409.
+// It must run after WithUserAuth, requests without the header are passed through
+func (m *middleware) WithIdempotency() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" {
+			return c.Next()
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return response.FailedResponse(c, m.errorHandler, apperror.ErrorInvalidRequest(IdempotencyKeyHeader, "is too long"))
+		}
+
+		ctx := c.Context()
+		userID := helper.GetUserID(c)
+		fingerprint := idempotencyFingerprint(c)
+
+		stored, err := m.idempotencyRepository.GetIdempotentResponseRedis(ctx, userID, key)
+		if err != nil {
+			return response.FailedResponse(c, m.errorHandler, apperror.ErrInternalServer.WithError(err))
+		}
+
+		if stored != nil {
+			return m.replayIdempotentResponse(c, stored, fingerprint)
+		}
+
+		token, acquired, err := m.idempotencyRepository.AcquireIdempotencyLockRedis(ctx, userID, key, idempotencyLockDuration)
+		if err != nil {
+			return response.FailedResponse(c, m.errorHandler, apperror.ErrInternalServer.WithError(err))
+		}
+
+		if !acquired {
+			return response.FailedResponse(c, m.errorHandler, apperror.ErrIdempotencyKeyInProgress)
+		}
+
+		// A request outliving the lock must not release the lock a retry took after it expired
+		defer func() {
+			_ = m.idempotencyRepository.ReleaseIdempotencyLockRedis(ctx, userID, key, token)
+		}()
+
+		// The first request may have finished between the lookup and acquiring the lock
+		stored, err = m.idempotencyRepository.GetIdempotentResponseRedis(ctx, userID, key)
+		if err != nil {
+			return response.FailedResponse(c, m.errorHandler, apperror.ErrInternalServer.WithError(err))
+		}
+
+		if stored != nil {
+			return m.replayIdempotentResponse(c, stored, fingerprint)
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		// Server errors are not stored so the client can retry them
+		statusCode := c.Response().StatusCode()
+		if statusCode >= fiber.StatusInternalServerError {
+			return nil
+		}
+
+		body := make([]byte, len(c.Response().Body()))
+		copy(body, c.Response().Body())
+
+		_ = m.idempotencyRepository.SetIdempotentResponseRedis(ctx, userID, key, &entities.IdempotentResponse{
+			Fingerprint: fingerprint,
+			StatusCode:  statusCode,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        body,
+		}, idempotencyResultDuration)
+
+		return nil
+	}
+}
+
+// idempotencyFingerprint identifies the request a key was first used for, by the method, the URL with
+// its query string and a hash of the body, so a key reused with other parameters is rejected
+func idempotencyFingerprint(c *fiber.Ctx) string {
+	sum := sha256.Sum256(c.Body())
+	return c.Method() + " " + c.OriginalURL() + " " + hex.EncodeToString(sum[:])
+}
+
+func (m *middleware) replayIdempotentResponse(c *fiber.Ctx, stored *entities.IdempotentResponse, fingerprint string) error {
+	if stored.Fingerprint != fingerprint {
+		return response.FailedResponse(c, m.errorHandler, apperror.ErrIdempotencyKeyReused)
+	}
+
+	c.Set(IdempotentReplayedHeader, "true")
+	c.Set(fiber.HeaderContentType, stored.ContentType)
+
+	return c.Status(stored.StatusCode).Send(stored.Body)
+}
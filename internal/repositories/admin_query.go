@@ -0,0 +1,9 @@
+package repositories
+
+const (
+	getAdminUserByUserIDQuery = `
+		SELECT 
+		    id, user_id, role, is_active, created_at, updated_at
+		FROM admin_users WHERE user_id = $1
+	`
+)
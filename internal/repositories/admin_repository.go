@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+)
+
+type AdminRepository interface {
+	WithTx(tx *sql.Tx) AdminRepository
+
+	GetAdminUserByUserIDDB(ctx context.Context, userID int64) (res *entities.AdminUser, err error)
+}
+
+type adminRepository struct {
+	BaseRepository
+}
+
+func NewAdminRepository(db *sql.DB) AdminRepository {
+	return &adminRepository{
+		BaseRepository{
+			db:   db,
+			pool: db,
+		},
+	}
+}
+
+func (r *adminRepository) WithTx(tx *sql.Tx) AdminRepository {
+	if tx == nil {
+		return r
+	}
+
+	return &adminRepository{
+		BaseRepository{
+			db:   tx,
+			pool: r.pool,
+		},
+	}
+}
+
+func (r *adminRepository) GetAdminUserByUserIDDB(ctx context.Context, userID int64) (*entities.AdminUser, error) {
+	var admin entities.AdminUser
+	var role string
+
+	err := r.db.QueryRowContext(ctx, getAdminUserByUserIDQuery, userID).Scan(
+		&admin.ID,
+		&admin.UserID,
+		&role,
+		&admin.IsActive,
+		&admin.CreatedAt,
+		&admin.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	admin.Role = entities.AdminRole(role)
+
+	return &admin, nil
+}
@@ -0,0 +1,157 @@
+package repositories
+
+const (
+	insertBoostQuery = `
+		INSERT INTO boosts (
+			slug,
+			name,
+			description,
+			effect_type,
+			effect_value,
+			duration_seconds,
+			stacking_rule,
+			max_stacks,
+			gem_cost,
+			reward_id,
+			is_active,
+			created_at,
+			updated_at
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6,
+			$7,
+			$8,
+			$9,
+			$10,
+			$11,
+			$12,
+			$13
+		) RETURNING id`
+
+	updateBoostQuery = `
+		UPDATE boosts
+		SET
+			name = $1,
+			description = $2,
+			effect_type = $3,
+			effect_value = $4,
+			duration_seconds = $5,
+			stacking_rule = $6,
+			max_stacks = $7,
+			gem_cost = $8,
+			reward_id = $9,
+			is_active = $10,
+			updated_at = $11
+		WHERE id = $12
+	`
+
+	selectBoostQuery = `
+		SELECT
+			b.id,
+			b.slug,
+			b.name,
+			b.description,
+			b.effect_type,
+			b.effect_value,
+			b.duration_seconds,
+			b.stacking_rule,
+			b.max_stacks,
+			b.gem_cost,
+			b.reward_id,
+			COALESCE(r.slug, '') AS reward_slug,
+			b.is_active,
+			b.created_at,
+			b.updated_at
+		FROM boosts b
+		LEFT JOIN rewards r ON r.id = b.reward_id
+	`
+
+	getBoostsQuery = selectBoostQuery + `
+		ORDER BY b.id ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	countBoostsQuery = `
+		SELECT
+			COUNT(*)
+		FROM boosts
+	`
+
+	getBoostByIDQuery = selectBoostQuery + `
+		WHERE b.id = $1
+	`
+
+	getBoostBySlugQuery = selectBoostQuery + `
+		WHERE b.slug = $1 AND b.is_active = true
+	`
+
+	getBoostByRewardIDQuery = selectBoostQuery + `
+		WHERE b.reward_id = $1 AND b.is_active = true
+	`
+
+	insertUserBoostQuery = `
+		INSERT INTO user_boosts (
+			user_id,
+			boost_id,
+			source_type,
+			source_ref,
+			started_at,
+			ends_at,
+			created_at,
+			updated_at
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6,
+			$7,
+			$8
+		) RETURNING id`
+
+	updateUserBoostPeriodQuery = `
+		UPDATE user_boosts
+		SET
+			started_at = $1,
+			ends_at = $2,
+			updated_at = $3
+		WHERE id = $4
+	`
+
+	selectUserBoostQuery = `
+		SELECT
+			ub.id,
+			ub.user_id,
+			ub.boost_id,
+			ub.source_type,
+			ub.source_ref,
+			ub.started_at,
+			ub.ends_at,
+			b.slug,
+			b.name,
+			b.description,
+			b.effect_type,
+			b.effect_value,
+			b.duration_seconds,
+			b.stacking_rule,
+			b.max_stacks
+		FROM user_boosts ub
+		JOIN boosts b ON b.id = ub.boost_id
+	`
+
+	getActiveUserBoostsByBoostIDForUpdateQuery = selectUserBoostQuery + `
+		WHERE ub.user_id = $1 AND ub.boost_id = $2 AND ub.ends_at > $3
+		ORDER BY ub.ends_at ASC
+		FOR UPDATE OF ub
+	`
+
+	getUserBoostsBetweenQuery = selectUserBoostQuery + `
+		WHERE ub.user_id = $1 AND ub.ends_at > $2 AND ub.started_at < $3
+		ORDER BY ub.ends_at ASC
+	`
+)
@@ -0,0 +1,326 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/database"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type BoostRepository interface {
+	WithTx(tx *sql.Tx) BoostRepository
+	BoostWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error)
+
+	CreateBoostDB(ctx context.Context, data entities.Boost) (id *int64, err error)
+	UpdateBoostDB(ctx context.Context, id int64, data entities.Boost) (err error)
+	GetBoostsDB(ctx context.Context, limit, offset int) (res []entities.Boost, err error)
+	CountBoostsDB(ctx context.Context) (totalRows int64, err error)
+	GetBoostByIDDB(ctx context.Context, id int64) (res *entities.Boost, err error)
+	GetBoostBySlugDB(ctx context.Context, slug string) (res *entities.Boost, err error)
+	GetBoostByRewardIDDB(ctx context.Context, rewardID int64) (res *entities.Boost, err error)
+
+	CreateUserBoostDB(ctx context.Context, data entities.UserBoost) (id int64, err error)
+	UpdateUserBoostPeriodDB(ctx context.Context, id int64, startedAt time.Time, endsAt time.Time) (err error)
+	GetActiveUserBoostsForUpdateDB(ctx context.Context, userID int64, boostID int64, now time.Time) (res []entities.UserBoost, err error)
+	GetUserBoostsBetweenDB(ctx context.Context, userID int64, from time.Time, to time.Time) (res []entities.UserBoost, err error)
+}
+
+type boostRepository struct {
+	BaseRepository
+}
+
+func NewBoostRepository(db *sql.DB) BoostRepository {
+	return &boostRepository{
+		BaseRepository: BaseRepository{
+			db:   db,
+			pool: db,
+		},
+	}
+}
+
+func (r *boostRepository) WithTx(tx *sql.Tx) BoostRepository {
+	if tx == nil {
+		return r
+	}
+
+	return &boostRepository{
+		BaseRepository: BaseRepository{
+			db:   tx,
+			pool: r.pool,
+		},
+	}
+}
+
+func (r *boostRepository) BoostWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *boostRepository) CreateBoostDB(ctx context.Context, data entities.Boost) (id *int64, err error) {
+	now := helper.NowUTC()
+
+	var lastInsertId int64
+	err = r.db.QueryRowContext(ctx, insertBoostQuery,
+		data.Slug,
+		data.Name,
+		data.Description,
+		data.EffectType,
+		data.EffectValue,
+		int64(data.Duration.Seconds()),
+		data.StackingRule,
+		data.MaxStacks,
+		data.GemCost,
+		data.RewardID,
+		data.IsActive,
+		now,
+		now,
+	).Scan(&lastInsertId)
+	if database.IsDuplicateError(err) {
+		return nil, apperror.ErrorAlreadyExists("boost", "slug or reward", data.Slug)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &lastInsertId, nil
+}
+
+func (r *boostRepository) UpdateBoostDB(ctx context.Context, id int64, data entities.Boost) (err error) {
+	now := helper.NowUTC()
+
+	_, err = r.db.ExecContext(ctx, updateBoostQuery,
+		data.Name,
+		data.Description,
+		data.EffectType,
+		data.EffectValue,
+		int64(data.Duration.Seconds()),
+		data.StackingRule,
+		data.MaxStacks,
+		data.GemCost,
+		data.RewardID,
+		data.IsActive,
+		now,
+		id,
+	)
+	if database.IsDuplicateError(err) {
+		return apperror.ErrorAlreadyExists("boost", "reward", data.RewardSlug)
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *boostRepository) GetBoostsDB(ctx context.Context, limit, offset int) (res []entities.Boost, err error) {
+	rows, err := r.db.QueryContext(ctx, getBoostsQuery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		boost, err := r.scanBoost(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *boost)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *boostRepository) CountBoostsDB(ctx context.Context) (totalRows int64, err error) {
+	err = r.db.QueryRowContext(ctx, countBoostsQuery).Scan(&totalRows)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalRows, nil
+}
+
+func (r *boostRepository) GetBoostByIDDB(ctx context.Context, id int64) (res *entities.Boost, err error) {
+	res, err = r.scanBoost(r.db.QueryRowContext(ctx, getBoostByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrorNotFound("boost", "id", fmt.Sprint(id))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *boostRepository) GetBoostBySlugDB(ctx context.Context, slug string) (res *entities.Boost, err error) {
+	res, err = r.scanBoost(r.db.QueryRowContext(ctx, getBoostBySlugQuery, slug))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrorNotFound("boost", "slug", slug)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetBoostByRewardIDDB returns nil when the reward doesn't grant an active boost
+func (r *boostRepository) GetBoostByRewardIDDB(ctx context.Context, rewardID int64) (res *entities.Boost, err error) {
+	res, err = r.scanBoost(r.db.QueryRowContext(ctx, getBoostByRewardIDQuery, rewardID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *boostRepository) CreateUserBoostDB(ctx context.Context, data entities.UserBoost) (id int64, err error) {
+	now := helper.NowUTC()
+
+	err = r.db.QueryRowContext(ctx, insertUserBoostQuery,
+		data.UserID,
+		data.BoostID,
+		data.Source.Type,
+		data.Source.Ref,
+		data.StartedAt,
+		data.EndsAt,
+		now,
+		now,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *boostRepository) UpdateUserBoostPeriodDB(ctx context.Context, id int64, startedAt time.Time, endsAt time.Time) (err error) {
+	res, err := r.db.ExecContext(ctx, updateUserBoostPeriodQuery, startedAt, endsAt, helper.NowUTC(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return apperror.ErrNoUpdateRecord
+	}
+
+	return nil
+}
+
+// GetActiveUserBoostsForUpdateDB locks the active boosts of one kind, it must run inside a transaction
+func (r *boostRepository) GetActiveUserBoostsForUpdateDB(ctx context.Context, userID int64, boostID int64, now time.Time) (res []entities.UserBoost, err error) {
+	return r.queryUserBoosts(ctx, getActiveUserBoostsByBoostIDForUpdateQuery, userID, boostID, now)
+}
+
+// GetUserBoostsBetweenDB gets every boost of the user that was active at some point between from and to
+func (r *boostRepository) GetUserBoostsBetweenDB(ctx context.Context, userID int64, from time.Time, to time.Time) (res []entities.UserBoost, err error) {
+	return r.queryUserBoosts(ctx, getUserBoostsBetweenQuery, userID, from, to)
+}
+
+func (r *boostRepository) queryUserBoosts(ctx context.Context, query string, args ...interface{}) (res []entities.UserBoost, err error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userBoost entities.UserBoost
+		var boost entities.Boost
+		var durationSeconds int64
+
+		err := rows.Scan(
+			&userBoost.ID,
+			&userBoost.UserID,
+			&userBoost.BoostID,
+			&userBoost.Source.Type,
+			&userBoost.Source.Ref,
+			&userBoost.StartedAt,
+			&userBoost.EndsAt,
+			&boost.Slug,
+			&boost.Name,
+			&boost.Description,
+			&boost.EffectType,
+			&boost.EffectValue,
+			&durationSeconds,
+			&boost.StackingRule,
+			&boost.MaxStacks,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		boost.ID = userBoost.BoostID
+		boost.Duration = time.Duration(durationSeconds) * time.Second
+		userBoost.Boost = boost
+
+		res = append(res, userBoost)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type boostScanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *boostRepository) scanBoost(row boostScanner) (*entities.Boost, error) {
+	var boost entities.Boost
+	var durationSeconds int64
+	var rewardID sql.NullInt64
+
+	err := row.Scan(
+		&boost.ID,
+		&boost.Slug,
+		&boost.Name,
+		&boost.Description,
+		&boost.EffectType,
+		&boost.EffectValue,
+		&durationSeconds,
+		&boost.StackingRule,
+		&boost.MaxStacks,
+		&boost.GemCost,
+		&rewardID,
+		&boost.RewardSlug,
+		&boost.IsActive,
+		&boost.CreatedAt,
+		&boost.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	boost.Duration = time.Duration(durationSeconds) * time.Second
+	if rewardID.Valid {
+		boost.RewardID = &rewardID.Int64
+	}
+
+	return &boost, nil
+}
@@ -0,0 +1,108 @@
+package repositories
+
+const (
+	insertCurrencyLedgerQuery = `
+		INSERT INTO currency_ledger
+		    (
+		     user_id,
+		     currency,
+		     source_type,
+		     source_ref,
+		     delta,
+		     balance_after,
+		     created_at
+		    )
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	getCurrencyLedgerByUserIDQuery = `
+		SELECT
+		    id,
+		    user_id,
+		    currency,
+		    source_type,
+		    source_ref,
+		    delta,
+		    balance_after,
+		    created_at
+		FROM currency_ledger
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	countCurrencyLedgerByUserIDQuery = `
+		SELECT COUNT(*)
+		FROM currency_ledger
+		WHERE user_id = $1
+	`
+
+	flagCurrencyLedgerMismatchesQuery = `
+		INSERT INTO currency_ledger_mismatches
+		    (
+		     user_id,
+		     currency,
+		     balance,
+		     ledger_sum,
+		     first_detected_at,
+		     last_detected_at
+		    )
+		SELECT
+		    b.user_id,
+		    b.currency,
+		    b.balance,
+		    COALESCE(l.ledger_sum, 0),
+		    $1,
+		    $1
+		FROM (
+		    SELECT id AS user_id, 'COIN' AS currency, coin AS balance FROM users
+		    UNION ALL
+		    SELECT id AS user_id, 'GEM' AS currency, gem AS balance FROM users
+		) b
+		LEFT JOIN (
+		    SELECT user_id, currency, SUM(delta) AS ledger_sum
+		    FROM currency_ledger
+		    GROUP BY user_id, currency
+		) l ON l.user_id = b.user_id AND l.currency = b.currency
+		WHERE b.balance <> COALESCE(l.ledger_sum, 0)
+		ON CONFLICT (user_id, currency)
+		DO UPDATE SET
+		    balance = EXCLUDED.balance,
+		    ledger_sum = EXCLUDED.ledger_sum,
+		    last_detected_at = EXCLUDED.last_detected_at,
+		    first_detected_at = CASE
+		        WHEN currency_ledger_mismatches.resolved_at IS NULL THEN currency_ledger_mismatches.first_detected_at
+		        ELSE EXCLUDED.first_detected_at
+		    END,
+		    resolved_at = NULL
+	`
+
+	resolveCurrencyLedgerMismatchesQuery = `
+		UPDATE currency_ledger_mismatches
+		SET resolved_at = $1
+		WHERE resolved_at IS NULL AND last_detected_at < $1
+	`
+
+	getUnresolvedCurrencyLedgerMismatchesQuery = `
+		SELECT
+		    id,
+		    user_id,
+		    currency,
+		    balance,
+		    ledger_sum,
+		    first_detected_at,
+		    last_detected_at,
+		    resolved_at
+		FROM currency_ledger_mismatches
+		WHERE resolved_at IS NULL
+		ORDER BY last_detected_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	countUnresolvedCurrencyLedgerMismatchesQuery = `
+		SELECT COUNT(*)
+		FROM currency_ledger_mismatches
+		WHERE resolved_at IS NULL
+	`
+)
@@ -0,0 +1,178 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type CurrencyLedgerRepository interface {
+	WithTx(tx *sql.Tx) CurrencyLedgerRepository
+
+	CreateLedgerEntryDB(ctx context.Context, data *entities.CurrencyLedgerEntry) (id *int64, err error)
+	GetLedgerEntriesByUserIDDB(ctx context.Context, userID int64, limit, offset int) (res []entities.CurrencyLedgerEntry, err error)
+	CountLedgerEntriesByUserIDDB(ctx context.Context, userID int64) (count int64, err error)
+
+	FlagLedgerMismatchesDB(ctx context.Context, detectedAt time.Time) (flagged int64, err error)
+	ResolveLedgerMismatchesDB(ctx context.Context, detectedAt time.Time) (resolved int64, err error)
+	GetUnresolvedLedgerMismatchesDB(ctx context.Context, limit, offset int) (res []entities.CurrencyLedgerMismatch, err error)
+	CountUnresolvedLedgerMismatchesDB(ctx context.Context) (count int64, err error)
+}
+
+type currencyLedgerRepository struct {
+	BaseRepository
+}
+
+func NewCurrencyLedgerRepository(db *sql.DB) CurrencyLedgerRepository {
+	return &currencyLedgerRepository{
+		BaseRepository{
+			db:   db,
+			pool: db,
+		},
+	}
+}
+
+func (r *currencyLedgerRepository) WithTx(tx *sql.Tx) CurrencyLedgerRepository {
+	if tx == nil {
+		return r
+	}
+
+	return &currencyLedgerRepository{
+		BaseRepository{
+			db:   tx,
+			pool: r.pool,
+		},
+	}
+}
+
+func (r *currencyLedgerRepository) CreateLedgerEntryDB(ctx context.Context, data *entities.CurrencyLedgerEntry) (*int64, error) {
+	return insertCurrencyLedgerEntry(ctx, r.db, data)
+}
+
+// insertCurrencyLedgerEntry is shared with userRepository so balance updates can write the ledger in their own transaction
+func insertCurrencyLedgerEntry(ctx context.Context, db DbTx, data *entities.CurrencyLedgerEntry) (*int64, error) {
+	now := helper.NowUTC()
+	var id int64
+	err := db.QueryRowContext(
+		ctx,
+		insertCurrencyLedgerQuery,
+		data.UserID,
+		data.Currency.String(),
+		data.SourceType.String(),
+		data.SourceRef,
+		data.Delta,
+		data.BalanceAfter,
+		now,
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (r *currencyLedgerRepository) GetLedgerEntriesByUserIDDB(ctx context.Context, userID int64, limit, offset int) (res []entities.CurrencyLedgerEntry, err error) {
+	rows, err := r.db.QueryContext(ctx, getCurrencyLedgerByUserIDQuery, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry entities.CurrencyLedgerEntry
+		var currency, sourceType string
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&currency,
+			&sourceType,
+			&entry.SourceRef,
+			&entry.Delta,
+			&entry.BalanceAfter,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Currency = entities.UserBalanceType(currency)
+		entry.SourceType = entities.CurrencySourceType(sourceType)
+		res = append(res, entry)
+	}
+
+	return res, rows.Err()
+}
+
+func (r *currencyLedgerRepository) CountLedgerEntriesByUserIDDB(ctx context.Context, userID int64) (count int64, err error) {
+	err = r.db.QueryRowContext(ctx, countCurrencyLedgerByUserIDQuery, userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *currencyLedgerRepository) FlagLedgerMismatchesDB(ctx context.Context, detectedAt time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, flagCurrencyLedgerMismatchesQuery, detectedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (r *currencyLedgerRepository) ResolveLedgerMismatchesDB(ctx context.Context, detectedAt time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, resolveCurrencyLedgerMismatchesQuery, detectedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (r *currencyLedgerRepository) GetUnresolvedLedgerMismatchesDB(ctx context.Context, limit, offset int) (res []entities.CurrencyLedgerMismatch, err error) {
+	rows, err := r.db.QueryContext(ctx, getUnresolvedCurrencyLedgerMismatchesQuery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var mismatch entities.CurrencyLedgerMismatch
+		var currency string
+
+		err := rows.Scan(
+			&mismatch.ID,
+			&mismatch.UserID,
+			&currency,
+			&mismatch.Balance,
+			&mismatch.LedgerSum,
+			&mismatch.FirstDetectedAt,
+			&mismatch.LastDetectedAt,
+			&mismatch.ResolvedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		mismatch.Currency = entities.UserBalanceType(currency)
+		res = append(res, mismatch)
+	}
+
+	return res, rows.Err()
+}
+
+func (r *currencyLedgerRepository) CountUnresolvedLedgerMismatchesDB(ctx context.Context) (count int64, err error) {
+	err = r.db.QueryRowContext(ctx, countUnresolvedCurrencyLedgerMismatchesQuery).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -4,6 +4,7 @@ const (
 	insertDailyRewardQuery = `
         INSERT INTO daily_rewards 
 		(
+			 calendar_id,
 			 reward_id,
 			 day_number, 
 			 is_active,
@@ -11,13 +12,15 @@ const (
 			 created_at,
 			 updated_at
 		 )
-        VALUES ($1, $2, $3, $4, $5, $6)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
         RETURNING id
 	`
 
-	getDailyRewardsQuery = `
+	getDailyRewardsByCalendarIDQuery = `
         SELECT
                 dr.id,
+                dr.calendar_id,
+                c.slug,
                 dr.reward_id,
                 dr.day_number,
                 r.slug,
@@ -29,14 +32,18 @@ const (
                 rt.slug as reward_type_slug,
                 rt.name as reward_type_name
         FROM daily_rewards AS dr
+        	JOIN daily_reward_calendars AS c on c.id = dr.calendar_id
        		JOIN rewards AS r on r.id = dr.reward_id
         	JOIN reward_types AS rt on r.reward_type_id = rt.id
-        order by dr.id
+        WHERE dr.calendar_id = $1 AND dr.is_active = true
+        order by dr.day_number
 	`
 
 	getDailyRewardsWithPaginationQuery = `
 		SELECT
 			dr.id,
+			dr.calendar_id,
+			c.slug,
 			dr.reward_id,
 			dr.day_number,
 			r.slug,
@@ -48,20 +55,31 @@ const (
 			rt.slug as reward_type_slug,
 			rt.name as reward_type_name
 		FROM daily_rewards AS dr
+			JOIN daily_reward_calendars AS c ON c.id = dr.calendar_id
 			JOIN rewards AS r ON r.id = dr.reward_id
 			JOIN reward_types AS rt ON r.reward_type_id = rt.id
-		ORDER BY dr.id
-		LIMIT $1 OFFSET $2
+		WHERE ($1::BIGINT = 0 OR dr.calendar_id = $1)
+		ORDER BY dr.calendar_id, dr.day_number
+		LIMIT $2 OFFSET $3
 	`
 
 	countDailyRewardsQuery = `
 		SELECT COUNT(*) 
 		FROM daily_rewards
+		WHERE ($1::BIGINT = 0 OR calendar_id = $1)
+	`
+
+	getMaxDailyRewardDayNumberQuery = `
+		SELECT COALESCE(MAX(day_number), 0)
+		FROM daily_rewards
+		WHERE calendar_id = $1
 	`
 
 	getDailyRewardByIDQuery = `
 		SELECT
                 dr.id,
+                dr.calendar_id,
+                c.slug,
                 dr.reward_id,
                 rt.slug,
                 rt.name,
@@ -70,6 +88,7 @@ const (
                 dr.is_active,
                 dr.description
 		FROM daily_rewards AS dr
+        	JOIN daily_reward_calendars AS c on c.id = dr.calendar_id
        		JOIN rewards AS r on r.id = dr.reward_id
         	JOIN reward_types AS rt on r.reward_type_id = rt.id 
 		WHERE dr.id = $1 
@@ -78,11 +97,77 @@ const (
 	updateDailyRewardQuery = `
 		UPDATE daily_rewards
 		SET
-			reward_id = $1,
-			day_number = $2,
-			is_active = $3,
-			description = $4,
-			updated_at = $5
-		WHERE id = $6
+			calendar_id = $1,
+			reward_id = $2,
+			day_number = $3,
+			is_active = $4,
+			description = $5,
+			updated_at = $6
+		WHERE id = $7
+	`
+
+	insertDailyRewardCalendarQuery = `
+		INSERT INTO daily_reward_calendars (
+			slug,
+			name,
+			type,
+			starts_at,
+			created_at,
+			updated_at
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6
+		) RETURNING id`
+
+	updateDailyRewardCalendarQuery = `
+		UPDATE daily_reward_calendars
+		SET
+			name = $1,
+			type = $2,
+			starts_at = $3,
+			updated_at = $4
+		WHERE id = $5
+	`
+
+	selectDailyRewardCalendarQuery = `
+		SELECT
+			id,
+			slug,
+			name,
+			type,
+			starts_at,
+			created_at,
+			updated_at
+		FROM daily_reward_calendars
+	`
+
+	getDailyRewardCalendarsQuery = selectDailyRewardCalendarQuery + `
+		ORDER BY starts_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	countDailyRewardCalendarsQuery = `
+		SELECT
+			COUNT(*)
+		FROM daily_reward_calendars
+	`
+
+	getDailyRewardCalendarByIDQuery = selectDailyRewardCalendarQuery + `
+		WHERE id = $1
+	`
+
+	getDailyRewardCalendarBySlugQuery = selectDailyRewardCalendarQuery + `
+		WHERE slug = $1
+	`
+
+	// The calendar that started last is the active one, later calendars are scheduled
+	getActiveDailyRewardCalendarQuery = selectDailyRewardCalendarQuery + `
+		WHERE starts_at <= $1
+		ORDER BY starts_at DESC
+		LIMIT 1
 	`
 )
@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/database"
 	"github.com/winartodev/cat-cafe/pkg/helper"
 )
 
@@ -22,11 +24,20 @@ type DailyRewardRepository interface {
 	WithTx(tx *sql.Tx) DailyRewardRepository
 
 	CreateDailyRewardDB(ctx context.Context, data entities.DailyReward) (id *int64, err error)
-	GetDailyRewardsWithPaginationDB(ctx context.Context, limit, offset int) (res []entities.DailyReward, err error)
-	GetDailyRewardsDB(ctx context.Context) (res []entities.DailyReward, err error)
+	GetDailyRewardsWithPaginationDB(ctx context.Context, calendarID int64, limit, offset int) (res []entities.DailyReward, err error)
+	GetDailyRewardsByCalendarIDDB(ctx context.Context, calendarID int64) (res []entities.DailyReward, err error)
 	GetDailyRewardByIDDB(ctx context.Context, id int64) (res *entities.DailyReward, err error)
 	UpdateDailyRewardDB(ctx context.Context, id int64, data entities.DailyReward) (err error)
-	CountDailyRewardsDB(ctx context.Context) (count int64, err error)
+	CountDailyRewardsDB(ctx context.Context, calendarID int64) (count int64, err error)
+	GetMaxDailyRewardDayNumberDB(ctx context.Context, calendarID int64) (dayNumber int64, err error)
+
+	CreateDailyRewardCalendarDB(ctx context.Context, data entities.DailyRewardCalendar) (id *int64, err error)
+	UpdateDailyRewardCalendarDB(ctx context.Context, id int64, data entities.DailyRewardCalendar) (err error)
+	GetDailyRewardCalendarsDB(ctx context.Context, limit, offset int) (res []entities.DailyRewardCalendar, err error)
+	CountDailyRewardCalendarsDB(ctx context.Context) (totalRows int64, err error)
+	GetDailyRewardCalendarByIDDB(ctx context.Context, id int64) (res *entities.DailyRewardCalendar, err error)
+	GetDailyRewardCalendarBySlugDB(ctx context.Context, slug string) (res *entities.DailyRewardCalendar, err error)
+	GetActiveDailyRewardCalendarDB(ctx context.Context, at time.Time) (res *entities.DailyRewardCalendar, err error)
 
 	DailyRewardWithTx(ctx context.Context, fn func(txRepo *sql.Tx) error) (err error)
 
@@ -68,6 +79,7 @@ func (r *dailyRewardRepository) CreateDailyRewardDB(ctx context.Context, data en
 	var lastInsertId int64
 
 	err = r.db.QueryRowContext(ctx, insertDailyRewardQuery,
+		data.CalendarID,
 		data.Reward.ID,
 		data.DayNumber,
 		data.IsActive,
@@ -82,8 +94,8 @@ func (r *dailyRewardRepository) CreateDailyRewardDB(ctx context.Context, data en
 	return &lastInsertId, err
 }
 
-func (r *dailyRewardRepository) GetDailyRewardsDB(ctx context.Context) (res []entities.DailyReward, err error) {
-	rows, err := r.db.QueryContext(ctx, getDailyRewardsQuery)
+func (r *dailyRewardRepository) GetDailyRewardsByCalendarIDDB(ctx context.Context, calendarID int64) (res []entities.DailyReward, err error) {
+	rows, err := r.db.QueryContext(ctx, getDailyRewardsByCalendarIDQuery, calendarID)
 	if err != nil {
 		return nil, err
 	}
@@ -97,6 +109,8 @@ func (r *dailyRewardRepository) GetDailyRewardsDB(ctx context.Context) (res []en
 
 		err := rows.Scan(
 			&row.ID,
+			&row.CalendarID,
+			&row.CalendarSlug,
 			&reward.ID,
 			&row.DayNumber,
 			&reward.Slug,
@@ -122,10 +136,10 @@ func (r *dailyRewardRepository) GetDailyRewardsDB(ctx context.Context) (res []en
 	return res, err
 }
 
-func (r *dailyRewardRepository) GetDailyRewardsWithPaginationDB(ctx context.Context, limit, offset int) (res []entities.DailyReward, err error) {
+func (r *dailyRewardRepository) GetDailyRewardsWithPaginationDB(ctx context.Context, calendarID int64, limit, offset int) (res []entities.DailyReward, err error) {
 	var dailyRewards []entities.DailyReward
 
-	rows, err := r.db.QueryContext(ctx, getDailyRewardsWithPaginationQuery, limit, offset)
+	rows, err := r.db.QueryContext(ctx, getDailyRewardsWithPaginationQuery, calendarID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +152,8 @@ func (r *dailyRewardRepository) GetDailyRewardsWithPaginationDB(ctx context.Cont
 
 		err := rows.Scan(
 			&dailyReward.ID,
+			&dailyReward.CalendarID,
+			&dailyReward.CalendarSlug,
 			&reward.ID,
 			&dailyReward.DayNumber,
 			&reward.Slug,
@@ -173,6 +189,7 @@ func (r *dailyRewardRepository) UpdateDailyRewardDB(ctx context.Context, id int6
 
 	res, err := r.db.ExecContext(ctx,
 		updateDailyRewardQuery,
+		data.CalendarID,
 		data.Reward.ID,
 		data.DayNumber,
 		data.IsActive,
@@ -192,8 +209,8 @@ func (r *dailyRewardRepository) UpdateDailyRewardDB(ctx context.Context, id int6
 	return err
 }
 
-func (r *dailyRewardRepository) CountDailyRewardsDB(ctx context.Context) (count int64, err error) {
-	err = r.db.QueryRowContext(ctx, countDailyRewardsQuery).Scan(&count)
+func (r *dailyRewardRepository) CountDailyRewardsDB(ctx context.Context, calendarID int64) (count int64, err error) {
+	err = r.db.QueryRowContext(ctx, countDailyRewardsQuery, calendarID).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
@@ -201,6 +218,122 @@ func (r *dailyRewardRepository) CountDailyRewardsDB(ctx context.Context) (count
 	return count, nil
 }
 
+func (r *dailyRewardRepository) GetMaxDailyRewardDayNumberDB(ctx context.Context, calendarID int64) (dayNumber int64, err error) {
+	err = r.db.QueryRowContext(ctx, getMaxDailyRewardDayNumberQuery, calendarID).Scan(&dayNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	return dayNumber, nil
+}
+
+func (r *dailyRewardRepository) CreateDailyRewardCalendarDB(ctx context.Context, data entities.DailyRewardCalendar) (id *int64, err error) {
+	now := helper.NowUTC()
+
+	var lastInsertId int64
+	err = r.db.QueryRowContext(ctx, insertDailyRewardCalendarQuery,
+		data.Slug,
+		data.Name,
+		data.Type,
+		data.StartsAt,
+		now,
+		now,
+	).Scan(&lastInsertId)
+	if database.IsDuplicateError(err) {
+		return nil, apperror.ErrorAlreadyExists("daily reward calendar", "slug or starts_at", data.Slug)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &lastInsertId, nil
+}
+
+func (r *dailyRewardRepository) UpdateDailyRewardCalendarDB(ctx context.Context, id int64, data entities.DailyRewardCalendar) (err error) {
+	now := helper.NowUTC()
+
+	res, err := r.db.ExecContext(ctx, updateDailyRewardCalendarQuery,
+		data.Name,
+		data.Type,
+		data.StartsAt,
+		now,
+		id,
+	)
+	if database.IsDuplicateError(err) {
+		return apperror.ErrorAlreadyExists("daily reward calendar", "starts_at", data.StartsAt.Format(time.RFC3339))
+	} else if err != nil {
+		return err
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return apperror.ErrNoUpdateRecord
+	}
+
+	return nil
+}
+
+func (r *dailyRewardRepository) GetDailyRewardCalendarsDB(ctx context.Context, limit, offset int) (res []entities.DailyRewardCalendar, err error) {
+	rows, err := r.db.QueryContext(ctx, getDailyRewardCalendarsQuery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		calendar, err := r.scanDailyRewardCalendar(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *calendar)
+	}
+
+	return res, rows.Err()
+}
+
+func (r *dailyRewardRepository) CountDailyRewardCalendarsDB(ctx context.Context) (totalRows int64, err error) {
+	err = r.db.QueryRowContext(ctx, countDailyRewardCalendarsQuery).Scan(&totalRows)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalRows, nil
+}
+
+func (r *dailyRewardRepository) GetDailyRewardCalendarByIDDB(ctx context.Context, id int64) (res *entities.DailyRewardCalendar, err error) {
+	res, err = r.scanDailyRewardCalendar(r.db.QueryRowContext(ctx, getDailyRewardCalendarByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrorNotFound("daily reward calendar", "id", fmt.Sprintf("%d", id))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *dailyRewardRepository) GetDailyRewardCalendarBySlugDB(ctx context.Context, slug string) (res *entities.DailyRewardCalendar, err error) {
+	res, err = r.scanDailyRewardCalendar(r.db.QueryRowContext(ctx, getDailyRewardCalendarBySlugQuery, slug))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrorNotFound("daily reward calendar", "slug", slug)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetActiveDailyRewardCalendarDB returns the calendar that is active at the given time, nil when none has started yet
+func (r *dailyRewardRepository) GetActiveDailyRewardCalendarDB(ctx context.Context, at time.Time) (res *entities.DailyRewardCalendar, err error) {
+	res, err = r.scanDailyRewardCalendar(r.db.QueryRowContext(ctx, getActiveDailyRewardCalendarQuery, at))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (r *dailyRewardRepository) DailyRewardWithTx(ctx context.Context, fn func(txRepo *sql.Tx) error) error {
 	tx, err := r.pool.BeginTx(ctx, nil)
 	if err != nil {
@@ -222,6 +355,8 @@ func (r *dailyRewardRepository) scanDailyRewardTypeRow(row *sql.Row) (*entities.
 
 	err := row.Scan(
 		&dailyReward.ID,
+		&dailyReward.CalendarID,
+		&dailyReward.CalendarSlug,
 		&reward.ID,
 		&reward.Slug,
 		&reward.Name,
@@ -242,6 +377,29 @@ func (r *dailyRewardRepository) scanDailyRewardTypeRow(row *sql.Row) (*entities.
 	return &dailyReward, err
 }
 
+type dailyRewardCalendarScanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *dailyRewardRepository) scanDailyRewardCalendar(row dailyRewardCalendarScanner) (*entities.DailyRewardCalendar, error) {
+	var calendar entities.DailyRewardCalendar
+
+	err := row.Scan(
+		&calendar.ID,
+		&calendar.Slug,
+		&calendar.Name,
+		&calendar.Type,
+		&calendar.StartsAt,
+		&calendar.CreatedAt,
+		&calendar.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &calendar, nil
+}
+
 func (r *dailyRewardRepository) GetDailyRewardsRedis(ctx context.Context) (res []entities.DailyReward, err error) {
 	val, err := r.redis.Get(ctx, dailyRewardMasterRedisKey).Result()
 	if errors.Is(err, redis.Nil) {
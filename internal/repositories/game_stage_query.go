@@ -124,7 +124,8 @@ const (
 					'initial_cost', fi.initial_cost,
 					'initial_profit', fi.initial_profit,
 					'cooking_time', fi.cooking_time,
-					'auto_unlock', ks.auto_unlock
+					'auto_unlock', ks.auto_unlock,
+					'unlock_phase', ks.unlock_phase
 							)) as data
 			FROM kitchen_stations AS ks
 					 JOIN food_items fi ON fi.id = ks.food_item_id
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/cat-cafe/internal/entities"
+)
+
+const (
+	idempotencyResponseRedisKey = "idempotency:response:%d:%s"
+	idempotencyLockRedisKey     = "idempotency:lock:%d:%s"
+	idempotencyLockTokenBytes   = 16
+)
+
+// releaseIdempotencyLockScript deletes the lock only while it still holds the token of the request releasing it,
+// a lock that expired and was taken by a retry is left to the retry
+var releaseIdempotencyLockScript = redis.NewScript(`
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	end
+	return 0
+`)
+
+type IdempotencyRepository interface {
+	AcquireIdempotencyLockRedis(ctx context.Context, userID int64, key string, exp time.Duration) (token string, acquired bool, err error)
+	ReleaseIdempotencyLockRedis(ctx context.Context, userID int64, key string, token string) (err error)
+
+	SetIdempotentResponseRedis(ctx context.Context, userID int64, key string, data *entities.IdempotentResponse, exp time.Duration) (err error)
+	GetIdempotentResponseRedis(ctx context.Context, userID int64, key string) (res *entities.IdempotentResponse, err error)
+}
+
+type idempotencyRepository struct {
+	BaseRepository
+}
+
+func NewIdempotencyRepository(redis *redis.Client) IdempotencyRepository {
+	return &idempotencyRepository{
+		BaseRepository{
+			redis: redis,
+		},
+	}
+}
+
+// AcquireIdempotencyLockRedis takes the lock with a random token, only the same token can release it
+func (r *idempotencyRepository) AcquireIdempotencyLockRedis(ctx context.Context, userID int64, key string, exp time.Duration) (string, bool, error) {
+	buf := make([]byte, idempotencyLockTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", false, err
+	}
+
+	token := hex.EncodeToString(buf)
+
+	acquired, err := r.redis.SetNX(ctx, fmt.Sprintf(idempotencyLockRedisKey, userID, key), token, exp).Result()
+	if err != nil {
+		return "", false, err
+	}
+
+	return token, acquired, nil
+}
+
+func (r *idempotencyRepository) ReleaseIdempotencyLockRedis(ctx context.Context, userID int64, key string, token string) error {
+	return releaseIdempotencyLockScript.Run(ctx, r.redis, []string{fmt.Sprintf(idempotencyLockRedisKey, userID, key)}, token).Err()
+}
+
+func (r *idempotencyRepository) SetIdempotentResponseRedis(ctx context.Context, userID int64, key string, data *entities.IdempotentResponse, exp time.Duration) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return r.redis.Set(ctx, fmt.Sprintf(idempotencyResponseRedisKey, userID, key), jsonData, exp).Err()
+}
+
+func (r *idempotencyRepository) GetIdempotentResponseRedis(ctx context.Context, userID int64, key string) (*entities.IdempotentResponse, error) {
+	val, err := r.redis.Get(ctx, fmt.Sprintf(idempotencyResponseRedisKey, userID, key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var res entities.IdempotentResponse
+	if err := json.Unmarshal(val, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
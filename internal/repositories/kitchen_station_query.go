@@ -6,6 +6,7 @@ const (
 			stage_id,
 		    food_item_id, 
 		    auto_unlock,
+		    unlock_phase,
 		    created_at, 
 		    updated_at
 		) VALUES 
@@ -16,6 +17,7 @@ const (
 		    ks.stage_id,
 		    ks.food_item_id,
 		    ks.auto_unlock,
+		    ks.unlock_phase,
 		    fi.slug,
 		    fi.name,
 		    fi.initial_profit,
@@ -36,6 +38,7 @@ const (
 		    ks.stage_id,
 		    ks.food_item_id,
 		    ks.auto_unlock,
+		    ks.unlock_phase,
 		    fi.slug,
 		    fi.name,
 		    fi.initial_profit,
@@ -48,7 +48,7 @@ func (r *kitchenStationRepository) CreateKitchenStationsWithTxDB(ctx context.Con
 		return nil, nil
 	}
 
-	numFields := 5
+	numFields := 6
 	queryString := r.BuildBulkInsertQuery(bulkInsertKitchenStationQuery, len(items), numFields, "RETURNING id")
 
 	args := make([]interface{}, 0, len(items)*numFields)
@@ -59,6 +59,7 @@ func (r *kitchenStationRepository) CreateKitchenStationsWithTxDB(ctx context.Con
 			item.StageID,
 			item.FoodItemID,
 			item.AutoUnlock,
+			item.UnlockPhase,
 			now,
 			now,
 		)
@@ -101,6 +102,7 @@ func (r *kitchenStationRepository) GetKitchenStationsDB(ctx context.Context, sta
 			&kitchenStation.StageID,
 			&kitchenStation.FoodItemID,
 			&kitchenStation.AutoUnlock,
+			&kitchenStation.UnlockPhase,
 			&foodItem.Slug,
 			&foodItem.Name,
 			&foodItem.InitialProfit,
@@ -140,6 +142,7 @@ func (r *kitchenStationRepository) GetKitchenStationByFoodIDDB(ctx context.Conte
 		&kitchenStation.StageID,
 		&kitchenStation.FoodItemID,
 		&kitchenStation.AutoUnlock,
+		&kitchenStation.UnlockPhase,
 		&foodItem.Slug,
 		&foodItem.Name,
 		&foodItem.InitialProfit,
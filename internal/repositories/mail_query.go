@@ -0,0 +1,131 @@
+package repositories
+
+const (
+	insertMailQuery = `
+		INSERT INTO mails (
+			title,
+			body,
+			audience,
+			expires_at,
+			created_by,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	insertMailAttachmentsQuery = `
+		INSERT INTO mail_attachments (mail_id, reward_id, created_at)
+		SELECT $1, reward_id, $3
+		FROM UNNEST($2::BIGINT[]) AS reward_id
+	`
+
+	// insertUserMailsQuery skips the ids that are not users, the rows affected are the recipients
+	insertUserMailsQuery = `
+		INSERT INTO user_mails (mail_id, user_id, created_at, updated_at)
+		SELECT $1, u.id, $3, $3
+		FROM users u
+		WHERE u.id = ANY($2)
+		ON CONFLICT (mail_id, user_id) DO NOTHING
+	`
+
+	mailColumns = `
+			m.id,
+			m.title,
+			m.body,
+			m.audience,
+			m.expires_at,
+			m.created_by,
+			m.created_at,
+			m.updated_at
+	`
+
+	selectMailQuery = `
+		SELECT` + mailColumns + `,
+			(SELECT COUNT(*) FROM user_mails um WHERE um.mail_id = m.id AND m.audience = 'users') AS recipients,
+			(SELECT COUNT(*) FROM user_mails um WHERE um.mail_id = m.id AND um.claimed_at IS NOT NULL) AS claims
+		FROM mails m
+	`
+
+	getMailsQuery = selectMailQuery + `
+		ORDER BY m.id DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	countMailsQuery = `SELECT COUNT(*) FROM mails`
+
+	getMailByIDQuery = selectMailQuery + `
+		WHERE m.id = $1
+	`
+
+	getMailAttachmentsQuery = `
+		SELECT
+			ma.mail_id,
+			r.id,
+			r.slug,
+			r.name,
+			r.amount,
+			r.is_active,
+			rt.slug reward_type_slug,
+			rt.name reward_type_name
+		FROM mail_attachments ma
+			JOIN rewards r ON r.id = ma.reward_id
+			JOIN reward_types rt ON rt.id = r.reward_type_id
+		WHERE ma.mail_id = ANY($1)
+		ORDER BY ma.id
+	`
+
+	// userMailFrom is the mailbox of user $1 at $2. A mail to everyone reaches the players that signed up
+	// before it was sent, whether or not they have a row in user_mails yet. Expired mails are left out
+	userMailFrom = `
+		FROM mails m
+			LEFT JOIN user_mails um ON um.mail_id = m.id AND um.user_id = $1
+		WHERE (
+				um.id IS NOT NULL
+				OR (m.audience = 'everyone' AND m.created_at >= (SELECT u.created_at FROM users u WHERE u.id = $1))
+			)
+			AND (m.expires_at IS NULL OR m.expires_at > $2)
+	`
+
+	selectUserMailQuery = `
+		SELECT` + mailColumns + `,
+			um.read_at,
+			um.claimed_at
+	` + userMailFrom
+
+	getUserMailsQuery = selectUserMailQuery + `
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	countUserMailsQuery = `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE um.read_at IS NULL)
+	` + userMailFrom
+
+	getUserMailByIDQuery = selectUserMailQuery + `
+			AND m.id = $3
+	`
+
+	markUserMailReadQuery = `
+		INSERT INTO user_mails (mail_id, user_id, read_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $3, $3)
+		ON CONFLICT (mail_id, user_id)
+		DO UPDATE SET
+			read_at = COALESCE(user_mails.read_at, EXCLUDED.read_at),
+			updated_at = EXCLUDED.updated_at
+	`
+
+	// claimUserMailQuery only updates a mail that was not claimed yet, so it can be claimed once
+	claimUserMailQuery = `
+		INSERT INTO user_mails (mail_id, user_id, read_at, claimed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $3, $3, $3)
+		ON CONFLICT (mail_id, user_id)
+		DO UPDATE SET
+			read_at = COALESCE(user_mails.read_at, EXCLUDED.read_at),
+			claimed_at = EXCLUDED.claimed_at,
+			updated_at = EXCLUDED.updated_at
+		WHERE user_mails.claimed_at IS NULL
+	`
+)
@@ -0,0 +1,317 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type MailRepository interface {
+	WithTx(tx *sql.Tx) MailRepository
+	MailWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error)
+
+	CreateMailDB(ctx context.Context, data entities.Mail) (id int64, err error)
+	CreateMailAttachmentsDB(ctx context.Context, mailID int64, rewardIDs []int64) (err error)
+	CreateUserMailsDB(ctx context.Context, mailID int64, userIDs []int64) (recipients int64, err error)
+	GetMailsDB(ctx context.Context, limit, offset int) (res []entities.Mail, err error)
+	CountMailsDB(ctx context.Context) (totalRows int64, err error)
+	GetMailByIDDB(ctx context.Context, id int64) (res *entities.Mail, err error)
+	GetMailAttachmentsDB(ctx context.Context, mailIDs []int64) (res map[int64][]entities.Reward, err error)
+
+	GetUserMailsDB(ctx context.Context, userID int64, now time.Time, limit, offset int) (res []entities.UserMail, err error)
+	CountUserMailsDB(ctx context.Context, userID int64, now time.Time) (totalRows int64, unread int64, err error)
+	GetUserMailByIDDB(ctx context.Context, userID int64, id int64, now time.Time) (res *entities.UserMail, err error)
+	MarkUserMailReadDB(ctx context.Context, userID int64, id int64, now time.Time) (err error)
+	ClaimUserMailDB(ctx context.Context, userID int64, id int64, now time.Time) (err error)
+}
+
+type mailRepository struct {
+	BaseRepository
+}
+
+func NewMailRepository(db *sql.DB) MailRepository {
+	return &mailRepository{
+		BaseRepository: BaseRepository{
+			db:   db,
+			pool: db,
+		},
+	}
+}
+
+func (r *mailRepository) WithTx(tx *sql.Tx) MailRepository {
+	if tx == nil {
+		return r
+	}
+
+	return &mailRepository{
+		BaseRepository: BaseRepository{
+			db:   tx,
+			pool: r.pool,
+		},
+	}
+}
+
+func (r *mailRepository) MailWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *mailRepository) CreateMailDB(ctx context.Context, data entities.Mail) (id int64, err error) {
+	now := helper.NowUTC()
+
+	err = r.db.QueryRowContext(ctx, insertMailQuery,
+		data.Title,
+		data.Body,
+		data.Audience,
+		data.ExpiresAt,
+		data.CreatedBy,
+		now,
+		now,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *mailRepository) CreateMailAttachmentsDB(ctx context.Context, mailID int64, rewardIDs []int64) (err error) {
+	if len(rewardIDs) == 0 {
+		return nil
+	}
+
+	_, err = r.db.ExecContext(ctx, insertMailAttachmentsQuery, mailID, pq.Array(rewardIDs), helper.NowUTC())
+	return err
+}
+
+// CreateUserMailsDB delivers the mail to the users in a single insert, ids that are not users
+// are skipped. It returns how many users got the mail
+func (r *mailRepository) CreateUserMailsDB(ctx context.Context, mailID int64, userIDs []int64) (recipients int64, err error) {
+	res, err := r.db.ExecContext(ctx, insertUserMailsQuery, mailID, pq.Array(userIDs), helper.NowUTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *mailRepository) GetMailsDB(ctx context.Context, limit, offset int) (res []entities.Mail, err error) {
+	rows, err := r.db.QueryContext(ctx, getMailsQuery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		mail, err := r.scanMail(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *mail)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *mailRepository) CountMailsDB(ctx context.Context) (totalRows int64, err error) {
+	err = r.db.QueryRowContext(ctx, countMailsQuery).Scan(&totalRows)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalRows, nil
+}
+
+func (r *mailRepository) GetMailByIDDB(ctx context.Context, id int64) (res *entities.Mail, err error) {
+	res, err = r.scanMail(r.db.QueryRowContext(ctx, getMailByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrorNotFound("mail", "id", fmt.Sprint(id))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMailAttachmentsDB gets the rewards attached to the mails, keyed by mail id
+func (r *mailRepository) GetMailAttachmentsDB(ctx context.Context, mailIDs []int64) (res map[int64][]entities.Reward, err error) {
+	res = make(map[int64][]entities.Reward, len(mailIDs))
+	if len(mailIDs) == 0 {
+		return res, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, getMailAttachmentsQuery, pq.Array(mailIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mailID int64
+		var reward entities.Reward
+		var rewardType entities.RewardType
+
+		err := rows.Scan(
+			&mailID,
+			&reward.ID,
+			&reward.Slug,
+			&reward.Name,
+			&reward.Amount,
+			&reward.IsActive,
+			&rewardType.Slug,
+			&rewardType.Name,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		reward.RewardType = &rewardType
+		res[mailID] = append(res[mailID], reward)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetUserMailsDB gets the mailbox of the user at now, newest first
+func (r *mailRepository) GetUserMailsDB(ctx context.Context, userID int64, now time.Time, limit, offset int) (res []entities.UserMail, err error) {
+	rows, err := r.db.QueryContext(ctx, getUserMailsQuery, userID, now, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		mail, err := r.scanUserMail(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *mail)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *mailRepository) CountUserMailsDB(ctx context.Context, userID int64, now time.Time) (totalRows int64, unread int64, err error) {
+	err = r.db.QueryRowContext(ctx, countUserMailsQuery, userID, now).Scan(&totalRows, &unread)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return totalRows, unread, nil
+}
+
+// GetUserMailByIDDB gets a mail from the mailbox of the user, mails sent to others and expired
+// mails are not found
+func (r *mailRepository) GetUserMailByIDDB(ctx context.Context, userID int64, id int64, now time.Time) (res *entities.UserMail, err error) {
+	res, err = r.scanUserMail(r.db.QueryRowContext(ctx, getUserMailByIDQuery, userID, now, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrorNotFound("mail", "id", fmt.Sprint(id))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *mailRepository) MarkUserMailReadDB(ctx context.Context, userID int64, id int64, now time.Time) (err error) {
+	_, err = r.db.ExecContext(ctx, markUserMailReadQuery, id, userID, now)
+	return err
+}
+
+// ClaimUserMailDB marks the mail as claimed, it returns apperror.ErrNoUpdateRecord when it was claimed before
+func (r *mailRepository) ClaimUserMailDB(ctx context.Context, userID int64, id int64, now time.Time) (err error) {
+	res, err := r.db.ExecContext(ctx, claimUserMailQuery, id, userID, now)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return apperror.ErrNoUpdateRecord
+	}
+
+	return nil
+}
+
+type mailScanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *mailRepository) scanMail(row mailScanner) (*entities.Mail, error) {
+	var mail entities.Mail
+
+	err := row.Scan(
+		&mail.ID,
+		&mail.Title,
+		&mail.Body,
+		&mail.Audience,
+		&mail.ExpiresAt,
+		&mail.CreatedBy,
+		&mail.CreatedAt,
+		&mail.UpdatedAt,
+		&mail.Recipients,
+		&mail.Claims,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mail, nil
+}
+
+func (r *mailRepository) scanUserMail(row mailScanner) (*entities.UserMail, error) {
+	var mail entities.UserMail
+
+	err := row.Scan(
+		&mail.ID,
+		&mail.Title,
+		&mail.Body,
+		&mail.Audience,
+		&mail.ExpiresAt,
+		&mail.CreatedBy,
+		&mail.CreatedAt,
+		&mail.UpdatedAt,
+		&mail.ReadAt,
+		&mail.ClaimedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mail, nil
+}
@@ -0,0 +1,63 @@
+package repositories
+
+const (
+	insertUserSuspensionQuery = `
+		INSERT INTO user_suspensions (
+			user_id,
+			type,
+			reason,
+			expires_at,
+			created_by,
+			created_at,
+			updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		) RETURNING id
+	`
+
+	// Permanent suspensions come first, then the one that lasts the longest
+	getActiveUserSuspensionQuery = `
+		SELECT
+			id,
+			user_id,
+			type,
+			reason,
+			expires_at,
+			created_by,
+			lifted_at,
+			lifted_by,
+			created_at
+		FROM user_suspensions
+		WHERE user_id = $1
+			AND lifted_at IS NULL
+			AND (expires_at IS NULL OR expires_at > $2)
+		ORDER BY expires_at DESC NULLS FIRST
+		LIMIT 1
+	`
+
+	getUserSuspensionsQuery = `
+		SELECT
+			id,
+			user_id,
+			type,
+			reason,
+			expires_at,
+			created_by,
+			lifted_at,
+			lifted_by,
+			created_at
+		FROM user_suspensions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	liftUserSuspensionsQuery = `
+		UPDATE user_suspensions SET
+			lifted_at = $1,
+			lifted_by = $2,
+			updated_at = $1
+		WHERE user_id = $3
+			AND lifted_at IS NULL
+			AND (expires_at IS NULL OR expires_at > $1)
+	`
+)
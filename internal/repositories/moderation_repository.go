@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type ModerationRepository interface {
+	WithTx(tx *sql.Tx) ModerationRepository
+	ModerationWithTx(ctx context.Context, fn func(tx *sql.Tx) error) error
+
+	CreateSuspensionDB(ctx context.Context, data *entities.UserSuspension) (id *int64, err error)
+	GetActiveSuspensionByUserIDDB(ctx context.Context, userID int64, now time.Time) (res *entities.UserSuspension, err error)
+	GetSuspensionsByUserIDDB(ctx context.Context, userID int64) (res []entities.UserSuspension, err error)
+	LiftSuspensionsByUserIDDB(ctx context.Context, userID int64, liftedBy int64) (lifted int64, err error)
+}
+
+type moderationRepository struct {
+	BaseRepository
+}
+
+func NewModerationRepository(db *sql.DB) ModerationRepository {
+	return &moderationRepository{
+		BaseRepository{
+			db:   db,
+			pool: db,
+		},
+	}
+}
+
+func (r *moderationRepository) WithTx(tx *sql.Tx) ModerationRepository {
+	if tx == nil {
+		return r
+	}
+
+	return &moderationRepository{
+		BaseRepository{
+			db:   tx,
+			pool: r.pool,
+		},
+	}
+}
+
+func (r *moderationRepository) ModerationWithTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *moderationRepository) CreateSuspensionDB(ctx context.Context, data *entities.UserSuspension) (*int64, error) {
+	now := helper.NowUTC()
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		insertUserSuspensionQuery,
+		data.UserID,
+		data.Type.String(),
+		data.Reason,
+		data.ExpiresAt,
+		data.CreatedBy,
+		now,
+		now,
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (r *moderationRepository) GetActiveSuspensionByUserIDDB(ctx context.Context, userID int64, now time.Time) (*entities.UserSuspension, error) {
+	var suspension entities.UserSuspension
+	var suspensionType string
+
+	err := r.db.QueryRowContext(ctx, getActiveUserSuspensionQuery, userID, now).Scan(
+		&suspension.ID,
+		&suspension.UserID,
+		&suspensionType,
+		&suspension.Reason,
+		&suspension.ExpiresAt,
+		&suspension.CreatedBy,
+		&suspension.LiftedAt,
+		&suspension.LiftedBy,
+		&suspension.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	suspension.Type = entities.SuspensionType(suspensionType)
+
+	return &suspension, nil
+}
+
+func (r *moderationRepository) GetSuspensionsByUserIDDB(ctx context.Context, userID int64) ([]entities.UserSuspension, error) {
+	rows, err := r.db.QueryContext(ctx, getUserSuspensionsQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suspensions []entities.UserSuspension
+	for rows.Next() {
+		var suspension entities.UserSuspension
+		var suspensionType string
+
+		err := rows.Scan(
+			&suspension.ID,
+			&suspension.UserID,
+			&suspensionType,
+			&suspension.Reason,
+			&suspension.ExpiresAt,
+			&suspension.CreatedBy,
+			&suspension.LiftedAt,
+			&suspension.LiftedBy,
+			&suspension.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		suspension.Type = entities.SuspensionType(suspensionType)
+		suspensions = append(suspensions, suspension)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suspensions, nil
+}
+
+func (r *moderationRepository) LiftSuspensionsByUserIDDB(ctx context.Context, userID int64, liftedBy int64) (int64, error) {
+	res, err := r.db.ExecContext(ctx, liftUserSuspensionsQuery, helper.NowUTC(), liftedBy, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
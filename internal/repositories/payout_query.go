@@ -0,0 +1,99 @@
+package repositories
+
+const (
+	insertPayoutQuery = `
+		INSERT INTO payouts (
+			user_id,
+			reward_id,
+			currency,
+			amount,
+			source_type,
+			source_ref,
+			status,
+			next_attempt_at,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	payoutColumns = `
+			p.id,
+			p.user_id,
+			p.reward_id,
+			r.slug,
+			p.currency,
+			p.amount,
+			p.source_type,
+			p.source_ref,
+			p.status,
+			p.attempts,
+			p.next_attempt_at,
+			p.last_error,
+			p.provider_ref,
+			p.completed_at,
+			p.created_at,
+			p.updated_at
+	`
+
+	selectPayoutQuery = `
+		SELECT` + payoutColumns + `
+		FROM payouts p
+		JOIN rewards r ON r.id = p.reward_id
+	`
+
+	getPayoutsQuery = selectPayoutQuery + `
+		WHERE ($1 = '' OR p.status = $1)
+		ORDER BY p.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	countPayoutsQuery = `
+		SELECT COUNT(*) FROM payouts WHERE ($1 = '' OR status = $1)
+	`
+
+	getPayoutByIDQuery = selectPayoutQuery + `
+		WHERE p.id = $1
+	`
+
+	// claimDuePayoutsQuery leases the due payouts by pushing their next attempt to the end of the lease,
+	// a worker that dies mid-send leaves them to be picked up again once the lease runs out
+	claimDuePayoutsQuery = `
+		UPDATE payouts p
+		SET
+			attempts = p.attempts + 1,
+			next_attempt_at = $2,
+			updated_at = $1
+		FROM (
+			SELECT id FROM payouts
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		) due, rewards r
+		WHERE p.id = due.id AND r.id = p.reward_id
+		RETURNING` + payoutColumns
+
+	// updatePayoutAttemptQuery only applies to the attempt that claimed the payout
+	updatePayoutAttemptQuery = `
+		UPDATE payouts
+		SET
+			status = $1,
+			next_attempt_at = $2,
+			last_error = $3,
+			provider_ref = $4,
+			completed_at = $5,
+			updated_at = $6
+		WHERE id = $7 AND attempts = $8 AND status = 'pending'
+	`
+
+	retryDeadPayoutQuery = `
+		UPDATE payouts
+		SET
+			status = 'pending',
+			attempts = 0,
+			next_attempt_at = $1,
+			updated_at = $1
+		WHERE id = $2 AND status = 'dead'
+	`
+)
@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type PayoutRepository interface {
+	WithTx(tx *sql.Tx) PayoutRepository
+	PayoutWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error)
+
+	CreatePayoutDB(ctx context.Context, data entities.Payout) (id int64, err error)
+	GetPayoutsDB(ctx context.Context, status entities.PayoutStatus, limit, offset int) (res []entities.Payout, err error)
+	CountPayoutsDB(ctx context.Context, status entities.PayoutStatus) (totalRows int64, err error)
+	GetPayoutByIDDB(ctx context.Context, id int64) (res *entities.Payout, err error)
+
+	ClaimDuePayoutsDB(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) (res []entities.Payout, err error)
+	UpdatePayoutAttemptDB(ctx context.Context, data entities.Payout) (err error)
+	RetryDeadPayoutDB(ctx context.Context, id int64, now time.Time) (err error)
+}
+
+type payoutRepository struct {
+	BaseRepository
+}
+
+func NewPayoutRepository(db *sql.DB) PayoutRepository {
+	return &payoutRepository{
+		BaseRepository: BaseRepository{
+			db:   db,
+			pool: db,
+		},
+	}
+}
+
+func (r *payoutRepository) WithTx(tx *sql.Tx) PayoutRepository {
+	if tx == nil {
+		return r
+	}
+
+	return &payoutRepository{
+		BaseRepository: BaseRepository{
+			db:   tx,
+			pool: r.pool,
+		},
+	}
+}
+
+func (r *payoutRepository) PayoutWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *payoutRepository) CreatePayoutDB(ctx context.Context, data entities.Payout) (id int64, err error) {
+	now := helper.NowUTC()
+
+	err = r.db.QueryRowContext(ctx, insertPayoutQuery,
+		data.UserID,
+		data.RewardID,
+		data.Currency,
+		data.Amount,
+		data.Source.Type,
+		data.Source.Ref,
+		entities.PayoutStatusPending,
+		now,
+		now,
+		now,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetPayoutsDB gets the payouts with the status, every payout when the status is empty
+func (r *payoutRepository) GetPayoutsDB(ctx context.Context, status entities.PayoutStatus, limit, offset int) (res []entities.Payout, err error) {
+	return r.queryPayouts(ctx, getPayoutsQuery, status, limit, offset)
+}
+
+func (r *payoutRepository) CountPayoutsDB(ctx context.Context, status entities.PayoutStatus) (totalRows int64, err error) {
+	err = r.db.QueryRowContext(ctx, countPayoutsQuery, status).Scan(&totalRows)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalRows, nil
+}
+
+func (r *payoutRepository) GetPayoutByIDDB(ctx context.Context, id int64) (res *entities.Payout, err error) {
+	res, err = r.scanPayout(r.db.QueryRowContext(ctx, getPayoutByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrorNotFound("payout", "id", fmt.Sprint(id))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ClaimDuePayoutsDB leases up to limit pending payouts that are due, counting the attempt.
+// Payouts leased by another worker are skipped
+func (r *payoutRepository) ClaimDuePayoutsDB(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) (res []entities.Payout, err error) {
+	return r.queryPayouts(ctx, claimDuePayoutsQuery, now, leaseUntil, limit)
+}
+
+// UpdatePayoutAttemptDB saves the outcome of the attempt in data.Attempts, it returns
+// apperror.ErrNoUpdateRecord when the payout was claimed again in the meantime
+func (r *payoutRepository) UpdatePayoutAttemptDB(ctx context.Context, data entities.Payout) (err error) {
+	res, err := r.db.ExecContext(ctx, updatePayoutAttemptQuery,
+		data.Status,
+		data.NextAttemptAt,
+		data.LastError,
+		data.ProviderRef,
+		data.CompletedAt,
+		helper.NowUTC(),
+		data.ID,
+		data.Attempts,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return apperror.ErrNoUpdateRecord
+	}
+
+	return nil
+}
+
+// RetryDeadPayoutDB puts a dead payout back in the queue with fresh attempts
+func (r *payoutRepository) RetryDeadPayoutDB(ctx context.Context, id int64, now time.Time) (err error) {
+	res, err := r.db.ExecContext(ctx, retryDeadPayoutQuery, now, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return apperror.ErrNoUpdateRecord
+	}
+
+	return nil
+}
+
+func (r *payoutRepository) queryPayouts(ctx context.Context, query string, args ...interface{}) (res []entities.Payout, err error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		payout, err := r.scanPayout(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *payout)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type payoutScanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *payoutRepository) scanPayout(row payoutScanner) (*entities.Payout, error) {
+	var payout entities.Payout
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&payout.ID,
+		&payout.UserID,
+		&payout.RewardID,
+		&payout.RewardSlug,
+		&payout.Currency,
+		&payout.Amount,
+		&payout.Source.Type,
+		&payout.Source.Ref,
+		&payout.Status,
+		&payout.Attempts,
+		&payout.NextAttemptAt,
+		&payout.LastError,
+		&payout.ProviderRef,
+		&completedAt,
+		&payout.CreatedAt,
+		&payout.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		payout.CompletedAt = &completedAt.Time
+	}
+
+	return &payout, nil
+}
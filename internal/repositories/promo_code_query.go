@@ -0,0 +1,138 @@
+package repositories
+
+const (
+	insertPromoCodeQuery = `
+		INSERT INTO promo_codes (
+			code,
+			reward_id,
+			max_redemptions,
+			max_per_user,
+			starts_at,
+			ends_at,
+			is_active,
+			created_by,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	insertPromoCodeBatchQuery = `
+		INSERT INTO promo_code_batches (
+			name,
+			prefix,
+			size,
+			reward_id,
+			starts_at,
+			ends_at,
+			created_by,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	// insertPromoCodeBatchCodesQuery inserts single-use codes of a batch, codes that are taken are
+	// skipped so the rows affected are the codes that were created
+	insertPromoCodeBatchCodesQuery = `
+		INSERT INTO promo_codes (
+			code,
+			batch_id,
+			reward_id,
+			max_redemptions,
+			max_per_user,
+			starts_at,
+			ends_at,
+			is_active,
+			created_by,
+			created_at,
+			updated_at
+		)
+		SELECT code, $2, $3, 1, 1, $4, $5, TRUE, $6, $7, $7
+		FROM UNNEST($1::TEXT[]) AS code
+		ON CONFLICT (code) DO NOTHING
+	`
+
+	selectPromoCodeQuery = `
+		SELECT
+			pc.id,
+			pc.code,
+			pc.batch_id,
+			pc.reward_id,
+			r.slug,
+			pc.max_redemptions,
+			pc.max_per_user,
+			pc.redemptions,
+			pc.starts_at,
+			pc.ends_at,
+			pc.is_active,
+			pc.created_by,
+			pc.created_at,
+			pc.updated_at
+		FROM promo_codes pc
+			JOIN rewards r ON r.id = pc.reward_id
+	`
+
+	getPromoCodesQuery = selectPromoCodeQuery + `
+		WHERE ($1::BIGINT IS NULL OR pc.batch_id = $1)
+		ORDER BY pc.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	countPromoCodesQuery = `SELECT COUNT(*) FROM promo_codes WHERE ($1::BIGINT IS NULL OR batch_id = $1)`
+
+	getPromoCodeByIDQuery = selectPromoCodeQuery + `
+		WHERE pc.id = $1
+	`
+
+	getPromoCodeByCodeForUpdateQuery = selectPromoCodeQuery + `
+		WHERE pc.code = $1
+		FOR UPDATE OF pc
+	`
+
+	getPromoCodesByBatchIDQuery = selectPromoCodeQuery + `
+		WHERE pc.batch_id = $1
+		ORDER BY pc.id
+	`
+
+	incrementPromoCodeRedemptionsQuery = `
+		UPDATE promo_codes
+		SET redemptions = redemptions + 1, updated_at = $2
+		WHERE id = $1
+	`
+
+	insertPromoCodeRedemptionQuery = `
+		INSERT INTO promo_code_redemptions (promo_code_id, user_id, created_at)
+		VALUES ($1, $2, $3)
+	`
+
+	countUserPromoCodeRedemptionsQuery = `
+		SELECT COUNT(*) FROM promo_code_redemptions WHERE promo_code_id = $1 AND user_id = $2
+	`
+
+	selectPromoCodeBatchQuery = `
+		SELECT
+			b.id,
+			b.name,
+			b.prefix,
+			b.size,
+			b.reward_id,
+			r.slug,
+			b.starts_at,
+			b.ends_at,
+			b.created_by,
+			b.created_at
+		FROM promo_code_batches b
+			JOIN rewards r ON r.id = b.reward_id
+	`
+
+	getPromoCodeBatchesQuery = selectPromoCodeBatchQuery + `
+		ORDER BY b.id DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	countPromoCodeBatchesQuery = `SELECT COUNT(*) FROM promo_code_batches`
+
+	getPromoCodeBatchByIDQuery = selectPromoCodeBatchQuery + `
+		WHERE b.id = $1
+	`
+)
@@ -0,0 +1,349 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/database"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type PromoCodeRepository interface {
+	WithTx(tx *sql.Tx) PromoCodeRepository
+	PromoCodeWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error)
+
+	CreatePromoCodeDB(ctx context.Context, data entities.PromoCode) (id int64, err error)
+	GetPromoCodesDB(ctx context.Context, batchID *int64, limit, offset int) (res []entities.PromoCode, err error)
+	CountPromoCodesDB(ctx context.Context, batchID *int64) (totalRows int64, err error)
+	GetPromoCodeByIDDB(ctx context.Context, id int64) (res *entities.PromoCode, err error)
+
+	CreatePromoCodeBatchDB(ctx context.Context, data entities.PromoCodeBatch) (id int64, err error)
+	CreatePromoCodeBatchCodesDB(ctx context.Context, batch entities.PromoCodeBatch, codes []string) (created int64, err error)
+	GetPromoCodeBatchesDB(ctx context.Context, limit, offset int) (res []entities.PromoCodeBatch, err error)
+	CountPromoCodeBatchesDB(ctx context.Context) (totalRows int64, err error)
+	GetPromoCodeBatchByIDDB(ctx context.Context, id int64) (res *entities.PromoCodeBatch, err error)
+	GetPromoCodesByBatchIDDB(ctx context.Context, batchID int64) (res []entities.PromoCode, err error)
+
+	// GetPromoCodeByCodeForUpdateDB locks the code until the transaction ends, requires an active transaction
+	GetPromoCodeByCodeForUpdateDB(ctx context.Context, code string) (res *entities.PromoCode, err error)
+	CountUserPromoCodeRedemptionsDB(ctx context.Context, promoCodeID int64, userID int64) (count int64, err error)
+	CreatePromoCodeRedemptionDB(ctx context.Context, promoCodeID int64, userID int64) (err error)
+}
+
+type promoCodeRepository struct {
+	BaseRepository
+}
+
+func NewPromoCodeRepository(db *sql.DB) PromoCodeRepository {
+	return &promoCodeRepository{
+		BaseRepository: BaseRepository{
+			db:   db,
+			pool: db,
+		},
+	}
+}
+
+func (r *promoCodeRepository) WithTx(tx *sql.Tx) PromoCodeRepository {
+	if tx == nil {
+		return r
+	}
+
+	return &promoCodeRepository{
+		BaseRepository: BaseRepository{
+			db:   tx,
+			pool: r.pool,
+		},
+	}
+}
+
+func (r *promoCodeRepository) PromoCodeWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *promoCodeRepository) CreatePromoCodeDB(ctx context.Context, data entities.PromoCode) (id int64, err error) {
+	now := helper.NowUTC()
+
+	err = r.db.QueryRowContext(ctx, insertPromoCodeQuery,
+		data.Code,
+		data.RewardID,
+		data.MaxRedemptions,
+		data.MaxPerUser,
+		data.StartsAt,
+		data.EndsAt,
+		data.IsActive,
+		data.CreatedBy,
+		now,
+		now,
+	).Scan(&id)
+	if database.IsDuplicateError(err) {
+		return 0, apperror.ErrorAlreadyExists("promo code", "code", data.Code)
+	} else if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *promoCodeRepository) GetPromoCodesDB(ctx context.Context, batchID *int64, limit, offset int) (res []entities.PromoCode, err error) {
+	rows, err := r.db.QueryContext(ctx, getPromoCodesQuery, batchID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPromoCodes(rows)
+}
+
+func (r *promoCodeRepository) CountPromoCodesDB(ctx context.Context, batchID *int64) (totalRows int64, err error) {
+	err = r.db.QueryRowContext(ctx, countPromoCodesQuery, batchID).Scan(&totalRows)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalRows, nil
+}
+
+func (r *promoCodeRepository) GetPromoCodeByIDDB(ctx context.Context, id int64) (res *entities.PromoCode, err error) {
+	res, err = r.scanPromoCode(r.db.QueryRowContext(ctx, getPromoCodeByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrorNotFound("promo code", "id", fmt.Sprint(id))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *promoCodeRepository) CreatePromoCodeBatchDB(ctx context.Context, data entities.PromoCodeBatch) (id int64, err error) {
+	err = r.db.QueryRowContext(ctx, insertPromoCodeBatchQuery,
+		data.Name,
+		data.Prefix,
+		data.Size,
+		data.RewardID,
+		data.StartsAt,
+		data.EndsAt,
+		data.CreatedBy,
+		helper.NowUTC(),
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// CreatePromoCodeBatchCodesDB inserts the codes of the batch in a single insert. Codes that already
+// exist are skipped, it returns how many codes were created
+func (r *promoCodeRepository) CreatePromoCodeBatchCodesDB(ctx context.Context, batch entities.PromoCodeBatch, codes []string) (created int64, err error) {
+	res, err := r.db.ExecContext(ctx, insertPromoCodeBatchCodesQuery,
+		pq.Array(codes),
+		batch.ID,
+		batch.RewardID,
+		batch.StartsAt,
+		batch.EndsAt,
+		batch.CreatedBy,
+		helper.NowUTC(),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *promoCodeRepository) GetPromoCodeBatchesDB(ctx context.Context, limit, offset int) (res []entities.PromoCodeBatch, err error) {
+	rows, err := r.db.QueryContext(ctx, getPromoCodeBatchesQuery, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		batch, err := r.scanPromoCodeBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *batch)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *promoCodeRepository) CountPromoCodeBatchesDB(ctx context.Context) (totalRows int64, err error) {
+	err = r.db.QueryRowContext(ctx, countPromoCodeBatchesQuery).Scan(&totalRows)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalRows, nil
+}
+
+func (r *promoCodeRepository) GetPromoCodeBatchByIDDB(ctx context.Context, id int64) (res *entities.PromoCodeBatch, err error) {
+	res, err = r.scanPromoCodeBatch(r.db.QueryRowContext(ctx, getPromoCodeBatchByIDQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrorNotFound("promo code batch", "id", fmt.Sprint(id))
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *promoCodeRepository) GetPromoCodesByBatchIDDB(ctx context.Context, batchID int64) (res []entities.PromoCode, err error) {
+	rows, err := r.db.QueryContext(ctx, getPromoCodesByBatchIDQuery, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPromoCodes(rows)
+}
+
+// GetPromoCodeByCodeForUpdateDB returns nil when there is no such code
+func (r *promoCodeRepository) GetPromoCodeByCodeForUpdateDB(ctx context.Context, code string) (res *entities.PromoCode, err error) {
+	if _, ok := r.db.(*sql.Tx); !ok {
+		return nil, apperror.ErrRequiredActiveTx
+	}
+
+	res, err = r.scanPromoCode(r.db.QueryRowContext(ctx, getPromoCodeByCodeForUpdateQuery, code))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *promoCodeRepository) CountUserPromoCodeRedemptionsDB(ctx context.Context, promoCodeID int64, userID int64) (count int64, err error) {
+	err = r.db.QueryRowContext(ctx, countUserPromoCodeRedemptionsQuery, promoCodeID, userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CreatePromoCodeRedemptionDB records the redemption and counts it on the code
+func (r *promoCodeRepository) CreatePromoCodeRedemptionDB(ctx context.Context, promoCodeID int64, userID int64) (err error) {
+	now := helper.NowUTC()
+
+	_, err = r.db.ExecContext(ctx, insertPromoCodeRedemptionQuery, promoCodeID, userID, now)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.db.ExecContext(ctx, incrementPromoCodeRedemptionsQuery, promoCodeID, now)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return apperror.ErrNoUpdateRecord
+	}
+
+	return nil
+}
+
+type promoCodeScanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *promoCodeRepository) scanPromoCode(row promoCodeScanner) (*entities.PromoCode, error) {
+	var promoCode entities.PromoCode
+	var reward entities.Reward
+
+	err := row.Scan(
+		&promoCode.ID,
+		&promoCode.Code,
+		&promoCode.BatchID,
+		&promoCode.RewardID,
+		&reward.Slug,
+		&promoCode.MaxRedemptions,
+		&promoCode.MaxPerUser,
+		&promoCode.Redemptions,
+		&promoCode.StartsAt,
+		&promoCode.EndsAt,
+		&promoCode.IsActive,
+		&promoCode.CreatedBy,
+		&promoCode.CreatedAt,
+		&promoCode.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reward.ID = promoCode.RewardID
+	promoCode.Reward = &reward
+
+	return &promoCode, nil
+}
+
+func (r *promoCodeRepository) scanPromoCodes(rows *sql.Rows) (res []entities.PromoCode, err error) {
+	for rows.Next() {
+		promoCode, err := r.scanPromoCode(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *promoCode)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *promoCodeRepository) scanPromoCodeBatch(row promoCodeScanner) (*entities.PromoCodeBatch, error) {
+	var batch entities.PromoCodeBatch
+	var reward entities.Reward
+
+	err := row.Scan(
+		&batch.ID,
+		&batch.Name,
+		&batch.Prefix,
+		&batch.Size,
+		&batch.RewardID,
+		&reward.Slug,
+		&batch.StartsAt,
+		&batch.EndsAt,
+		&batch.CreatedBy,
+		&batch.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reward.ID = batch.RewardID
+	batch.Reward = &reward
+
+	return &batch, nil
+}
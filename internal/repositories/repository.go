@@ -64,7 +64,17 @@ type Repository struct {
 	KitchenStationRepository      KitchenStationRepository
 	UpgradeRepository             UpgradeRepository
 	StageUpgradeRepository        StageUpgradeRepository
+	StageRequirementRepository    StageRequirementRepository
+	BoostRepository               BoostRepository
+	PayoutRepository              PayoutRepository
 	TutorialRepository            TutorialRepository
+	AdminRepository               AdminRepository
+	CurrencyLedgerRepository      CurrencyLedgerRepository
+	IdempotencyRepository         IdempotencyRepository
+	SessionRepository             SessionRepository
+	ModerationRepository          ModerationRepository
+	MailRepository                MailRepository
+	PromoCodeRepository           PromoCodeRepository
 }
 
 func SetupRepository(db *sql.DB, client *redis.Client) *Repository {
@@ -82,6 +92,16 @@ func SetupRepository(db *sql.DB, client *redis.Client) *Repository {
 		KitchenStationRepository:      NewKitchenStationRepository(db),
 		UpgradeRepository:             NewUpgradeRepository(db),
 		StageUpgradeRepository:        NewStageUpgradeRepository(db),
+		StageRequirementRepository:    NewStageRequirementRepository(db),
+		BoostRepository:               NewBoostRepository(db),
+		PayoutRepository:              NewPayoutRepository(db),
 		TutorialRepository:            NewTutorialRepository(db, client),
+		AdminRepository:               NewAdminRepository(db),
+		CurrencyLedgerRepository:      NewCurrencyLedgerRepository(db),
+		IdempotencyRepository:         NewIdempotencyRepository(client),
+		SessionRepository:             NewSessionRepository(db, client),
+		ModerationRepository:          NewModerationRepository(db),
+		MailRepository:                NewMailRepository(db),
+		PromoCodeRepository:           NewPromoCodeRepository(db),
 	}
 }
@@ -82,4 +82,32 @@ const (
 		SELECT COUNT(*) 
 		FROM rewards
 	`
+
+	rewardBundleItemInsertQuery = `
+		INSERT INTO reward_bundle_items (
+		    reward_id,
+		    reward_type_id,
+		    boost_id,
+		    amount,
+		    created_at,
+		    updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	getRewardBundleItemsQuery = `
+		SELECT
+		    rbi.id,
+		    rbi.reward_id,
+		    rt.id reward_type_id,
+		    rt.slug reward_type_slug,
+		    rt.name reward_type_name,
+		    rbi.amount,
+		    rbi.boost_id,
+		    COALESCE(b.slug, '') boost_slug
+		FROM reward_bundle_items AS rbi
+			JOIN reward_types AS rt ON rbi.reward_type_id = rt.id
+			LEFT JOIN boosts AS b ON rbi.boost_id = b.id
+		WHERE rbi.reward_id = $1
+		ORDER BY rbi.id
+	`
 )
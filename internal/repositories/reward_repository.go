@@ -13,6 +13,8 @@ import (
 
 type RewardRepository interface {
 	WithTx(tx *sql.Tx) RewardRepository
+	RewardWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error)
+
 	CreateRewardTypeDB(ctx context.Context, data entities.RewardType) (id *int64, err error)
 	UpdateRewardTypesDB(ctx context.Context, id int64, data entities.RewardType) (err error)
 	GetRewardTypeBySlugDB(ctx context.Context, slug string) (res *entities.RewardType, err error)
@@ -25,6 +27,9 @@ type RewardRepository interface {
 	GetRewardBySlugDB(ctx context.Context, slug string) (data *entities.Reward, err error)
 	GetRewardByIDDB(ctx context.Context, id int64) (data *entities.Reward, err error)
 	CountRewardDB(ctx context.Context) (count int64, err error)
+
+	CreateRewardBundleItemsDB(ctx context.Context, rewardID int64, items []entities.RewardBundleItem) (err error)
+	GetRewardBundleItemsDB(ctx context.Context, rewardID int64) (res []entities.RewardBundleItem, err error)
 }
 
 type rewardRepository struct {
@@ -51,6 +56,21 @@ func (r *rewardRepository) WithTx(tx *sql.Tx) RewardRepository {
 	}
 }
 
+func (r *rewardRepository) RewardWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (r *rewardRepository) CreateRewardTypeDB(ctx context.Context, data entities.RewardType) (id *int64, err error) {
 	now := helper.NowUTC()
 	var lastInsertId int64
@@ -213,6 +233,62 @@ func (r *rewardRepository) CountRewardDB(ctx context.Context) (count int64, err
 	return count, nil
 }
 
+func (r *rewardRepository) CreateRewardBundleItemsDB(ctx context.Context, rewardID int64, items []entities.RewardBundleItem) (err error) {
+	now := helper.NowUTC()
+
+	for _, item := range items {
+		_, err = r.db.ExecContext(ctx,
+			rewardBundleItemInsertQuery,
+			rewardID,
+			item.RewardType.ID,
+			item.BoostID,
+			item.Amount,
+			now,
+			now,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *rewardRepository) GetRewardBundleItemsDB(ctx context.Context, rewardID int64) (res []entities.RewardBundleItem, err error) {
+	rows, err := r.db.QueryContext(ctx, getRewardBundleItemsQuery, rewardID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entities.RewardBundleItem
+		var rewardType entities.RewardType
+		var rewardTypeID int64
+
+		err := rows.Scan(
+			&item.ID,
+			&item.RewardID,
+			&rewardTypeID,
+			&rewardType.Slug,
+			&rewardType.Name,
+			&item.Amount,
+			&item.BoostID,
+			&item.BoostSlug,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rewardType.ID = &rewardTypeID
+		item.RewardType = &rewardType
+		res = append(res, item)
+	}
+
+	return res, rows.Err()
+}
+
 func (r *rewardRepository) scanRewardTypeRow(row *sql.Row) (*entities.RewardType, error) {
 	var res entities.RewardType
 	var id int64
@@ -0,0 +1,104 @@
+package repositories
+
+const (
+	insertSessionQuery = `
+		INSERT INTO user_sessions (
+			user_id,
+			device_name,
+			user_agent,
+			ip_address,
+			last_used_at,
+			expires_at,
+			created_at,
+			updated_at
+		) VALUES (
+			$1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, $8
+		) RETURNING id
+	`
+
+	getSessionByIDQuery = `
+		SELECT
+			id,
+			user_id,
+			COALESCE(device_name, ''),
+			COALESCE(user_agent, ''),
+			COALESCE(ip_address, ''),
+			last_used_at,
+			expires_at,
+			revoked_at,
+			COALESCE(revoke_reason, ''),
+			created_at
+		FROM user_sessions
+		WHERE id = $1
+	`
+
+	getActiveSessionsByUserIDQuery = `
+		SELECT
+			id,
+			user_id,
+			COALESCE(device_name, ''),
+			COALESCE(user_agent, ''),
+			COALESCE(ip_address, ''),
+			last_used_at,
+			expires_at,
+			revoked_at,
+			COALESCE(revoke_reason, ''),
+			created_at
+		FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_used_at DESC
+	`
+
+	touchSessionQuery = `
+		UPDATE user_sessions SET
+			user_agent = COALESCE(NULLIF($1, ''), user_agent),
+			ip_address = COALESCE(NULLIF($2, ''), ip_address),
+			last_used_at = $3,
+			expires_at = $4,
+			updated_at = $3
+		WHERE id = $5 AND revoked_at IS NULL
+	`
+
+	revokeSessionQuery = `
+		UPDATE user_sessions SET
+			revoked_at = $1,
+			revoke_reason = $2,
+			updated_at = $1
+		WHERE id = $3 AND revoked_at IS NULL
+	`
+
+	revokeSessionsByUserIDQuery = `
+		UPDATE user_sessions SET
+			revoked_at = $1,
+			revoke_reason = $2,
+			updated_at = $1
+		WHERE user_id = $3 AND revoked_at IS NULL
+		RETURNING id
+	`
+
+	insertSessionRefreshTokenQuery = `
+		INSERT INTO user_session_refresh_tokens (
+			session_id,
+			token_hash,
+			expires_at,
+			created_at
+		) VALUES (
+			$1, $2, $3, $4
+		) RETURNING id
+	`
+
+	getSessionRefreshTokenByHashForUpdateQuery = `
+		SELECT
+			id,
+			session_id,
+			token_hash,
+			expires_at,
+			used_at,
+			created_at
+		FROM user_session_refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+
+	markSessionRefreshTokenUsedQuery = `UPDATE user_session_refresh_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`
+)
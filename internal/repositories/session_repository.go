@@ -0,0 +1,258 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+const (
+	sessionRevokedRedisKey = "user:session:revoked:%d"
+)
+
+type SessionRepository interface {
+	WithTx(tx *sql.Tx) SessionRepository
+	SessionWithTx(ctx context.Context, fn func(tx *sql.Tx) error) error
+
+	CreateSessionDB(ctx context.Context, data *entities.UserSession) (id *int64, err error)
+	GetSessionByIDDB(ctx context.Context, id int64) (res *entities.UserSession, err error)
+	GetActiveSessionsByUserIDDB(ctx context.Context, userID int64, now time.Time) (res []entities.UserSession, err error)
+	TouchSessionDB(ctx context.Context, id int64, device entities.SessionDevice, lastUsedAt time.Time, expiresAt time.Time) (err error)
+	RevokeSessionDB(ctx context.Context, id int64, reason string) (revoked bool, err error)
+	RevokeSessionsByUserIDDB(ctx context.Context, userID int64, reason string) (sessionIDs []int64, err error)
+
+	CreateRefreshTokenDB(ctx context.Context, data *entities.UserSessionRefreshToken) (id *int64, err error)
+	GetRefreshTokenByHashForUpdateDB(ctx context.Context, tokenHash string) (res *entities.UserSessionRefreshToken, err error)
+	MarkRefreshTokenUsedDB(ctx context.Context, id int64, usedAt time.Time) (err error)
+
+	SetSessionRevokedRedis(ctx context.Context, sessionID int64, exp time.Duration) (err error)
+	IsSessionRevokedRedis(ctx context.Context, sessionID int64) bool
+}
+
+type sessionRepository struct {
+	BaseRepository
+}
+
+func NewSessionRepository(db *sql.DB, redis *redis.Client) SessionRepository {
+	return &sessionRepository{
+		BaseRepository{
+			db:    db,
+			pool:  db,
+			redis: redis,
+		},
+	}
+}
+
+func (r *sessionRepository) WithTx(tx *sql.Tx) SessionRepository {
+	if tx == nil {
+		return r
+	}
+
+	return &sessionRepository{
+		BaseRepository{
+			db:    tx,
+			pool:  r.pool,
+			redis: r.redis,
+		},
+	}
+}
+
+func (r *sessionRepository) SessionWithTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *sessionRepository) CreateSessionDB(ctx context.Context, data *entities.UserSession) (*int64, error) {
+	now := helper.NowUTC()
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		insertSessionQuery,
+		data.UserID,
+		data.DeviceName,
+		data.UserAgent,
+		data.IPAddress,
+		data.LastUsedAt,
+		data.ExpiresAt,
+		now,
+		now,
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (r *sessionRepository) GetSessionByIDDB(ctx context.Context, id int64) (*entities.UserSession, error) {
+	row := r.db.QueryRowContext(ctx, getSessionByIDQuery, id)
+	return r.scanSessionRow(row)
+}
+
+func (r *sessionRepository) GetActiveSessionsByUserIDDB(ctx context.Context, userID int64, now time.Time) ([]entities.UserSession, error) {
+	rows, err := r.db.QueryContext(ctx, getActiveSessionsByUserIDQuery, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []entities.UserSession
+	for rows.Next() {
+		var session entities.UserSession
+		err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.DeviceName,
+			&session.UserAgent,
+			&session.IPAddress,
+			&session.LastUsedAt,
+			&session.ExpiresAt,
+			&session.RevokedAt,
+			&session.RevokeReason,
+			&session.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) TouchSessionDB(ctx context.Context, id int64, device entities.SessionDevice, lastUsedAt time.Time, expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, touchSessionQuery, device.UserAgent, device.IPAddress, lastUsedAt, expiresAt, id)
+	return err
+}
+
+func (r *sessionRepository) RevokeSessionDB(ctx context.Context, id int64, reason string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, revokeSessionQuery, helper.NowUTC(), reason, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *sessionRepository) RevokeSessionsByUserIDDB(ctx context.Context, userID int64, reason string) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, revokeSessionsByUserIDQuery, helper.NowUTC(), reason, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessionIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		sessionIDs = append(sessionIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessionIDs, nil
+}
+
+func (r *sessionRepository) CreateRefreshTokenDB(ctx context.Context, data *entities.UserSessionRefreshToken) (*int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		insertSessionRefreshTokenQuery,
+		data.SessionID,
+		data.TokenHash,
+		data.ExpiresAt,
+		helper.NowUTC(),
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (r *sessionRepository) GetRefreshTokenByHashForUpdateDB(ctx context.Context, tokenHash string) (*entities.UserSessionRefreshToken, error) {
+	var token entities.UserSessionRefreshToken
+	err := r.db.QueryRowContext(ctx, getSessionRefreshTokenByHashForUpdateQuery, tokenHash).Scan(
+		&token.ID,
+		&token.SessionID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *sessionRepository) MarkRefreshTokenUsedDB(ctx context.Context, id int64, usedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, markSessionRefreshTokenUsedQuery, usedAt, id)
+	return err
+}
+
+func (r *sessionRepository) SetSessionRevokedRedis(ctx context.Context, sessionID int64, exp time.Duration) error {
+	return r.redis.Set(ctx, fmt.Sprintf(sessionRevokedRedisKey, sessionID), "1", exp).Err()
+}
+
+func (r *sessionRepository) IsSessionRevokedRedis(ctx context.Context, sessionID int64) bool {
+	_, err := r.redis.Get(ctx, fmt.Sprintf(sessionRevokedRedisKey, sessionID)).Result()
+	return err == nil
+}
+
+func (r *sessionRepository) scanSessionRow(row *sql.Row) (*entities.UserSession, error) {
+	var session entities.UserSession
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.DeviceName,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+		&session.RevokeReason,
+		&session.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
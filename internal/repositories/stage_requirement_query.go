@@ -0,0 +1,30 @@
+package repositories
+
+const (
+	insertStageRequirementQuery = `
+		INSERT INTO stage_completion_requirements (
+			game_stage_id,
+			type,
+			target,
+			created_at,
+			updated_at
+		) VALUES
+	`
+
+	getStageRequirementsQuery = `
+		SELECT
+			id,
+			game_stage_id,
+			type,
+			target,
+			created_at,
+			updated_at
+		FROM stage_completion_requirements
+		WHERE game_stage_id = $1
+		ORDER BY id ASC
+	`
+
+	deleteStageRequirementsQuery = `
+		DELETE FROM stage_completion_requirements WHERE game_stage_id = $1
+	`
+)
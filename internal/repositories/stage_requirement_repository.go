@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/database"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type StageRequirementRepository interface {
+	WithTx(tx *sql.Tx) StageRequirementRepository
+	StageRequirementWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error)
+
+	BulkCreateStageRequirementsDB(ctx context.Context, data []entities.StageCompletionRequirement) (err error)
+	GetStageRequirementsDB(ctx context.Context, stageID int64) (res []entities.StageCompletionRequirement, err error)
+	DeleteStageRequirementsDB(ctx context.Context, stageID int64) (err error)
+}
+
+type stageRequirementRepository struct {
+	BaseRepository
+}
+
+func NewStageRequirementRepository(db *sql.DB) StageRequirementRepository {
+	return &stageRequirementRepository{
+		BaseRepository: BaseRepository{
+			db:   db,
+			pool: db,
+		},
+	}
+}
+
+func (r *stageRequirementRepository) WithTx(tx *sql.Tx) StageRequirementRepository {
+	if tx == nil {
+		return r
+	}
+
+	return &stageRequirementRepository{
+		BaseRepository: BaseRepository{
+			db:   tx,
+			pool: r.pool,
+		},
+	}
+}
+
+func (r *stageRequirementRepository) StageRequirementWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *stageRequirementRepository) BulkCreateStageRequirementsDB(ctx context.Context, data []entities.StageCompletionRequirement) (err error) {
+	if len(data) == 0 {
+		return nil
+	}
+
+	numFields := 5
+	queryString := r.BuildBulkInsertQuery(insertStageRequirementQuery, len(data), numFields, "")
+
+	args := make([]interface{}, 0, len(data)*numFields)
+	now := helper.NowUTC()
+
+	for _, item := range data {
+		args = append(args,
+			item.StageID,
+			item.Type,
+			item.Target,
+			now,
+			now,
+		)
+	}
+
+	_, err = r.db.ExecContext(ctx, queryString, args...)
+	if database.IsDuplicateError(err) {
+		return apperror.ErrConflict
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *stageRequirementRepository) GetStageRequirementsDB(ctx context.Context, stageID int64) (res []entities.StageCompletionRequirement, err error) {
+	rows, err := r.db.QueryContext(ctx, getStageRequirementsQuery, stageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var requirement entities.StageCompletionRequirement
+		err := rows.Scan(
+			&requirement.ID,
+			&requirement.StageID,
+			&requirement.Type,
+			&requirement.Target,
+			&requirement.CreatedAt,
+			&requirement.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, requirement)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *stageRequirementRepository) DeleteStageRequirementsDB(ctx context.Context, stageID int64) (err error) {
+	_, err = r.db.ExecContext(ctx, deleteStageRequirementsQuery, stageID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -35,7 +35,7 @@ const (
 	getUpgradeByStageIDAndSlugQuery = `
 		SELECT 
 		    gsu.id,
-		    u.slug, u.name, u.cost,
+		    u.id, u.slug, u.name, u.cost,
     		u.cost_type, u.effect_value, u.effect_unit,
     		u.effect_type, u.effect_target, u.effect_target_id, 
     		COALESCE(fi.slug, '') AS target_name
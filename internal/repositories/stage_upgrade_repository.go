@@ -168,6 +168,7 @@ func (r *stageUpgradeRepository) GetUpgradeByStageIDAndSlugDB(ctx context.Contex
 
 	err = r.db.QueryRowContext(ctx, getUpgradeByStageIDAndSlugQuery, stageID, slug).Scan(
 		&stageUpgrade.ID,
+		&upgrade.ID,
 		&upgrade.Slug,
 		&upgrade.Name,
 		&upgrade.Cost,
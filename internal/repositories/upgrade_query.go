@@ -47,6 +47,14 @@ const (
 		LIMIT $1 OFFSET $2
 	`
 
+	getUpgradesBySlugsQuery = `
+		SELECT
+			id,
+			slug
+		FROM upgrades
+		WHERE slug = ANY($1)
+	`
+
 	countUpgradesQuery = `
 		SELECT 
 			COUNT(*) 
@@ -135,4 +143,55 @@ const (
 			updated_at = $12
 		WHERE id = $13
 	`
+
+	insertUpgradePrerequisiteQuery = `
+		INSERT INTO upgrade_prerequisites (
+			upgrade_id,
+			type,
+			required_upgrade_id,
+			food_item_id,
+			target,
+			created_at,
+			updated_at
+		) VALUES
+	`
+
+	selectUpgradePrerequisiteQuery = `
+		SELECT
+			p.id,
+			p.upgrade_id,
+			p.type,
+			p.required_upgrade_id,
+			COALESCE(ru.slug, '') AS required_upgrade_slug,
+			p.food_item_id,
+			COALESCE(fi.slug, '') AS food_item_slug,
+			p.target,
+			p.created_at,
+			p.updated_at
+		FROM upgrade_prerequisites p
+		LEFT JOIN upgrades ru ON ru.id = p.required_upgrade_id
+		LEFT JOIN food_items fi ON fi.id = p.food_item_id
+	`
+
+	getUpgradePrerequisitesByUpgradeIDsQuery = selectUpgradePrerequisiteQuery + `
+		WHERE p.upgrade_id = ANY($1)
+		ORDER BY p.upgrade_id ASC, p.id ASC
+	`
+
+	getUpgradePrerequisitesByTypeQuery = selectUpgradePrerequisiteQuery + `
+		WHERE p.type = $1
+		ORDER BY p.upgrade_id ASC, p.id ASC
+	`
+
+	getUpgradeStageIDsQuery = `
+		SELECT
+			upgrade_id,
+			game_stage_id
+		FROM game_stage_upgrades
+		WHERE upgrade_id = ANY($1)
+	`
+
+	deleteUpgradePrerequisitesQuery = `
+		DELETE FROM upgrade_prerequisites WHERE upgrade_id = $1
+	`
 )
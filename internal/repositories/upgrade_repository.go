@@ -23,6 +23,13 @@ type UpgradeRepository interface {
 	GetActiveUpgradesDB(ctx context.Context, stageID int64) (res []entities.Upgrade, err error)
 	CountUpgradesDB(ctx context.Context) (totalRows int64, err error)
 	GetUpgradesBySlugsDB(ctx context.Context, slugs []string) ([]entities.Upgrade, error)
+
+	UpgradeWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error)
+	BulkCreateUpgradePrerequisitesDB(ctx context.Context, data []entities.UpgradePrerequisite) (err error)
+	GetUpgradePrerequisitesDB(ctx context.Context, upgradeIDs []int64) (res []entities.UpgradePrerequisite, err error)
+	GetUpgradePrerequisitesByTypeDB(ctx context.Context, prerequisiteType entities.UpgradePrerequisiteType) (res []entities.UpgradePrerequisite, err error)
+	DeleteUpgradePrerequisitesDB(ctx context.Context, upgradeID int64) (err error)
+	GetUpgradeStageIDsDB(ctx context.Context, upgradeIDs []int64) (res map[int64][]int64, err error)
 }
 
 type upgradeRepository struct {
@@ -232,7 +239,7 @@ func (r *upgradeRepository) scanUpgrade(rows *sql.Row) (res *entities.Upgrade, e
 
 func (r *upgradeRepository) GetUpgradesBySlugsDB(ctx context.Context, slugs []string) ([]entities.Upgrade, error) {
 	var upgrades []entities.Upgrade
-	rows, err := r.db.QueryContext(ctx, "SELECT id, slug FROM upgrades WHERE slug = ANY($1)", pq.Array(slugs))
+	rows, err := r.db.QueryContext(ctx, getUpgradesBySlugsQuery, pq.Array(slugs))
 	if err != nil {
 		return nil, err
 	}
@@ -254,3 +261,137 @@ func (r *upgradeRepository) GetUpgradesBySlugsDB(ctx context.Context, slugs []st
 
 	return upgrades, nil
 }
+
+func (r *upgradeRepository) UpgradeWithTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *upgradeRepository) BulkCreateUpgradePrerequisitesDB(ctx context.Context, data []entities.UpgradePrerequisite) (err error) {
+	if len(data) == 0 {
+		return nil
+	}
+
+	numFields := 7
+	queryString := r.BuildBulkInsertQuery(insertUpgradePrerequisiteQuery, len(data), numFields, "")
+
+	args := make([]interface{}, 0, len(data)*numFields)
+	now := helper.NowUTC()
+
+	for _, item := range data {
+		args = append(args,
+			item.UpgradeID,
+			item.Type,
+			sql.NullInt64{Int64: item.RequiredUpgradeID, Valid: item.RequiredUpgradeID > 0},
+			sql.NullInt64{Int64: item.FoodItemID, Valid: item.FoodItemID > 0},
+			item.Target,
+			now,
+			now,
+		)
+	}
+
+	_, err = r.db.ExecContext(ctx, queryString, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetUpgradePrerequisitesDB gets the prerequisites of every given upgrade
+func (r *upgradeRepository) GetUpgradePrerequisitesDB(ctx context.Context, upgradeIDs []int64) (res []entities.UpgradePrerequisite, err error) {
+	if len(upgradeIDs) == 0 {
+		return nil, nil
+	}
+
+	return r.queryUpgradePrerequisites(ctx, getUpgradePrerequisitesByUpgradeIDsQuery, pq.Array(upgradeIDs))
+}
+
+func (r *upgradeRepository) GetUpgradePrerequisitesByTypeDB(ctx context.Context, prerequisiteType entities.UpgradePrerequisiteType) (res []entities.UpgradePrerequisite, err error) {
+	return r.queryUpgradePrerequisites(ctx, getUpgradePrerequisitesByTypeQuery, prerequisiteType)
+}
+
+func (r *upgradeRepository) DeleteUpgradePrerequisitesDB(ctx context.Context, upgradeID int64) (err error) {
+	_, err = r.db.ExecContext(ctx, deleteUpgradePrerequisitesQuery, upgradeID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetUpgradeStageIDsDB gets the stages selling each of the upgrades, keyed by upgrade id
+func (r *upgradeRepository) GetUpgradeStageIDsDB(ctx context.Context, upgradeIDs []int64) (res map[int64][]int64, err error) {
+	res = make(map[int64][]int64)
+	if len(upgradeIDs) == 0 {
+		return res, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, getUpgradeStageIDsQuery, pq.Array(upgradeIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var upgradeID, stageID int64
+		if err := rows.Scan(&upgradeID, &stageID); err != nil {
+			return nil, err
+		}
+
+		res[upgradeID] = append(res[upgradeID], stageID)
+	}
+
+	return res, rows.Err()
+}
+
+func (r *upgradeRepository) queryUpgradePrerequisites(ctx context.Context, query string, args ...interface{}) (res []entities.UpgradePrerequisite, err error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prerequisite entities.UpgradePrerequisite
+		var requiredUpgradeID sql.NullInt64
+		var foodItemID sql.NullInt64
+
+		err := rows.Scan(
+			&prerequisite.ID,
+			&prerequisite.UpgradeID,
+			&prerequisite.Type,
+			&requiredUpgradeID,
+			&prerequisite.RequiredUpgradeSlug,
+			&foodItemID,
+			&prerequisite.FoodItemSlug,
+			&prerequisite.Target,
+			&prerequisite.CreatedAt,
+			&prerequisite.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		prerequisite.RequiredUpgradeID = requiredUpgradeID.Int64
+		prerequisite.FoodItemID = foodItemID.Int64
+
+		res = append(res, prerequisite)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
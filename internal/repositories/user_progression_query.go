@@ -24,7 +24,7 @@ const (
 			last_started_at
 		FROM user_stage_progress
 		WHERE user_id = $1
-		ORDER BY last_started_at DESC
+		ORDER BY last_started_at DESC NULLS LAST
     	LIMIT 1;
 	`
 
@@ -60,7 +60,8 @@ const (
 			stage_id,
 			station_levels,
 			unlocked_stations,
-			station_upgrades
+			station_upgrades,
+			kitchen_upgrades
 		FROM user_kitchen_progress
 		WHERE user_id = $1 and stage_id =$2
 	`
@@ -143,6 +144,18 @@ const (
 		WHERE user_id = $2 AND stage_id = $3
 	`
 
+	claimStageStartingCoinQuery = `
+		UPDATE user_stage_progress
+		SET starting_coin_granted_at = $1
+		WHERE user_id = $2 AND stage_id = $3 AND starting_coin_granted_at IS NULL
+	`
+
+	claimStagePrizeQuery = `
+		UPDATE user_stage_progress
+		SET prize_granted_at = $1
+		WHERE user_id = $2 AND stage_id = $3 AND is_complete = true AND prize_granted_at IS NULL
+	`
+
 	insertUserUpgradeStageProgressionQuery = `
 		INSERT INTO user_stage_upgrades (
 		user_id,
@@ -163,6 +176,13 @@ const (
 		WHERE user_id = $3 AND stage_id = $4
 	`
 
+	updateKitchenUpgradeQuery = `
+		UPDATE user_kitchen_progress
+			SET kitchen_upgrades = $1,
+			    updated_at = $2
+		WHERE user_id = $3 AND stage_id = $4
+	`
+
 	stageUpgradeAlreadyPurchaseQuery = `
 		SELECT
 		    usu.id,
@@ -179,7 +199,7 @@ const (
 
 	currentStageUpgradeQuery = `
 		SELECT
-			u.slug, u.name,  u.description, u.cost, 
+			u.id, u.slug, u.name,  u.description, u.cost, 
 			u.cost_type, u.effect_type, u.effect_value, 
 			u.effect_unit, u.effect_target, u.effect_target_id,
 			COALESCE(fi.slug, '') AS effect_target_name,
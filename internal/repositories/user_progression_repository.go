@@ -24,7 +24,9 @@ type UserProgressionRepository interface {
 	WithUserProgressionTx(ctx context.Context, fn func(tx *sql.Tx) error) error
 
 	GetUserDailyRewardByIDDB(ctx context.Context, id int64) (res *entities.UserDailyReward, err error)
-	UpsertDailyRewardProgressionDB(ctx context.Context, userID int64, longestStreak int64, currentStreak int64, lastClaim time.Time) (err error)
+	UpsertDailyRewardProgressionDB(ctx context.Context, data entities.UserDailyReward) (err error)
+	AddStreakFreezesDB(ctx context.Context, userID int64, count int64) (streakFreezes int64, err error)
+	UseStreakFreezesDB(ctx context.Context, userID int64, count int64) (err error)
 
 	GetGameStageProgressionDB(ctx context.Context, userID int64, stageID int64) (res *entities.UserGameStageProgression, err error)
 	GetLatestGameStageProgressionDB(ctx context.Context, userID int64) (res *entities.UserGameStageProgression, err error)
@@ -32,6 +34,8 @@ type UserProgressionRepository interface {
 	CheckStageProgressionExistsDB(ctx context.Context, userID int64, stageID int64) (bool, error)
 	MarkStageAsCompleteDB(ctx context.Context, userID int64, stageID int64) error
 	MarkStageAsStartedDB(ctx context.Context, userID int64, stageID int64) error
+	ClaimStageStartingCoinDB(ctx context.Context, userID int64, stageID int64) (claimed bool, err error)
+	ClaimStagePrizeDB(ctx context.Context, userID int64, stageID int64) (claimed bool, err error)
 
 	CreateUserKitchenProgressionDB(ctx context.Context, data *entities.UserKitchenStageProgression) (err error)
 	UpdateUserKitchenProgressDB(ctx context.Context, userID int64, stageID int64, progress *entities.UserKitchenStageProgression) (err error)
@@ -46,6 +50,7 @@ type UserProgressionRepository interface {
 
 	CreateUpgradeStageProgression(ctx context.Context, data entities.UserStageUpgrade) (err error)
 	UpdateKitchenStationUpgradeDB(ctx context.Context, userID int64, stageID int64, data map[string]entities.UserStationUpgrade) (err error)
+	UpdateKitchenUpgradeDB(ctx context.Context, userID int64, stageID int64, data entities.UserStationUpgrade) (err error)
 	GetPurchasedStageUpgradesDB(ctx context.Context, userID int64, stageID int64) (res []entities.StageUpgrade, err error)
 	GetCurrentStageUpgradeDB(ctx context.Context, userID int64, stageID int64) (res []entities.UserStageUpgrade, err error)
 
@@ -102,9 +107,15 @@ func (r *userProgressionRepository) GetUserDailyRewardByIDDB(ctx context.Context
 	err = r.db.QueryRowContext(ctx, getUserDailyRewardProgressQuery, id).Scan(
 		&data.ID,
 		&data.UserID,
+		&data.CalendarID,
+		&data.CycleClaims,
 		&data.LongestStreak,
 		&data.CurrentStreak,
 		&data.LastClaimDate,
+		&data.LastClaimTimezone,
+		&data.StreakFreezes,
+		&data.StreakFreezesUsed,
+		&data.LastWelcomeBackAt,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -116,20 +127,53 @@ func (r *userProgressionRepository) GetUserDailyRewardByIDDB(ctx context.Context
 	return &data, err
 }
 
-func (r *userProgressionRepository) UpsertDailyRewardProgressionDB(ctx context.Context, userID int64, longestStreak int64, currentStreak int64, lastClaim time.Time) (err error) {
+func (r *userProgressionRepository) UpsertDailyRewardProgressionDB(ctx context.Context, data entities.UserDailyReward) (err error) {
 	now := time.Now()
 
 	_, err = r.db.ExecContext(ctx, upsertUserDailyRewardProgressQuery,
-		userID,
-		longestStreak,
-		currentStreak,
-		lastClaim,
+		data.UserID,
+		data.CalendarID,
+		data.CycleClaims,
+		data.LongestStreak,
+		data.CurrentStreak,
+		data.LastClaimDate,
+		data.LastClaimTimezone,
+		data.LastWelcomeBackAt,
 		now,
 	)
 
 	return err
 }
 
+// AddStreakFreezesDB gives the user count streak freezes and returns how many they hold now
+func (r *userProgressionRepository) AddStreakFreezesDB(ctx context.Context, userID int64, count int64) (streakFreezes int64, err error) {
+	err = r.db.QueryRowContext(ctx, addUserStreakFreezesQuery, userID, count, time.Now()).Scan(&streakFreezes)
+	if err != nil {
+		return 0, err
+	}
+
+	return streakFreezes, nil
+}
+
+// UseStreakFreezesDB takes count streak freezes from the user, it fails when the user holds fewer
+func (r *userProgressionRepository) UseStreakFreezesDB(ctx context.Context, userID int64, count int64) (err error) {
+	res, err := r.db.ExecContext(ctx, useUserStreakFreezesQuery, userID, count, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return apperror.ErrNoUpdateRecord
+	}
+
+	return nil
+}
+
 func (r *userProgressionRepository) GetUserDailyRewardRedis(ctx context.Context, userID int64) (res *entities.UserDailyReward, err error) {
 	key := r.userDailyRewardKey(userID)
 	val, err := r.redis.Get(ctx, key).Result()
@@ -275,6 +319,7 @@ func (r *userProgressionRepository) GetUserKitchenProgressDB(ctx context.Context
 	var stationLevelsJSON []byte
 	var unlockedStationsJSON []byte
 	var stationUpgradesJSON []byte
+	var kitchenUpgradesJSON []byte
 
 	err = r.db.QueryRowContext(ctx,
 		getUserKitchenProgressQuery,
@@ -287,6 +332,7 @@ func (r *userProgressionRepository) GetUserKitchenProgressDB(ctx context.Context
 		&stationLevelsJSON,
 		&unlockedStationsJSON,
 		&stationUpgradesJSON,
+		&kitchenUpgradesJSON,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
@@ -306,6 +352,10 @@ func (r *userProgressionRepository) GetUserKitchenProgressDB(ctx context.Context
 		return nil, err
 	}
 
+	if err := json.Unmarshal(kitchenUpgradesJSON, &progression.KitchenUpgrades); err != nil {
+		return nil, err
+	}
+
 	return &progression, nil
 }
 
@@ -539,6 +589,21 @@ func (r *userProgressionRepository) UpdateKitchenStationUpgradeDB(ctx context.Co
 	return nil
 }
 
+// UpdateKitchenUpgradeDB stores the upgrade effects that apply to the whole kitchen instead of a single station
+func (r *userProgressionRepository) UpdateKitchenUpgradeDB(ctx context.Context, userID int64, stageID int64, data entities.UserStationUpgrade) (err error) {
+	kitchenUpgradesJSON, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, updateKitchenUpgradeQuery, kitchenUpgradesJSON, helper.NowUTC(), userID, stageID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *userProgressionRepository) GetPurchasedStageUpgradesDB(ctx context.Context, userID int64, stageID int64) (res []entities.StageUpgrade, err error) {
 	rows, err := r.db.QueryContext(ctx, stageUpgradeAlreadyPurchaseQuery, userID, stageID)
 	if err != nil {
@@ -594,6 +659,7 @@ func (r *userProgressionRepository) GetCurrentStageUpgradeDB(ctx context.Context
 		var upgrade entities.Upgrade
 		var upgradeEffect entities.UpgradeEffect
 		err := rows.Scan(
+			&upgrade.ID,
 			&upgrade.Slug,
 			&upgrade.Name,
 			&upgrade.Description,
@@ -623,3 +689,27 @@ func (r *userProgressionRepository) GetCurrentStageUpgradeDB(ctx context.Context
 
 	return upgrades, nil
 }
+
+// ClaimStageStartingCoinDB marks the starting coins as granted, claimed is false when they were granted before
+func (r *userProgressionRepository) ClaimStageStartingCoinDB(ctx context.Context, userID int64, stageID int64) (bool, error) {
+	return r.claimStageGrant(ctx, claimStageStartingCoinQuery, userID, stageID)
+}
+
+// ClaimStagePrizeDB marks the prize of a completed stage as granted, claimed is false when it was granted before
+func (r *userProgressionRepository) ClaimStagePrizeDB(ctx context.Context, userID int64, stageID int64) (bool, error) {
+	return r.claimStageGrant(ctx, claimStagePrizeQuery, userID, stageID)
+}
+
+func (r *userProgressionRepository) claimStageGrant(ctx context.Context, query string, userID int64, stageID int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, query, helper.NowUTC(), userID, stageID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -7,40 +7,68 @@ const (
 		     external_id,
 		     username,
 		     email,
+		     display_name,
+		     avatar_url,
+		     timezone,
+		     last_login_at,
 		     created_at,
 		     updated_at
 		     ) 
 		VALUES (
-		        $1, $2, $3, $4, $5
+		        NULLIF($1, ''), $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, $9
 		) RETURNING id
 	`
 
 	// TODO: FIX THIS QUERY IMMEDIATELY
 	getUserByIDQuery = `
 		SELECT 
-		    id, external_id, username, email, gem, coin
+		    id, COALESCE(external_id, ''), username, email, COALESCE(display_name, ''), COALESCE(avatar_url, ''), COALESCE(is_active, true), gem, coin, timezone
 		FROM users WHERE id = $1
 	`
 
 	getUserByIDForUpdateQuery = `
 		SELECT 
-		    id, external_id, username, email, gem, coin
+		    id, COALESCE(external_id, ''), username, email, COALESCE(display_name, ''), COALESCE(avatar_url, ''), COALESCE(is_active, true), gem, coin, timezone
 		FROM users WHERE id = $1 FOR UPDATE
 	`
 
 	getUserByEmailQuery = `
 		SELECT 
-		    id, external_id, username, email, gem, coin
+		    id, COALESCE(external_id, ''), username, email, COALESCE(display_name, ''), COALESCE(avatar_url, ''), COALESCE(is_active, true), gem, coin, timezone
 		FROM users WHERE email = $1
 	`
 
+	getUserByExternalIDQuery = `
+		SELECT 
+		    id, COALESCE(external_id, ''), username, email, COALESCE(display_name, ''), COALESCE(avatar_url, ''), COALESCE(is_active, true), gem, coin, timezone
+		FROM users WHERE external_id = $1
+	`
+
+	updateUserProfileQuery = `
+		UPDATE users SET
+			external_id = NULLIF($1, ''),
+			email = $2,
+			display_name = NULLIF($3, ''),
+			avatar_url = NULLIF($4, ''),
+			timezone = $5,
+			last_login_at = $6,
+			updated_at = $7
+		WHERE id = $8
+	`
+
 	getUserDailyRewardProgressQuery = `
 		SELECT 
 		    id,
 		    user_id, 
+		    calendar_id,
+		    cycle_claims,
 		    longest_streak,
 		    current_streak,
-		    last_claim_date 
+		    last_claim_date,
+		    COALESCE(last_claim_timezone, ''),
+		    streak_freezes,
+		    streak_freezes_used,
+		    last_welcome_back_at
 		FROM user_daily_reward_progress
 		WHERE user_id = $1
 	`
@@ -51,19 +79,104 @@ const (
 		INSERT INTO user_daily_reward_progress 
 		    (
 		     user_id,
+		     calendar_id,
+		     cycle_claims,
 		     longest_streak,
 		     current_streak,
 		     last_claim_date,
+		     last_claim_timezone,
+		     last_welcome_back_at,
 		     updated_at
 		    ) 
-		VALUES ($1, $2, $3, $4, $5)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9)
 		ON CONFLICT  (user_id)
 		DO UPDATE SET 
+		    calendar_id = EXCLUDED.calendar_id,
+		    cycle_claims = EXCLUDED.cycle_claims,
 		    longest_streak = EXCLUDED.longest_streak,
 		    current_streak = EXCLUDED.current_streak,
 		    last_claim_date = EXCLUDED.last_claim_date,
+		    last_claim_timezone = EXCLUDED.last_claim_timezone,
+		    last_welcome_back_at = COALESCE(EXCLUDED.last_welcome_back_at, user_daily_reward_progress.last_welcome_back_at),
 			updated_at=EXCLUDED.updated_at
 	`
 
+	addUserStreakFreezesQuery = `
+		INSERT INTO user_daily_reward_progress (user_id, streak_freezes, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+		    streak_freezes = user_daily_reward_progress.streak_freezes + EXCLUDED.streak_freezes,
+		    updated_at = EXCLUDED.updated_at
+		RETURNING streak_freezes
+	`
+
+	useUserStreakFreezesQuery = `
+		UPDATE user_daily_reward_progress
+		SET streak_freezes = streak_freezes - $2,
+		    streak_freezes_used = streak_freezes_used + $2,
+		    updated_at = $3
+		WHERE user_id = $1 AND streak_freezes >= $2
+	`
+
 	updateLastSyncBalanceQuery = `UPDATE users SET last_sync_balance_at = $1, updated_at = $2 WHERE id = $3`
+
+	updateUserCoinBalanceQuery = `UPDATE users SET coin = coin + $1 WHERE id = $2 RETURNING coin`
+
+	updateUserGemBalanceQuery = `UPDATE users SET gem = gem + $1 WHERE id = $2 RETURNING gem`
+
+	getLastSyncBalanceForUpdateQuery = `SELECT last_sync_balance_at FROM users WHERE id = $1 FOR UPDATE`
+
+	insertBalanceSyncDiscrepancyQuery = `
+		INSERT INTO balance_sync_discrepancies
+		    (
+		     user_id,
+		     stage_id,
+		     coins_claimed,
+		     coins_allowed,
+		     coins_per_second,
+		     elapsed_seconds,
+		     last_sync_at,
+		     created_at
+		    )
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	insertOfflineEarningQuery = `
+		INSERT INTO user_offline_earnings
+		    (
+		     user_id,
+		     stage_id,
+		     coins,
+		     coins_per_second,
+		     elapsed_seconds,
+		     is_capped,
+		     created_at
+		    )
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	getOfflineEarningByIDForUpdateQuery = `
+		SELECT
+			id,
+			user_id,
+			stage_id,
+			coins,
+			coins_per_second,
+			elapsed_seconds,
+			is_capped,
+			bonus_coins,
+			bonus_granted_at,
+			created_at
+		FROM user_offline_earnings
+		WHERE id = $1 AND user_id = $2
+		FOR UPDATE
+	`
+
+	updateOfflineEarningBonusQuery = `
+		UPDATE user_offline_earnings
+		SET bonus_coins = $1, bonus_granted_at = $2
+		WHERE id = $3 AND bonus_granted_at IS NULL
+	`
 )
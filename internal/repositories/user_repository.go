@@ -6,17 +6,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/database"
 	"github.com/winartodev/cat-cafe/pkg/helper"
 )
 
 const (
 	userTokenBlacklistRedisKey = "user:token:blacklist:%s"
 	userIdRedisKey             = "user:id:%d"
+
+	// usersEmailKey is the unique constraint postgres named for users.email
+	usersEmailKey = "users_email_key"
 )
 
 type UserRepository interface {
@@ -26,11 +31,18 @@ type UserRepository interface {
 	GetUserByIDDB(ctx context.Context, id int64) (res *entities.User, err error)
 	GetUserByIDForUpdateDB(ctx context.Context, id int64) (res *entities.User, err error)
 	GetUserByEmailDB(ctx context.Context, email string) (res *entities.User, err error)
+	GetUserByExternalIDDB(ctx context.Context, externalID string) (res *entities.User, err error)
+	UpdateUserProfileDB(ctx context.Context, data *entities.User) (err error)
 	GetUserBalanceByIDDB(ctx context.Context, id int64) (res *entities.UserBalance, err error)
 
 	BalanceWithTx(ctx context.Context, fn func(txRepo *sql.Tx) error) error
-	UpdateUserBalanceWithTx(ctx context.Context, userID int64, rewardType entities.UserBalanceType, amount int64) (err error)
+	UpdateUserBalanceWithTx(ctx context.Context, userID int64, balanceType entities.UserBalanceType, amount int64, source entities.CurrencyLedgerSource) (err error)
 	UpdateLastSyncBalanceWithTx(ctx context.Context, userID int64, lastSyncTime time.Time) (err error)
+	GetLastSyncBalanceForUpdateDB(ctx context.Context, userID int64) (res *time.Time, err error)
+	CreateBalanceSyncDiscrepancyDB(ctx context.Context, data *entities.BalanceSyncDiscrepancy) (err error)
+	CreateOfflineEarningDB(ctx context.Context, data *entities.OfflineEarning) (id int64, err error)
+	GetOfflineEarningByIDForUpdateDB(ctx context.Context, userID int64, id int64) (res *entities.OfflineEarning, err error)
+	UpdateOfflineEarningBonusDB(ctx context.Context, id int64, bonusCoins int64, grantedAt time.Time) (err error)
 
 	SetUserRedis(ctx context.Context, userID int64, data *entities.UserCache, exp time.Duration) (err error)
 	GetUserRedis(ctx context.Context, userID int64) (res *entities.UserCache, err error)
@@ -77,11 +89,15 @@ func (r *userRepository) CreateUserDB(ctx context.Context, data *entities.User)
 		data.ExternalID,
 		data.Username,
 		data.Email,
+		data.DisplayName,
+		data.AvatarURL,
+		data.Timezone,
+		data.LastLoginAt,
 		now,
 		now,
 	).Scan(&id)
 	if err != nil {
-		return nil, err
+		return nil, r.userUniqueViolationError(err)
 	}
 
 	return id, nil
@@ -102,6 +118,44 @@ func (r *userRepository) GetUserByEmailDB(ctx context.Context, email string) (re
 	return r.scanUserRow(row)
 }
 
+func (r *userRepository) GetUserByExternalIDDB(ctx context.Context, externalID string) (res *entities.User, err error) {
+	row := r.db.QueryRowContext(ctx, getUserByExternalIDQuery, externalID)
+	return r.scanUserRow(row)
+}
+
+func (r *userRepository) UpdateUserProfileDB(ctx context.Context, data *entities.User) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		updateUserProfileQuery,
+		data.ExternalID,
+		data.Email,
+		data.DisplayName,
+		data.AvatarURL,
+		data.Timezone,
+		data.LastLoginAt,
+		helper.NowUTC(),
+		data.ID,
+	)
+	if err != nil {
+		return r.userUniqueViolationError(err)
+	}
+
+	return nil
+}
+
+// userUniqueViolationError tells an email held by another account apart from the other unique columns
+func (r *userRepository) userUniqueViolationError(err error) error {
+	if !database.IsDuplicateError(err) {
+		return err
+	}
+
+	if strings.Contains(err.Error(), usersEmailKey) {
+		return apperror.ErrEmailAlreadyLinked
+	}
+
+	return apperror.ErrAlreadyExists.WithError(err)
+}
+
 func (r *userRepository) GetUserBalanceByIDDB(ctx context.Context, id int64) (res *entities.UserBalance, err error) {
 	var userBalance entities.UserBalance
 
@@ -134,18 +188,38 @@ func (r *userRepository) BalanceWithTx(ctx context.Context, fn func(txRepo *sql.
 	return tx.Commit()
 }
 
-func (r *userRepository) UpdateUserBalanceWithTx(ctx context.Context, userID int64, balanceType entities.UserBalanceType, amount int64) error {
+// UpdateUserBalanceWithTx changes the balance and appends the movement to the currency ledger in the same transaction
+func (r *userRepository) UpdateUserBalanceWithTx(ctx context.Context, userID int64, balanceType entities.UserBalanceType, amount int64, source entities.CurrencyLedgerSource) error {
+	if _, ok := r.db.(*sql.Tx); !ok {
+		return apperror.ErrRequiredActiveTx
+	}
+
 	var query string
 	switch balanceType {
 	case entities.BalanceTypeCoin:
-		query = `UPDATE users SET coin = coin + $1 WHERE id = $2`
+		query = updateUserCoinBalanceQuery
 	case entities.BalanceTypeGem:
-		query = `UPDATE users SET gem = gem + $1 WHERE id = $2`
+		query = updateUserGemBalanceQuery
 	default:
 		return apperror.ErrUnknownRewardType
 	}
 
-	_, err := r.db.ExecContext(ctx, query, amount, userID)
+	var balanceAfter int64
+	err := r.db.QueryRowContext(ctx, query, amount, userID).Scan(&balanceAfter)
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperror.ErrUserNotFound
+	} else if err != nil {
+		return err
+	}
+
+	_, err = insertCurrencyLedgerEntry(ctx, r.db, &entities.CurrencyLedgerEntry{
+		UserID:       userID,
+		Currency:     balanceType,
+		SourceType:   source.Type,
+		SourceRef:    source.Ref,
+		Delta:        amount,
+		BalanceAfter: balanceAfter,
+	})
 
 	return err
 }
@@ -161,6 +235,113 @@ func (r *userRepository) UpdateLastSyncBalanceWithTx(ctx context.Context, userID
 
 }
 
+func (r *userRepository) GetLastSyncBalanceForUpdateDB(ctx context.Context, userID int64) (*time.Time, error) {
+	var lastSync sql.NullTime
+	err := r.db.QueryRowContext(ctx, getLastSyncBalanceForUpdateQuery, userID).Scan(&lastSync)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperror.ErrUserNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !lastSync.Valid {
+		return nil, nil
+	}
+
+	return &lastSync.Time, nil
+}
+
+func (r *userRepository) CreateBalanceSyncDiscrepancyDB(ctx context.Context, data *entities.BalanceSyncDiscrepancy) error {
+	now := helper.NowUTC()
+	_, err := r.db.ExecContext(
+		ctx,
+		insertBalanceSyncDiscrepancyQuery,
+		data.UserID,
+		data.StageID,
+		data.CoinsClaimed,
+		data.CoinsAllowed,
+		data.CoinsPerSecond,
+		data.ElapsedSeconds,
+		data.LastSyncAt,
+		now,
+	)
+
+	return err
+}
+
+func (r *userRepository) CreateOfflineEarningDB(ctx context.Context, data *entities.OfflineEarning) (id int64, err error) {
+	now := helper.NowUTC()
+	err = r.db.QueryRowContext(
+		ctx,
+		insertOfflineEarningQuery,
+		data.UserID,
+		data.StageID,
+		data.Coins,
+		data.CoinsPerSecond,
+		data.ElapsedSeconds,
+		data.IsCapped,
+		now,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetOfflineEarningByIDForUpdateDB locks the row, it must run inside a transaction
+func (r *userRepository) GetOfflineEarningByIDForUpdateDB(ctx context.Context, userID int64, id int64) (*entities.OfflineEarning, error) {
+	var earning entities.OfflineEarning
+	var stageID sql.NullInt64
+	var bonusGrantedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, getOfflineEarningByIDForUpdateQuery, id, userID).Scan(
+		&earning.ID,
+		&earning.UserID,
+		&stageID,
+		&earning.Coins,
+		&earning.CoinsPerSecond,
+		&earning.ElapsedSeconds,
+		&earning.IsCapped,
+		&earning.BonusCoins,
+		&bonusGrantedAt,
+		&earning.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if stageID.Valid {
+		earning.StageID = &stageID.Int64
+	}
+
+	if bonusGrantedAt.Valid {
+		earning.BonusGrantedAt = &bonusGrantedAt.Time
+	}
+
+	return &earning, nil
+}
+
+func (r *userRepository) UpdateOfflineEarningBonusDB(ctx context.Context, id int64, bonusCoins int64, grantedAt time.Time) error {
+	result, err := r.db.ExecContext(ctx, updateOfflineEarningBonusQuery, bonusCoins, grantedAt, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return apperror.ErrNoUpdateRecord
+	}
+
+	return nil
+}
+
 func (r *userRepository) scanUserRow(row *sql.Row) (*entities.User, error) {
 	var user entities.User
 	var userBalance entities.UserBalance
@@ -169,8 +350,12 @@ func (r *userRepository) scanUserRow(row *sql.Row) (*entities.User, error) {
 		&user.ExternalID,
 		&user.Username,
 		&user.Email,
+		&user.DisplayName,
+		&user.AvatarURL,
+		&user.IsActive,
 		&userBalance.Gem,
 		&userBalance.Coin,
+		&user.Timezone,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
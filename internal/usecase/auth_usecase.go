@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"github.com/winartodev/cat-cafe/internal/repositories"
 	"github.com/winartodev/cat-cafe/pkg/jwt"
@@ -10,63 +11,68 @@ import (
 	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
 	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/identity"
+	"github.com/winartodev/cat-cafe/pkg/jwk"
 )
 
 type AuthUseCase interface {
-	Login(ctx context.Context, authCode string) (authToken *string, user *entities.User, game *entities.Game, err error)
+	Login(ctx context.Context, authCode string, timezone string, device entities.SessionDevice) (tokens *entities.AuthTokens, user *entities.User, game *entities.Game, err error)
+	Refresh(ctx context.Context, refreshToken string, device entities.SessionDevice) (tokens *entities.AuthTokens, err error)
 	Logout(ctx context.Context, tokenString string, userID int64) error
 	GetUserByID(ctx context.Context, userID int64) (*entities.User, error)
+	GetJWKS(ctx context.Context) (res *jwk.Set, err error)
+
+	GetSessions(ctx context.Context) (res []entities.UserSession, err error)
+	RevokeSession(ctx context.Context, sessionID int64) (err error)
 }
 
 type authUseCase struct {
-	userUseCase UserUseCase
-	gameUseCase GameUseCase
-	userRepo    repositories.UserRepository
-	jwt_        *jwt.JWT
+	userUseCase       UserUseCase
+	gameUseCase       GameUseCase
+	moderationUseCase ModerationUseCase
+	userRepo          repositories.UserRepository
+	sessionRepo       repositories.SessionRepository
+	jwt_              *jwt.JWT
+	provider          identity.Provider
 }
 
 func NewAuthUseCase(
 	userUseCase UserUseCase,
 	gameUseCase GameUseCase,
+	moderationUseCase ModerationUseCase,
 	userRepo repositories.UserRepository,
+	sessionRepo repositories.SessionRepository,
 	jwt_ *jwt.JWT,
+	provider identity.Provider,
 ) AuthUseCase {
 	return &authUseCase{
-		userUseCase: userUseCase,
-		gameUseCase: gameUseCase,
-		userRepo:    userRepo,
-		jwt_:        jwt_,
+		userUseCase:       userUseCase,
+		gameUseCase:       gameUseCase,
+		moderationUseCase: moderationUseCase,
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		jwt_:              jwt_,
+		provider:          provider,
 	}
 }
 
-func (a *authUseCase) Login(ctx context.Context, authCode string) (authToken *string, user *entities.User, game *entities.Game, err error) {
-	// TODO: We want to exchange auth_code with Midtrans
-	emailFromProvider := authCode
-	user, err = a.userUseCase.GetUserByEmail(ctx, emailFromProvider)
-	if err != nil {
-		if errors.Is(err, apperror.ErrRecordNotFound) {
-			userData := entities.User{
-				ExternalID: helper.GenerateRandNumber(""),
-				Username:   helper.GenerateRandNumber("user@"),
-				Email:      authCode,
-				IsActive:   true,
-				UserBalance: &entities.UserBalance{
-					Gem:  0,
-					Coin: 0,
-				},
-			}
-
-			newUser, err := a.userUseCase.CreateUser(ctx, userData)
-			if err != nil {
-				return nil, nil, nil, err
-			}
-
-			user = newUser
-		} else {
-			return nil, nil, nil, err
-		}
-	} else {
-		// TODO: We update existing user data based on user data from midtrans
+func (a *authUseCase) Login(ctx context.Context, authCode string, timezone string, device entities.SessionDevice) (tokens *entities.AuthTokens, user *entities.User, game *entities.Game, err error) {
+	if a.provider == nil {
+		return nil, nil, nil, apperror.ErrIdentityProvider
+	}
+
+	profile, err := a.provider.Exchange(ctx, authCode)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	user, err = a.upsertUserFromProfile(ctx, profile, timezone)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := a.moderationUseCase.CheckUserAccess(ctx, user); err != nil {
+		return nil, nil, nil, err
 	}
 
 	ctx = context.WithValue(ctx, helper.ContextUserIDKey, user.ID)
@@ -75,14 +81,107 @@ func (a *authUseCase) Login(ctx context.Context, authCode string) (authToken *st
 		return nil, nil, nil, err
 	}
 
-	gameData.UserBalance = user.UserBalance
+	// The balance is only set when offline earnings changed it
+	if gameData.UserBalance == nil {
+		gameData.UserBalance = user.UserBalance
+	}
 
-	token, err := a.jwt_.GenerateToken(user.ID, user.Email)
+	tokens, err = a.createSession(ctx, user, device)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	return &token, user, gameData, nil
+	return tokens, user, gameData, nil
+}
+
+// Refresh rotates the refresh token of a session, presenting an already rotated token
+// is treated as a leak and revokes the whole session
+func (a *authUseCase) Refresh(ctx context.Context, refreshToken string, device entities.SessionDevice) (*entities.AuthTokens, error) {
+	if refreshToken == "" {
+		return nil, apperror.ErrInvalidRefreshToken
+	}
+
+	device = device.Normalize()
+	now := helper.NowUTC()
+	expiresAt := now.Add(a.jwt_.GetRefreshTokenDuration())
+
+	var session *entities.UserSession
+	var newRefreshToken string
+	var reused bool
+
+	err := a.sessionRepo.SessionWithTx(ctx, func(tx *sql.Tx) error {
+		sessionRepoTx := a.sessionRepo.WithTx(tx)
+
+		stored, err := sessionRepoTx.GetRefreshTokenByHashForUpdateDB(ctx, jwt.HashRefreshToken(refreshToken))
+		if err != nil {
+			return err
+		}
+
+		if stored == nil {
+			return apperror.ErrInvalidRefreshToken
+		}
+
+		session, err = sessionRepoTx.GetSessionByIDDB(ctx, stored.SessionID)
+		if err != nil {
+			return err
+		}
+
+		if !session.IsActive(now) {
+			return apperror.ErrInvalidRefreshToken
+		}
+
+		if stored.UsedAt != nil {
+			// Commit the revocation instead of rolling it back with the error
+			reused = true
+			_, err = sessionRepoTx.RevokeSessionDB(ctx, session.ID, entities.SessionRevokedByTokenReuse)
+			return err
+		}
+
+		if now.After(stored.ExpiresAt) {
+			return apperror.ErrInvalidRefreshToken
+		}
+
+		if err := sessionRepoTx.MarkRefreshTokenUsedDB(ctx, stored.ID, now); err != nil {
+			return err
+		}
+
+		newRefreshToken, err = a.issueRefreshToken(ctx, sessionRepoTx, session.ID, expiresAt)
+		if err != nil {
+			return err
+		}
+
+		return sessionRepoTx.TouchSessionDB(ctx, session.ID, device, now, expiresAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reused {
+		_ = a.sessionRepo.SetSessionRevokedRedis(ctx, session.ID, a.jwt_.GetTokenDuration())
+		return nil, apperror.ErrRefreshTokenReused
+	}
+
+	user, err := a.userUseCase.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.moderationUseCase.CheckUserAccess(ctx, user); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := a.jwt_.GenerateToken(user.ID, user.Email, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.AuthTokens{
+		SessionID:             session.ID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  now.Add(a.jwt_.GetTokenDuration()),
+		RefreshToken:          newRefreshToken,
+		RefreshTokenExpiresAt: expiresAt,
+	}, nil
 }
 
 func (a *authUseCase) Logout(ctx context.Context, tokenString string, userID int64) error {
@@ -96,6 +195,12 @@ func (a *authUseCase) Logout(ctx context.Context, tokenString string, userID int
 		return errors.New("failed to logout")
 	}
 
+	if _, err := a.sessionRepo.RevokeSessionDB(ctx, claims.SessionID, entities.SessionRevokedByLogout); err != nil {
+		return err
+	}
+
+	_ = a.sessionRepo.SetSessionRevokedRedis(ctx, claims.SessionID, a.jwt_.GetTokenDuration())
+
 	_ = a.userRepo.DeleteUserRedis(ctx, claims.UserID)
 
 	return nil
@@ -104,3 +209,183 @@ func (a *authUseCase) Logout(ctx context.Context, tokenString string, userID int
 func (a *authUseCase) GetUserByID(ctx context.Context, userID int64) (*entities.User, error) {
 	return a.userUseCase.GetUserByID(ctx, userID)
 }
+
+func (a *authUseCase) GetJWKS(ctx context.Context) (*jwk.Set, error) {
+	return a.jwt_.JWKS()
+}
+
+func (a *authUseCase) GetSessions(ctx context.Context) ([]entities.UserSession, error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.sessionRepo.GetActiveSessionsByUserIDDB(ctx, userID, helper.NowUTC())
+}
+
+func (a *authUseCase) RevokeSession(ctx context.Context, sessionID int64) error {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	session, err := a.sessionRepo.GetSessionByIDDB(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session == nil || session.UserID != userID || !session.IsActive(helper.NowUTC()) {
+		return apperror.ErrSessionNotFound
+	}
+
+	if _, err := a.sessionRepo.RevokeSessionDB(ctx, sessionID, entities.SessionRevokedByUser); err != nil {
+		return err
+	}
+
+	return a.sessionRepo.SetSessionRevokedRedis(ctx, sessionID, a.jwt_.GetTokenDuration())
+}
+
+// createSession starts a device session with its first refresh token and issues the access token
+func (a *authUseCase) createSession(ctx context.Context, user *entities.User, device entities.SessionDevice) (*entities.AuthTokens, error) {
+	device = device.Normalize()
+	now := helper.NowUTC()
+	expiresAt := now.Add(a.jwt_.GetRefreshTokenDuration())
+
+	var sessionID int64
+	var refreshToken string
+
+	err := a.sessionRepo.SessionWithTx(ctx, func(tx *sql.Tx) error {
+		sessionRepoTx := a.sessionRepo.WithTx(tx)
+
+		id, err := sessionRepoTx.CreateSessionDB(ctx, &entities.UserSession{
+			UserID:     user.ID,
+			DeviceName: device.DeviceName,
+			UserAgent:  device.UserAgent,
+			IPAddress:  device.IPAddress,
+			LastUsedAt: now,
+			ExpiresAt:  expiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		if id == nil {
+			return apperror.ErrFailedRetrieveID
+		}
+
+		sessionID = *id
+		refreshToken, err = a.issueRefreshToken(ctx, sessionRepoTx, sessionID, expiresAt)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := a.jwt_.GenerateToken(user.ID, user.Email, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.AuthTokens{
+		SessionID:             sessionID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  now.Add(a.jwt_.GetTokenDuration()),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: expiresAt,
+	}, nil
+}
+
+func (a *authUseCase) issueRefreshToken(ctx context.Context, sessionRepo repositories.SessionRepository, sessionID int64, expiresAt time.Time) (string, error) {
+	token, hash, err := jwt.GenerateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = sessionRepo.CreateRefreshTokenDB(ctx, &entities.UserSessionRefreshToken{
+		SessionID: sessionID,
+		TokenHash: hash,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// upsertUserFromProfile finds the player by provider subject, falling back to a verified email
+// for accounts created before the provider was linked, and syncs the profile fields on every login.
+//
+// The timezone sent by the client is kept when it is valid, otherwise the stored one stays
+func (a *authUseCase) upsertUserFromProfile(ctx context.Context, profile *identity.Profile, timezone string) (*entities.User, error) {
+	if profile.Subject == "" || !helper.IsEmailValid(profile.Email) {
+		return nil, apperror.ErrInvalidIDToken
+	}
+
+	// A timezone the server doesn't know is ignored rather than failing the login
+	var loginTimezone string
+	if timezone != "" {
+		if _, name, err := helper.ParseTimezone(timezone); err == nil {
+			loginTimezone = name
+		}
+	}
+
+	user, err := a.userRepo.GetUserByExternalIDDB(ctx, profile.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only an account that was never linked to the provider can be claimed through a verified email,
+	// an unverified email or an account linked to another subject must not be taken over
+	if user == nil {
+		user, err = a.userRepo.GetUserByEmailDB(ctx, profile.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		if user != nil && (!profile.EmailVerified || user.ExternalID != "") {
+			return nil, apperror.ErrEmailAlreadyLinked
+		}
+	}
+
+	now := helper.NowUTC()
+
+	if user == nil {
+		if loginTimezone == "" {
+			loginTimezone = helper.DefaultTimezone
+		}
+
+		return a.userUseCase.CreateUser(ctx, entities.User{
+			ExternalID:  profile.Subject,
+			Username:    helper.GenerateRandNumber("user@"),
+			Email:       profile.Email,
+			DisplayName: profile.Name,
+			AvatarURL:   profile.Picture,
+			Timezone:    loginTimezone,
+			IsActive:    true,
+			LastLoginAt: &now,
+			UserBalance: &entities.UserBalance{
+				Gem:  0,
+				Coin: 0,
+			},
+		})
+	}
+
+	user.ExternalID = profile.Subject
+	user.Email = profile.Email
+	user.DisplayName = profile.Name
+	user.AvatarURL = profile.Picture
+	user.LastLoginAt = &now
+	if loginTimezone != "" {
+		user.Timezone = loginTimezone
+	}
+
+	if err := a.userRepo.UpdateUserProfileDB(ctx, user); err != nil {
+		return nil, err
+	}
+
+	_ = a.userRepo.DeleteUserRedis(ctx, user.ID)
+
+	return user, nil
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/identity"
+)
+
+// fakeAuthUserRepository keeps users in memory and rejects a taken email like the users.email constraint
+type fakeAuthUserRepository struct {
+	repositories.UserRepository
+
+	users []*entities.User
+}
+
+func (r *fakeAuthUserRepository) emailTaken(email string, userID int64) bool {
+	for _, user := range r.users {
+		if user.Email == email && user.ID != userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *fakeAuthUserRepository) GetUserByExternalIDDB(ctx context.Context, externalID string) (*entities.User, error) {
+	for _, user := range r.users {
+		if user.ExternalID != "" && user.ExternalID == externalID {
+			res := *user
+			return &res, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeAuthUserRepository) GetUserByEmailDB(ctx context.Context, email string) (*entities.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			res := *user
+			return &res, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeAuthUserRepository) CreateUserDB(ctx context.Context, data *entities.User) (*int64, error) {
+	if r.emailTaken(data.Email, 0) {
+		return nil, apperror.ErrEmailAlreadyLinked
+	}
+
+	id := int64(len(r.users) + 1)
+	user := *data
+	user.ID = id
+	r.users = append(r.users, &user)
+
+	return &id, nil
+}
+
+func (r *fakeAuthUserRepository) UpdateUserProfileDB(ctx context.Context, data *entities.User) error {
+	if r.emailTaken(data.Email, data.ID) {
+		return apperror.ErrEmailAlreadyLinked
+	}
+
+	for _, user := range r.users {
+		if user.ID == data.ID {
+			*user = *data
+			return nil
+		}
+	}
+
+	return apperror.ErrNoUpdateRecord
+}
+
+func (r *fakeAuthUserRepository) DeleteUserRedis(ctx context.Context, userID int64) error {
+	return nil
+}
+
+func TestUpsertUserFromProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile identity.Profile
+
+		wantErr    error
+		wantUserID int64
+	}{
+		{
+			name:       "returning user",
+			profile:    identity.Profile{Subject: "sub-linked", Email: "linked@mail.com", EmailVerified: true},
+			wantUserID: 1,
+		},
+		{
+			name:       "new user",
+			profile:    identity.Profile{Subject: "sub-new", Email: "new@mail.com"},
+			wantUserID: 4,
+		},
+		{
+			name:       "verified email claims a legacy account",
+			profile:    identity.Profile{Subject: "sub-new", Email: "legacy@mail.com", EmailVerified: true},
+			wantUserID: 2,
+		},
+		{
+			name:    "unverified email of a legacy account",
+			profile: identity.Profile{Subject: "sub-new", Email: "legacy@mail.com"},
+			wantErr: apperror.ErrEmailAlreadyLinked,
+		},
+		{
+			name:    "unverified email linked to another subject",
+			profile: identity.Profile{Subject: "sub-new", Email: "linked@mail.com"},
+			wantErr: apperror.ErrEmailAlreadyLinked,
+		},
+		{
+			name:    "verified email linked to another subject",
+			profile: identity.Profile{Subject: "sub-new", Email: "linked@mail.com", EmailVerified: true},
+			wantErr: apperror.ErrEmailAlreadyLinked,
+		},
+		{
+			name:    "returning user moved to an email held by another account",
+			profile: identity.Profile{Subject: "sub-other", Email: "linked@mail.com", EmailVerified: true},
+			wantErr: apperror.ErrEmailAlreadyLinked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &fakeAuthUserRepository{users: []*entities.User{
+				{ID: 1, ExternalID: "sub-linked", Email: "linked@mail.com"},
+				{ID: 2, Email: "legacy@mail.com"},
+				{ID: 3, ExternalID: "sub-other", Email: "other@mail.com"},
+			}}
+
+			a := &authUseCase{userRepo: userRepo, userUseCase: NewUserUseCase(userRepo, nil)}
+
+			user, err := a.upsertUserFromProfile(context.Background(), &tt.profile, "")
+			if tt.wantErr != nil {
+				var appErr *apperror.AppError
+				if !errors.Is(err, tt.wantErr) || !errors.As(err, &appErr) || appErr.StatusCode != http.StatusConflict {
+					t.Fatalf("upsertUserFromProfile() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("upsertUserFromProfile() error = %v", err)
+			}
+
+			if user.ID != tt.wantUserID || user.ExternalID != tt.profile.Subject || user.Email != tt.profile.Email {
+				t.Fatalf("upsertUserFromProfile() = %+v, want user %d linked to %s", user, tt.wantUserID, tt.profile.Subject)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type BoostUseCase interface {
+	CreateBoost(ctx context.Context, data entities.Boost) (res *entities.Boost, err error)
+	GetBoosts(ctx context.Context, limit, offset int) (res []entities.Boost, totalRows int64, err error)
+	GetBoostByID(ctx context.Context, id int64) (res *entities.Boost, err error)
+	UpdateBoost(ctx context.Context, id int64, data entities.Boost) (res *entities.Boost, err error)
+
+	GetActiveBoosts(ctx context.Context) (res []entities.UserBoost, err error)
+	PurchaseBoost(ctx context.Context, slug string) (res *entities.UserBoost, balance *entities.UserBalance, err error)
+
+	GrantRewardBoostWithTx(ctx context.Context, tx *sql.Tx, userID int64, reward *entities.Reward, source entities.CurrencyLedgerSource) (res *entities.UserBoost, err error)
+	GrantBoostWithTx(ctx context.Context, tx *sql.Tx, userID int64, boostID int64, count int64, source entities.CurrencyLedgerSource) (res *entities.UserBoost, err error)
+	GetUserBoostsBetween(ctx context.Context, userID int64, from time.Time, to time.Time) (res []entities.UserBoost, err error)
+}
+
+type boostUseCase struct {
+	userUseCase UserUseCase
+	boostRepo   repositories.BoostRepository
+	rewardRepo  repositories.RewardRepository
+	userRepo    repositories.UserRepository
+}
+
+func NewBoostUseCase(
+	userUseCase UserUseCase,
+	boostRepo repositories.BoostRepository,
+	rewardRepo repositories.RewardRepository,
+	userRepo repositories.UserRepository,
+) BoostUseCase {
+	return &boostUseCase{
+		userUseCase: userUseCase,
+		boostRepo:   boostRepo,
+		rewardRepo:  rewardRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (b *boostUseCase) CreateBoost(ctx context.Context, data entities.Boost) (res *entities.Boost, err error) {
+	if err := b.resolveRewardID(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	id, err := b.boostRepo.CreateBoostDB(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.boostRepo.GetBoostByIDDB(ctx, *id)
+}
+
+func (b *boostUseCase) GetBoosts(ctx context.Context, limit, offset int) (res []entities.Boost, totalRows int64, err error) {
+	res, err = b.boostRepo.GetBoostsDB(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalRows, err = b.boostRepo.CountBoostsDB(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, totalRows, nil
+}
+
+func (b *boostUseCase) GetBoostByID(ctx context.Context, id int64) (res *entities.Boost, err error) {
+	return b.boostRepo.GetBoostByIDDB(ctx, id)
+}
+
+func (b *boostUseCase) UpdateBoost(ctx context.Context, id int64, data entities.Boost) (res *entities.Boost, err error) {
+	_, err = b.boostRepo.GetBoostByIDDB(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.resolveRewardID(ctx, &data); err != nil {
+		return nil, err
+	}
+
+	err = b.boostRepo.UpdateBoostDB(ctx, id, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.boostRepo.GetBoostByIDDB(ctx, id)
+}
+
+// resolveRewardID links the boost to a BOOST reward, claiming that reward activates the boost
+func (b *boostUseCase) resolveRewardID(ctx context.Context, data *entities.Boost) error {
+	data.RewardID = nil
+	if data.RewardSlug == "" {
+		return nil
+	}
+
+	reward, err := b.rewardRepo.GetRewardBySlugDB(ctx, data.RewardSlug)
+	if err != nil {
+		return err
+	}
+
+	if reward == nil {
+		return apperror.ErrorNotFound("reward", "slug", data.RewardSlug)
+	}
+
+	if reward.RewardType == nil || reward.RewardType.Slug != entities.RewardTypeBoost.String() {
+		return apperror.ErrorInvalidRequest("reward", data.RewardSlug, "is not a", entities.RewardTypeBoost.String(), "reward")
+	}
+
+	data.RewardID = &reward.ID
+
+	return nil
+}
+
+// GetActiveBoosts gets the boosts of the player that haven't expired yet
+func (b *boostUseCase) GetActiveBoosts(ctx context.Context) (res []entities.UserBoost, err error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := helper.NowUTC()
+
+	return b.boostRepo.GetUserBoostsBetweenDB(ctx, userID, now, now.Add(time.Nanosecond))
+}
+
+// PurchaseBoost spends gems to activate a boost, following its stacking rule when it is still active
+func (b *boostUseCase) PurchaseBoost(ctx context.Context, slug string) (res *entities.UserBoost, balance *entities.UserBalance, err error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	boost, err := b.boostRepo.GetBoostBySlugDB(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if boost.GemCost <= 0 {
+		return nil, nil, apperror.ErrInvalidState.WithDetails("boost can only be granted as a reward")
+	}
+
+	err = b.userRepo.BalanceWithTx(ctx, func(tx *sql.Tx) error {
+		userRepoTx := b.userRepo.WithTx(tx)
+
+		// Lock the user row, purchases of the same player are applied one after another
+		user, err := userRepoTx.GetUserByIDForUpdateDB(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			return apperror.ErrUserNotFound
+		}
+
+		if user.UserBalance.Gem < boost.GemCost {
+			return apperror.ErrInsufficientGems
+		}
+
+		source := entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourceBoostPurchase,
+			Ref:  boost.Slug,
+		}
+
+		err = userRepoTx.UpdateUserBalanceWithTx(ctx, userID, entities.BalanceTypeGem, -boost.GemCost, source)
+		if err != nil {
+			return err
+		}
+
+		res, err = b.activateBoost(ctx, b.boostRepo.WithTx(tx), userID, boost, 1, source)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_ = b.userRepo.DeleteUserRedis(ctx, userID)
+
+	balance, err = b.userUseCase.GetUserBalance(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, balance, nil
+}
+
+// GrantRewardBoostWithTx activates the boost linked to a BOOST reward, the reward amount is how
+// many times the boost duration is granted
+func (b *boostUseCase) GrantRewardBoostWithTx(ctx context.Context, tx *sql.Tx, userID int64, reward *entities.Reward, source entities.CurrencyLedgerSource) (res *entities.UserBoost, err error) {
+	boostRepoTx := b.boostRepo.WithTx(tx)
+
+	boost, err := boostRepoTx.GetBoostByRewardIDDB(ctx, reward.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if boost == nil {
+		return nil, apperror.ErrInvalidState.WithDetails(fmt.Sprintf("reward %s is not linked to an active boost", reward.Slug))
+	}
+
+	return b.activateBoost(ctx, boostRepoTx, userID, boost, max(reward.Amount, 1), source)
+}
+
+// GrantBoostWithTx activates a boost count times its duration, used by the BOOST items of a bundle reward
+func (b *boostUseCase) GrantBoostWithTx(ctx context.Context, tx *sql.Tx, userID int64, boostID int64, count int64, source entities.CurrencyLedgerSource) (res *entities.UserBoost, err error) {
+	boostRepoTx := b.boostRepo.WithTx(tx)
+
+	boost, err := boostRepoTx.GetBoostByIDDB(ctx, boostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !boost.IsActive {
+		return nil, apperror.ErrInvalidState.WithDetails(fmt.Sprintf("boost %s is not active", boost.Slug))
+	}
+
+	return b.activateBoost(ctx, boostRepoTx, userID, boost, max(count, 1), source)
+}
+
+// GetUserBoostsBetween gets every boost of the player that was active at some point between from and to
+func (b *boostUseCase) GetUserBoostsBetween(ctx context.Context, userID int64, from time.Time, to time.Time) (res []entities.UserBoost, err error) {
+	return b.boostRepo.GetUserBoostsBetweenDB(ctx, userID, from, to)
+}
+
+// activateBoost applies the stacking rule of the boost against the ones of the same kind that are still active.
+// A stack boost at its max stacks extends the stack that ends first, so a granted reward is never lost
+func (b *boostUseCase) activateBoost(ctx context.Context, boostRepoTx repositories.BoostRepository, userID int64, boost *entities.Boost, count int64, source entities.CurrencyLedgerSource) (*entities.UserBoost, error) {
+	now := helper.NowUTC()
+	duration := boost.Duration * time.Duration(count)
+
+	active, err := boostRepoTx.GetActiveUserBoostsForUpdateDB(ctx, userID, boost.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *entities.UserBoost
+	switch boost.StackingRule {
+	case entities.BoostStackingExtend:
+		if len(active) > 0 {
+			current = &active[len(active)-1]
+			current.EndsAt = current.EndsAt.Add(duration)
+		}
+
+	case entities.BoostStackingRefresh:
+		if len(active) > 0 {
+			current = &active[len(active)-1]
+			if endsAt := now.Add(duration); endsAt.After(current.EndsAt) {
+				current.EndsAt = endsAt
+			}
+		}
+
+	case entities.BoostStackingStack:
+		if int64(len(active)) >= boost.MaxStacks {
+			current = &active[0]
+			current.EndsAt = current.EndsAt.Add(duration)
+		}
+	}
+
+	if current != nil {
+		err = boostRepoTx.UpdateUserBoostPeriodDB(ctx, current.ID, current.StartedAt, current.EndsAt)
+		if err != nil {
+			return nil, err
+		}
+
+		return current, nil
+	}
+
+	userBoost := entities.UserBoost{
+		UserID:    userID,
+		BoostID:   boost.ID,
+		Boost:     *boost,
+		Source:    source,
+		StartedAt: now,
+		EndsAt:    now.Add(duration),
+	}
+
+	userBoost.ID, err = boostRepoTx.CreateUserBoostDB(ctx, userBoost)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userBoost, nil
+}
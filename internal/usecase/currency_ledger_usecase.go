@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type CurrencyLedgerUseCase interface {
+	GetUserLedger(ctx context.Context, limit, offset int) (res []entities.CurrencyLedgerEntry, totalRow int64, err error)
+	GetLedgerByUserID(ctx context.Context, userID int64, limit, offset int) (res []entities.CurrencyLedgerEntry, totalRow int64, err error)
+
+	ReconcileBalances(ctx context.Context) (res *entities.CurrencyReconciliation, err error)
+	GetLedgerMismatches(ctx context.Context, limit, offset int) (res []entities.CurrencyLedgerMismatch, totalRow int64, err error)
+}
+
+type currencyLedgerUseCase struct {
+	currencyLedgerRepo repositories.CurrencyLedgerRepository
+	userRepo           repositories.UserRepository
+}
+
+func NewCurrencyLedgerUseCase(currencyLedgerRepo repositories.CurrencyLedgerRepository, userRepo repositories.UserRepository) CurrencyLedgerUseCase {
+	return &currencyLedgerUseCase{
+		currencyLedgerRepo: currencyLedgerRepo,
+		userRepo:           userRepo,
+	}
+}
+
+func (c *currencyLedgerUseCase) GetUserLedger(ctx context.Context, limit, offset int) (res []entities.CurrencyLedgerEntry, totalRow int64, err error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.getLedger(ctx, userID, limit, offset)
+}
+
+func (c *currencyLedgerUseCase) GetLedgerByUserID(ctx context.Context, userID int64, limit, offset int) (res []entities.CurrencyLedgerEntry, totalRow int64, err error) {
+	user, err := c.userRepo.GetUserByIDDB(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if user == nil {
+		return nil, 0, apperror.ErrUserNotFound
+	}
+
+	return c.getLedger(ctx, userID, limit, offset)
+}
+
+func (c *currencyLedgerUseCase) getLedger(ctx context.Context, userID int64, limit, offset int) (res []entities.CurrencyLedgerEntry, totalRow int64, err error) {
+	res, err = c.currencyLedgerRepo.GetLedgerEntriesByUserIDDB(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalRow, err = c.currencyLedgerRepo.CountLedgerEntriesByUserIDDB(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, totalRow, nil
+}
+
+// ReconcileBalances flags every user whose balance doesn't equal the sum of their ledger entries,
+// and resolves flags from previous runs that now match again
+func (c *currencyLedgerUseCase) ReconcileBalances(ctx context.Context) (res *entities.CurrencyReconciliation, err error) {
+	runAt := helper.NowUTC()
+
+	flagged, err := c.currencyLedgerRepo.FlagLedgerMismatchesDB(ctx, runAt)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := c.currencyLedgerRepo.ResolveLedgerMismatchesDB(ctx, runAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.CurrencyReconciliation{
+		Flagged:  flagged,
+		Resolved: resolved,
+		RunAt:    runAt,
+	}, nil
+}
+
+func (c *currencyLedgerUseCase) GetLedgerMismatches(ctx context.Context, limit, offset int) (res []entities.CurrencyLedgerMismatch, totalRow int64, err error) {
+	res, err = c.currencyLedgerRepo.GetUnresolvedLedgerMismatchesDB(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalRow, err = c.currencyLedgerRepo.CountUnresolvedLedgerMismatchesDB(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, totalRow, nil
+}
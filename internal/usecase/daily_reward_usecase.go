@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/internal/repositories"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
@@ -11,26 +12,31 @@ import (
 	"time"
 )
 
-const (
-	maxDailyRewardDays = 3
-)
-
 type DailyRewardUseCase interface {
-	CreateDailyReward(ctx context.Context, data entities.DailyReward, rewardTypeSlug string) (res *entities.DailyReward, err error)
-	GetDailyRewards(ctx context.Context, limit, offset int) (res []entities.DailyReward, totalRows int64, err error)
+	CreateDailyReward(ctx context.Context, data entities.DailyReward, rewardTypeSlug string, calendarSlug string) (res *entities.DailyReward, err error)
+	GetDailyRewards(ctx context.Context, calendarSlug string, limit, offset int) (res []entities.DailyReward, totalRows int64, err error)
 	GetDailyRewardID(ctx context.Context, id int64) (res *entities.DailyReward, err error)
-	UpdateDailyReward(ctx context.Context, id int64, data entities.DailyReward, rewardTypeSlug string) (res *entities.DailyReward, err error)
+	UpdateDailyReward(ctx context.Context, id int64, data entities.DailyReward, rewardTypeSlug string, calendarSlug string) (res *entities.DailyReward, err error)
 
-	GetDailyRewardStatus(ctx context.Context) (rewards []entities.DailyReward, dailyRewardIdx *int64, isNewDay *bool, err error)
-	ClaimDailyReward(ctx context.Context) (reward *entities.DailyReward, newBalance *entities.UserBalance, err error)
+	CreateDailyRewardCalendar(ctx context.Context, data entities.DailyRewardCalendar) (res *entities.DailyRewardCalendar, err error)
+	GetDailyRewardCalendars(ctx context.Context, limit, offset int) (res []entities.DailyRewardCalendar, totalRows int64, err error)
+	GetDailyRewardCalendarByID(ctx context.Context, id int64) (res *entities.DailyRewardCalendar, err error)
+	UpdateDailyRewardCalendar(ctx context.Context, id int64, data entities.DailyRewardCalendar) (res *entities.DailyRewardCalendar, err error)
+
+	GetDailyRewardStatus(ctx context.Context) (res *entities.DailyRewardStatus, err error)
+	IsDailyRewardAvailable(ctx context.Context, userID int64) (isAvailable bool, err error)
+	ClaimDailyReward(ctx context.Context) (res *entities.DailyRewardClaim, newBalance *entities.UserBalance, err error)
+	PurchaseStreakFreeze(ctx context.Context) (streakFreezes int64, newBalance *entities.UserBalance, err error)
 }
 
 type dailyRewardUseCase struct {
-	userUseCase     UserUseCase
-	rewardUseCase   RewardUseCase
-	dailyRewardRepo repositories.DailyRewardRepository
-	userProgression repositories.UserProgressionRepository
-	userRepo        repositories.UserRepository
+	userUseCase        UserUseCase
+	rewardUseCase      RewardUseCase
+	rewardGrantUseCase RewardGrantUseCase
+	dailyRewardRepo    repositories.DailyRewardRepository
+	userProgression    repositories.UserProgressionRepository
+	userRepo           repositories.UserRepository
+	settings           entities.DailyRewardSettings
 }
 
 func NewDailyRewardUseCase(
@@ -39,17 +45,21 @@ func NewDailyRewardUseCase(
 	userRepo repositories.UserRepository,
 	userUseCase UserUseCase,
 	rewardUseCase RewardUseCase,
+	rewardGrantUseCase RewardGrantUseCase,
+	settings entities.DailyRewardSettings,
 ) DailyRewardUseCase {
 	return &dailyRewardUseCase{
-		userUseCase:     userUseCase,
-		rewardUseCase:   rewardUseCase,
-		dailyRewardRepo: dailyRewardRepo,
-		userProgression: userProgression,
-		userRepo:        userRepo,
+		userUseCase:        userUseCase,
+		rewardUseCase:      rewardUseCase,
+		rewardGrantUseCase: rewardGrantUseCase,
+		dailyRewardRepo:    dailyRewardRepo,
+		userProgression:    userProgression,
+		userRepo:           userRepo,
+		settings:           settings,
 	}
 }
 
-func (d *dailyRewardUseCase) CreateDailyReward(ctx context.Context, data entities.DailyReward, rewardSlug string) (res *entities.DailyReward, err error) {
+func (d *dailyRewardUseCase) CreateDailyReward(ctx context.Context, data entities.DailyReward, rewardSlug string, calendarSlug string) (res *entities.DailyReward, err error) {
 	rewardType, err := d.rewardUseCase.GetRewardBySlug(ctx, rewardSlug)
 	if err != nil {
 		return nil, err
@@ -61,6 +71,18 @@ func (d *dailyRewardUseCase) CreateDailyReward(ctx context.Context, data entitie
 
 	data.Reward = rewardType
 
+	calendar, err := d.getDailyRewardCalendarOrActive(ctx, calendarSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.validateDailyRewardDay(calendar, data.DayNumber); err != nil {
+		return nil, err
+	}
+
+	data.CalendarID = calendar.ID
+	data.CalendarSlug = calendar.Slug
+
 	id, err := d.dailyRewardRepo.CreateDailyRewardDB(ctx, data)
 	if database.IsDuplicateError(err) {
 		return nil, apperror.ErrConflict
@@ -75,13 +97,23 @@ func (d *dailyRewardUseCase) CreateDailyReward(ctx context.Context, data entitie
 	return &data, err
 }
 
-func (d *dailyRewardUseCase) GetDailyRewards(ctx context.Context, limit, offset int) (res []entities.DailyReward, totalRows int64, err error) {
-	dailyRewards, err := d.dailyRewardRepo.GetDailyRewardsWithPaginationDB(ctx, limit, offset)
+func (d *dailyRewardUseCase) GetDailyRewards(ctx context.Context, calendarSlug string, limit, offset int) (res []entities.DailyReward, totalRows int64, err error) {
+	var calendarID int64
+	if calendarSlug != "" {
+		calendar, err := d.dailyRewardRepo.GetDailyRewardCalendarBySlugDB(ctx, calendarSlug)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		calendarID = calendar.ID
+	}
+
+	dailyRewards, err := d.dailyRewardRepo.GetDailyRewardsWithPaginationDB(ctx, calendarID, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	totalRows, err = d.dailyRewardRepo.CountDailyRewardsDB(ctx)
+	totalRows, err = d.dailyRewardRepo.CountDailyRewardsDB(ctx, calendarID)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -102,7 +134,12 @@ func (d *dailyRewardUseCase) GetDailyRewardID(ctx context.Context, id int64) (re
 	return res, err
 }
 
-func (d *dailyRewardUseCase) UpdateDailyReward(ctx context.Context, id int64, data entities.DailyReward, rewardSlug string) (res *entities.DailyReward, err error) {
+func (d *dailyRewardUseCase) UpdateDailyReward(ctx context.Context, id int64, data entities.DailyReward, rewardSlug string, calendarSlug string) (res *entities.DailyReward, err error) {
+	current, err := d.GetDailyRewardID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	reward, err := d.rewardUseCase.GetRewardBySlug(ctx, rewardSlug)
 	if err != nil {
 		return nil, err
@@ -114,11 +151,29 @@ func (d *dailyRewardUseCase) UpdateDailyReward(ctx context.Context, id int64, da
 
 	data.Reward = reward
 
-	err = d.dailyRewardRepo.UpdateDailyRewardDB(ctx, id, data)
+	// The day stays in its calendar unless another one is given
+	if calendarSlug == "" {
+		calendarSlug = current.CalendarSlug
+	}
+
+	calendar, err := d.dailyRewardRepo.GetDailyRewardCalendarBySlugDB(ctx, calendarSlug)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := d.validateDailyRewardDay(calendar, data.DayNumber); err != nil {
+		return nil, err
+	}
+
+	data.CalendarID = calendar.ID
+
+	err = d.dailyRewardRepo.UpdateDailyRewardDB(ctx, id, data)
+	if database.IsDuplicateError(err) {
+		return nil, apperror.ErrConflict
+	} else if err != nil {
+		return nil, err
+	}
+
 	res, err = d.GetDailyRewardID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -129,205 +184,490 @@ func (d *dailyRewardUseCase) UpdateDailyReward(ctx context.Context, id int64, da
 	return res, err
 }
 
-func (d *dailyRewardUseCase) getDailyRewardByDay(ctx context.Context, day int64) (res *entities.DailyReward, err error) {
-	allRewards, _, err := d.GetDailyRewards(ctx, maxDailyRewardDays, 0)
+// getDailyRewardCalendarOrActive returns the calendar with the slug, or the active calendar when no slug is given
+func (d *dailyRewardUseCase) getDailyRewardCalendarOrActive(ctx context.Context, slug string) (res *entities.DailyRewardCalendar, err error) {
+	if slug != "" {
+		return d.dailyRewardRepo.GetDailyRewardCalendarBySlugDB(ctx, slug)
+	}
+
+	res, err = d.dailyRewardRepo.GetActiveDailyRewardCalendarDB(ctx, helper.NowUTC())
 	if err != nil {
 		return nil, err
 	}
 
-	var reward *entities.DailyReward
-	for _, r := range allRewards {
-		if r.DayNumber == day {
-			reward = &r
-			break
+	if res == nil {
+		return nil, apperror.ErrorNotFound("active daily reward calendar")
+	}
+
+	return res, nil
+}
+
+func (d *dailyRewardUseCase) validateDailyRewardDay(calendar *entities.DailyRewardCalendar, dayNumber int64) error {
+	if dayNumber <= 0 {
+		return apperror.ErrorInvalidRequest("day number must be greater than 0")
+	}
+
+	if maxDays := calendar.Type.MaxDays(); maxDays > 0 && dayNumber > maxDays {
+		return apperror.ErrorInvalidRequest(fmt.Sprintf("a %s calendar has at most %d days", calendar.Type, maxDays))
+	}
+
+	return nil
+}
+
+func (d *dailyRewardUseCase) CreateDailyRewardCalendar(ctx context.Context, data entities.DailyRewardCalendar) (res *entities.DailyRewardCalendar, err error) {
+	id, err := d.dailyRewardRepo.CreateDailyRewardCalendarDB(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetDailyRewardCalendarByID(ctx, *id)
+}
+
+func (d *dailyRewardUseCase) GetDailyRewardCalendars(ctx context.Context, limit, offset int) (res []entities.DailyRewardCalendar, totalRows int64, err error) {
+	res, err = d.dailyRewardRepo.GetDailyRewardCalendarsDB(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalRows, err = d.dailyRewardRepo.CountDailyRewardCalendarsDB(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	active, err := d.dailyRewardRepo.GetActiveDailyRewardCalendarDB(ctx, helper.NowUTC())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range res {
+		res[i].IsActive = active != nil && res[i].ID == active.ID
+	}
+
+	return res, totalRows, nil
+}
+
+func (d *dailyRewardUseCase) GetDailyRewardCalendarByID(ctx context.Context, id int64) (res *entities.DailyRewardCalendar, err error) {
+	res, err = d.dailyRewardRepo.GetDailyRewardCalendarByIDDB(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := d.dailyRewardRepo.GetActiveDailyRewardCalendarDB(ctx, helper.NowUTC())
+	if err != nil {
+		return nil, err
+	}
+
+	res.IsActive = active != nil && res.ID == active.ID
+
+	return res, nil
+}
+
+// UpdateDailyRewardCalendar changes the name, type or start of a calendar, moving the start
+// reschedules the switchover. The slug is kept so the days stay linked to it
+func (d *dailyRewardUseCase) UpdateDailyRewardCalendar(ctx context.Context, id int64, data entities.DailyRewardCalendar) (res *entities.DailyRewardCalendar, err error) {
+	_, err = d.dailyRewardRepo.GetDailyRewardCalendarByIDDB(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxDays := data.Type.MaxDays(); maxDays > 0 {
+		lastDay, err := d.dailyRewardRepo.GetMaxDailyRewardDayNumberDB(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastDay > maxDays {
+			return nil, apperror.ErrorInvalidRequest(fmt.Sprintf("a %s calendar has at most %d days, this calendar has %d", data.Type, maxDays, lastDay))
 		}
 	}
 
-	if reward == nil {
-		return nil, apperror.ErrRecordNotFound
+	err = d.dailyRewardRepo.UpdateDailyRewardCalendarDB(ctx, id, data)
+	if err != nil {
+		return nil, err
 	}
 
-	return reward, nil
+	return d.GetDailyRewardCalendarByID(ctx, id)
 }
 
-func (d *dailyRewardUseCase) GetDailyRewardStatus(ctx context.Context) ([]entities.DailyReward, *int64, *bool, error) {
-	userID, err := helper.GetUserIDFromContext(ctx)
-	if err != nil || userID <= 0 {
-		return nil, nil, nil, apperror.ErrUnauthorized
+// getDailyRewardStatus loads the progress of the user through the calendar that is active now,
+// returns nil when no calendar has started yet
+func (d *dailyRewardUseCase) getDailyRewardStatus(ctx context.Context, userID int64) (res *entities.DailyRewardStatus, err error) {
+	user, err := d.userUseCase.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
 	progression, err := d.userUseCase.GetUserDailyRewardByID(ctx, userID)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
-	rewards, _, err := d.GetDailyRewards(ctx, maxDailyRewardDays, 0)
+	now := helper.NowUTC()
+	calendar, err := d.dailyRewardRepo.GetActiveDailyRewardCalendarDB(ctx, now)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
-	now := helper.NowUTC()
-	today := now.Format("2006-01-02")
+	if calendar == nil {
+		return nil, nil
+	}
+
+	rewards, err := d.dailyRewardRepo.GetDailyRewardsByCalendarIDDB(ctx, calendar.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Show what the bundle days contain
+	for i := range rewards {
+		if err := d.rewardUseCase.LoadBundleItems(ctx, rewards[i].Reward); err != nil {
+			return nil, err
+		}
+	}
 
-	// Default to true for new users. If LastClaimDate exists, check if today is a later date.
-	isNewDay := true
-	var dailyRewardIdx int64
+	res, _ = d.resolveDailyRewardStatus(calendar, rewards, progression, helper.LoadTimezone(user.Timezone), now)
+	res.StreakFreezeGemCost = d.settings.StreakFreezeGemCost
+	res.MaxStreakFreezes = d.settings.MaxStreakFreezes
 
+	if res.Streak.IsWelcomeBack {
+		res.WelcomeBackRewards, err = d.getWelcomeBackRewards(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// getWelcomeBackRewards loads the rewards of the welcome back bundle in the order they are configured
+func (d *dailyRewardUseCase) getWelcomeBackRewards(ctx context.Context) (res []entities.Reward, err error) {
+	res = make([]entities.Reward, 0, len(d.settings.WelcomeBackRewards))
+	for _, slug := range d.settings.WelcomeBackRewards {
+		reward, err := d.rewardUseCase.GetRewardBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *reward)
+	}
+
+	return res, nil
+}
+
+// resolveDailyRewardStreak works out the streak the next claim continues from. Missed days are
+// covered by streak freezes, but only when the user holds enough to cover all of them, otherwise
+// the streak is lost and the freezes are kept for the next time
+func (d *dailyRewardUseCase) resolveDailyRewardStreak(progression *entities.UserDailyReward, loc *time.Location, now time.Time) (res entities.DailyRewardStreak) {
 	if progression == nil {
-		// initialize an empty progress object.
-		progression = &entities.UserDailyReward{LongestStreak: 0, CurrentStreak: 0, LastClaimDate: nil}
-	} else if progression.LastClaimDate != nil {
-		lastClaimDay := progression.LastClaimDate.Format("2006-01-02")
-		isNewDay = today > lastClaimDay
-	}
-
-	// Calculate the current day index in the 7-day cycle (0-6)
-	// LongestStreak tracks total days claimed (can exceed 7)
-	// dailyRewardIdx maps this to a position in the 7-day cycle using modulo
-	if isNewDay {
-		// New day: user hasn't claimed yet today
-		// Point to the next available day to claim
-		// Example: LongestStreak=1 (claimed Day 1) → dailyRewardIdx=1 (Day 2 available)
-		dailyRewardIdx = progression.LongestStreak % maxDailyRewardDays
-	} else {
-		// Same day: user already claimed earlier today
-		// Point to the day they just claimed
-		// Example: LongestStreak=1 (just claimed Day 1) → dailyRewardIdx=0 (Day 1 shown as claimed)
-		dailyRewardIdx = (progression.LongestStreak - 1) % maxDailyRewardDays
-		if progression.LongestStreak == 0 {
-			dailyRewardIdx = 0
+		return res
+	}
+
+	res.CurrentStreak = progression.CurrentStreak
+	res.LongestStreak = progression.LongestStreak
+	res.StreakFreezes = progression.StreakFreezes
+
+	if progression.LastClaimDate == nil {
+		return res
+	}
+
+	// Counted in the timezone of the last claim as well, moving timezones can't make more days pass
+	daysAway := d.settings.DayClock.DaysBetween(*progression.LastClaimDate, now, loc)
+	daysAway = min(daysAway, d.settings.DayClock.DaysBetween(*progression.LastClaimDate, now, lastClaimLocation(progression, loc)))
+	res.DaysAway = max(daysAway, 0)
+	if missed := res.DaysAway - 1; missed > 0 {
+		if missed <= progression.StreakFreezes {
+			res.StreakFreezesToUse = missed
+		} else {
+			res.IsStreakLost = true
+			res.CurrentStreak = 0
 		}
 	}
 
-	// We do deep copy to prevent corruption while modifying data
-	rewardCopy := make([]entities.DailyReward, len(rewards))
-	copy(rewardCopy, rewards)
-
-	for i := range rewardCopy {
-		var status entities.RewardStatus
-
-		rewardIdx := rewardCopy[i].DayNumber - 1
-
-		if rewardIdx < dailyRewardIdx {
-			// Days before current position = already claimed in previous days
-			status = entities.StatusClaimed
-		} else if rewardIdx == dailyRewardIdx {
-			// Current day position = available to claim OR already claimed today
-			if isNewDay {
-				status = entities.StatusAvailable // Can claim today
-			} else {
-				status = entities.StatusClaimed // Already claimed today
+	res.IsWelcomeBack = d.settings.IsWelcomeBackEnabled() && res.DaysAway >= d.settings.WelcomeBackAfterDays
+
+	return res
+}
+
+// lastClaimLocation is the timezone the last claim was made in, loc for claims made before it was kept
+func lastClaimLocation(progression *entities.UserDailyReward, loc *time.Location) *time.Location {
+	if progression.LastClaimTimezone == "" {
+		return loc
+	}
+
+	return helper.LoadTimezone(progression.LastClaimTimezone)
+}
+
+// resolveDailyRewardStatus places the user on the calendar. The cycle is as long as the calendar has active
+// days, rewards must be ordered by day number. Days are game days of the day clock in loc, the timezone of
+// the user. It also returns how many days of the current cycle the user claimed, that is 0 when the user was
+// on another calendar or, for a monthly calendar, last claimed in an earlier month
+func (d *dailyRewardUseCase) resolveDailyRewardStatus(calendar *entities.DailyRewardCalendar, rewards []entities.DailyReward, progression *entities.UserDailyReward, loc *time.Location, now time.Time) (*entities.DailyRewardStatus, int64) {
+	today := d.settings.DayClock.Day(now, loc)
+
+	res := &entities.DailyRewardStatus{
+		Calendar:    calendar,
+		IsNewDay:    true,
+		NextResetAt: d.settings.DayClock.StartOf(today.AddDate(0, 0, 1), loc),
+		Streak:      d.resolveDailyRewardStreak(progression, loc, now),
+	}
+
+	var cycleClaims int64
+	var lastClaim *time.Time
+	if progression != nil {
+		if progression.LastClaimDate != nil {
+			lastClaimDay := d.settings.DayClock.Day(*progression.LastClaimDate, loc)
+			lastClaim = &lastClaimDay
+
+			// A new day has to start in the timezone of the last claim too, otherwise a player could claim
+			// late in a western timezone and claim again right away after switching to an eastern one
+			claimLoc := lastClaimLocation(progression, loc)
+			claimLocToday := d.settings.DayClock.Day(now, claimLoc)
+			isNewClaimLocDay := claimLocToday.After(d.settings.DayClock.Day(*progression.LastClaimDate, claimLoc))
+			res.IsNewDay = today.After(lastClaimDay) && isNewClaimLocDay
+
+			// The next claim opens when the day changes in whichever timezone is still on the claimed day
+			if !isNewClaimLocDay {
+				claimLocReset := d.settings.DayClock.StartOf(claimLocToday.AddDate(0, 0, 1), claimLoc)
+				if today.After(lastClaimDay) || claimLocReset.After(res.NextResetAt) {
+					res.NextResetAt = claimLocReset
+				}
 			}
-		} else {
-			// Days after current position = locked until user progresses
-			status = entities.StatusLocked
 		}
 
-		rewardCopy[i].Status = status
+		// Switching to another calendar starts the user from its first day
+		if progression.CalendarID != nil && *progression.CalendarID == calendar.ID {
+			cycleClaims = progression.CycleClaims
+		}
+	}
+
+	if calendar.Type == entities.DailyRewardCalendarMonthly {
+		monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		resetsAt := d.settings.DayClock.StartOf(monthStart.AddDate(0, 1, 0), loc)
+		res.ResetsAt = &resetsAt
+
+		if lastClaim == nil || lastClaim.Before(monthStart) {
+			cycleClaims = 0
+		}
+	}
+
+	cycleLength := int64(len(rewards))
+	if cycleLength == 0 {
+		res.Rewards = []entities.DailyReward{}
+		return res, cycleClaims
+	}
 
-		// Calculate cycle number for display (Day 1-7, 8-14, 15-21, etc.)
-		// Use LongestStreak-1 if already claimed today to prevent premature cycle increment
-		claimedDays := progression.LongestStreak
-		if !isNewDay {
-			claimedDays = progression.LongestStreak - 1
+	// claimed is how many days of the cycle on display are claimed. A finished loop only
+	// starts over on the next day, so the day claimed today is still shown as claimed
+	var claimed, cycleNumber int64
+	switch calendar.Type {
+	case entities.DailyRewardCalendarMonthly:
+		claimed = min(cycleClaims, cycleLength)
+	default:
+		if res.IsNewDay {
+			claimed = cycleClaims % cycleLength
+			cycleNumber = cycleClaims / cycleLength
+		} else if cycleClaims > 0 {
+			claimed = (cycleClaims-1)%cycleLength + 1
+			cycleNumber = (cycleClaims - 1) / cycleLength
 		}
+	}
+
+	res.CanClaim = res.IsNewDay && claimed < cycleLength
+	res.CurrentIdx = claimed
+	if !res.CanClaim {
+		// Point to the day that was claimed last
+		res.CurrentIdx = max(claimed-1, 0)
+	}
+
+	// The day numbers of a loop keep counting up, e.g. Day 8-14 on the second run of a 7-day loop
+	dayOffset := cycleNumber * rewards[cycleLength-1].DayNumber
 
-		// Determine which cycle (0 = Days 1-7, 1 = Days 8-14, etc.)
-		cycleNumber := claimedDays / maxDailyRewardDays
+	// We do deep copy to prevent corruption while modifying data
+	res.Rewards = make([]entities.DailyReward, len(rewards))
+	copy(res.Rewards, rewards)
+
+	for i := range res.Rewards {
+		idx := int64(i)
+		switch {
+		case idx < claimed:
+			res.Rewards[i].Status = entities.StatusClaimed
+		case idx == claimed && res.CanClaim:
+			res.Rewards[i].Status = entities.StatusAvailable
+		default:
+			res.Rewards[i].Status = entities.StatusLocked
+		}
 
-		// Add cycle offset to base day number
-		rewardCopy[i].DayNumber = rewardCopy[i].DayNumber + (cycleNumber * maxDailyRewardDays)
+		res.Rewards[i].DayNumber += dayOffset
 	}
 
-	return rewardCopy, &dailyRewardIdx, &isNewDay, err
+	return res, cycleClaims
 }
 
-func (d *dailyRewardUseCase) ClaimDailyReward(ctx context.Context) (dailyReward *entities.DailyReward, newBalance *entities.UserBalance, err error) {
+func (d *dailyRewardUseCase) GetDailyRewardStatus(ctx context.Context) (res *entities.DailyRewardStatus, err error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil || userID <= 0 {
+		return nil, apperror.ErrUnauthorized
+	}
+
+	res, err = d.getDailyRewardStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, apperror.ErrRecordNotFound
+	}
+
+	return res, nil
+}
+
+func (d *dailyRewardUseCase) IsDailyRewardAvailable(ctx context.Context, userID int64) (isAvailable bool, err error) {
+	status, err := d.getDailyRewardStatus(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return status != nil && status.CanClaim, nil
+}
+
+// ClaimDailyReward claims the day of the calendar that is up next. Missed days are covered by the
+// streak freezes of the user when possible, and players returning after a long break also get the
+// welcome back rewards
+func (d *dailyRewardUseCase) ClaimDailyReward(ctx context.Context) (res *entities.DailyRewardClaim, newBalance *entities.UserBalance, err error) {
 	userID, err := helper.GetUserIDFromContext(ctx)
 	if err != nil || userID <= 0 {
 		return nil, nil, apperror.ErrUnauthorized
 	}
 
+	now := helper.NowUTC()
+	calendar, err := d.dailyRewardRepo.GetActiveDailyRewardCalendarDB(ctx, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if calendar == nil {
+		return nil, nil, apperror.ErrRecordNotFound
+	}
+
+	rewards, err := d.dailyRewardRepo.GetDailyRewardsByCalendarIDDB(ctx, calendar.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(rewards) == 0 {
+		return nil, nil, apperror.ErrRecordNotFound
+	}
+
+	var welcomeBackRewards []entities.Reward
+	if d.settings.IsWelcomeBackEnabled() {
+		welcomeBackRewards, err = d.getWelcomeBackRewards(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Start transaction early to lock the user row
 	err = d.dailyRewardRepo.DailyRewardWithTx(ctx, func(tx *sql.Tx) error {
 		userRepoTx := d.userRepo.WithTx(tx)
 		userProgressionTx := d.userProgression.WithTx(tx)
 
 		// Lock user row to prevent concurrent claims
-		_, err = userRepoTx.GetUserByIDForUpdateDB(ctx, userID)
+		user, err := userRepoTx.GetUserByIDForUpdateDB(ctx, userID)
 		if err != nil {
 			return err
 		}
 
+		if user == nil {
+			return apperror.ErrRecordNotFound
+		}
+
+		loc := helper.LoadTimezone(user.Timezone)
+
 		// Re-fetch progression inside the transaction/lock
 		progression, err := userProgressionTx.GetUserDailyRewardByIDDB(ctx, userID)
 		if err != nil {
 			return err
 		}
 
-		now := helper.NowUTC()
-		today := now.Truncate(24 * time.Hour)
+		status, cycleClaims := d.resolveDailyRewardStatus(calendar, rewards, progression, loc, now)
+		if !status.IsNewDay {
+			return apperror.ErrAlreadyClaimed
+		}
 
-		// Initialize progression for new users who haven't claimed any rewards yet
-		if progression == nil {
-			progression = &entities.UserDailyReward{LongestStreak: 0, CurrentStreak: 0, LastClaimDate: nil}
-		} else if progression.LastClaimDate != nil {
-			// Check if user already claimed today
-			lastClaim := progression.LastClaimDate.UTC().Truncate(24 * time.Hour)
-			if today.Equal(lastClaim) {
-				return apperror.ErrAlreadyClaimed
-			}
+		if !status.CanClaim {
+			return apperror.ErrInvalidState.WithDetails("every day of the calendar was claimed, it starts over on the 1st")
+		}
 
-			diffDay := today.Sub(lastClaim).Hours() / 24
-			if diffDay > 1 {
-				progression.CurrentStreak = 0
+		streak := status.Streak
+		if streak.StreakFreezesToUse > 0 {
+			err = userProgressionTx.UseStreakFreezesDB(ctx, userID, streak.StreakFreezesToUse)
+			if err != nil {
+				return err
 			}
 		}
 
-		// Calculate which day to claim in the 7-day cycle
-		// LongestStreak tracks total claims, rewardIdx maps to cycle position (0-6)
-		rewardIdx := progression.LongestStreak % maxDailyRewardDays
-		dayToClaim := rewardIdx + 1
+		dailyReward := status.Rewards[status.CurrentIdx]
+
+		// Increment the streak counter after successful claim
+		newCurrentStreak := streak.CurrentStreak + 1
+		newLongestStreak := max(streak.LongestStreak, newCurrentStreak)
+
+		data := entities.UserDailyReward{
+			UserID:            userID,
+			CalendarID:        &calendar.ID,
+			CycleClaims:       cycleClaims + 1,
+			LongestStreak:     newLongestStreak,
+			CurrentStreak:     newCurrentStreak,
+			LastClaimDate:     &now,
+			LastClaimTimezone: user.Timezone,
+		}
+
+		if streak.IsWelcomeBack {
+			data.LastWelcomeBackAt = &now
+		}
 
-		dailyReward, err = d.getDailyRewardByDay(ctx, dayToClaim)
+		// Update user's streak, calendar progress and last claim date
+		err = userProgressionTx.UpsertDailyRewardProgressionDB(ctx, data)
 		if err != nil {
 			return err
 		}
 
-		rewardType := dailyReward.Reward.RewardType
-		rewardTypeEnum, err := entities.ToRewardType(rewardType.Slug)
+		err = d.rewardGrantUseCase.GrantRewardWithTx(ctx, tx, userID, dailyReward.Reward, entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourceDailyReward,
+			Ref:  fmt.Sprintf("calendar:%d:day:%d", calendar.ID, rewards[status.CurrentIdx].DayNumber),
+		})
 		if err != nil {
 			return err
 		}
 
-		// Increment the streak counter after successful claim
-		newLongestStreak := progression.LongestStreak + 1
-		newCurrentStreak := progression.CurrentStreak + 1
+		dailyReward.Status = entities.StatusClaimed
+		res = &entities.DailyRewardClaim{
+			Reward:            &dailyReward,
+			CurrentStreak:     newCurrentStreak,
+			LongestStreak:     newLongestStreak,
+			StreakFreezesUsed: streak.StreakFreezesToUse,
+		}
+
+		if streak.IsWelcomeBack {
+			for i := range welcomeBackRewards {
+				err = d.rewardGrantUseCase.GrantRewardWithTx(ctx, tx, userID, &welcomeBackRewards[i], entities.CurrencyLedgerSource{
+					Type: entities.CurrencySourceWelcomeBack,
+					Ref:  fmt.Sprintf("welcome_back:%s", now.Format(time.DateOnly)),
+				})
+				if err != nil {
+					return err
+				}
+			}
 
-		// Update longest streak if current streak exceeds it
-		if newCurrentStreak > newLongestStreak {
-			newLongestStreak = newCurrentStreak
+			res.WelcomeBackRewards = welcomeBackRewards
 		}
 
-		// Update user's streak and last claim date
-		err = userProgressionTx.UpsertDailyRewardProgressionDB(ctx, userID, newLongestStreak, newCurrentStreak, now)
+		// Read the freezes back, a welcome back reward may have granted some
+		progression, err = userProgressionTx.GetUserDailyRewardByIDDB(ctx, userID)
 		if err != nil {
 			return err
 		}
 
-		// Handle dailyReward based on type
-		if rewardTypeEnum.RequiresBalanceUpdate() {
-			// For COIN and GEM, update user balance in database
-			balanceType := rewardTypeEnum.ToUserBalance()
-			err = userRepoTx.UpdateUserBalanceWithTx(ctx, userID, balanceType, dailyReward.Reward.Amount)
-			if err != nil {
-				return err
-			}
-		} else if rewardTypeEnum.IsSentExternally() {
-			// TODO: Call External API to give GoPay Coin to player
-		} else {
-			return apperror.ErrUnknownRewardType
-		}
+		res.StreakFreezes = progression.StreakFreezes
 
 		return nil
 	})
@@ -338,16 +678,75 @@ func (d *dailyRewardUseCase) ClaimDailyReward(ctx context.Context) (dailyReward
 
 	// Clear the cache so that the next request retrieves the latest progress data from the database
 	//_ = d.userDailyRewardRepo.DeleteUserDailyRewardRedis(ctx, userID)
+	_ = d.userRepo.DeleteUserRedis(ctx, userID)
 
-	if dailyReward != nil {
-		rewardTypeEnum, _ := entities.ToRewardType(dailyReward.Reward.RewardType.Slug)
-		if rewardTypeEnum.RequiresBalanceUpdate() {
-			newBalance, err = d.userUseCase.GetUserBalance(ctx, userID)
-			if err != nil {
-				return nil, nil, err
-			}
+	newBalance, err = d.userUseCase.GetUserBalance(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, newBalance, nil
+}
+
+// PurchaseStreakFreeze buys one streak freeze with gems, up to the configured number of freezes held
+func (d *dailyRewardUseCase) PurchaseStreakFreeze(ctx context.Context) (streakFreezes int64, newBalance *entities.UserBalance, err error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if d.settings.StreakFreezeGemCost <= 0 {
+		return 0, nil, apperror.ErrInvalidState.WithDetails("streak freezes can only be granted as a reward")
+	}
+
+	err = d.userRepo.BalanceWithTx(ctx, func(tx *sql.Tx) error {
+		userRepoTx := d.userRepo.WithTx(tx)
+		userProgressionTx := d.userProgression.WithTx(tx)
+
+		// Lock the user row, purchases and claims of the same player are applied one after another
+		user, err := userRepoTx.GetUserByIDForUpdateDB(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			return apperror.ErrUserNotFound
+		}
+
+		if user.UserBalance.Gem < d.settings.StreakFreezeGemCost {
+			return apperror.ErrInsufficientGems
 		}
+
+		progression, err := userProgressionTx.GetUserDailyRewardByIDDB(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if progression != nil && progression.StreakFreezes >= d.settings.MaxStreakFreezes {
+			return apperror.ErrInvalidState.WithDetails(fmt.Sprintf("at most %d streak freezes can be held", d.settings.MaxStreakFreezes))
+		}
+
+		err = userRepoTx.UpdateUserBalanceWithTx(ctx, userID, entities.BalanceTypeGem, -d.settings.StreakFreezeGemCost, entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourceStreakFreezePurchase,
+			Ref:  entities.RewardTypeStreakFreeze.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		streakFreezes, err = userProgressionTx.AddStreakFreezesDB(ctx, userID, 1)
+		return err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	_ = d.userRepo.DeleteUserRedis(ctx, userID)
+
+	newBalance, err = d.userUseCase.GetUserBalance(ctx, userID)
+	if err != nil {
+		return 0, nil, err
 	}
 
-	return dailyReward, newBalance, err
+	return streakFreezes, newBalance, nil
 }
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+func TestResolveDailyRewardStatusTimezoneHop(t *testing.T) {
+	d := &dailyRewardUseCase{settings: entities.DailyRewardSettings{DayClock: helper.DayClock{}}}
+
+	calendar := &entities.DailyRewardCalendar{ID: 1, Type: entities.DailyRewardCalendarMonthly}
+	rewards := make([]entities.DailyReward, 28)
+	for i := range rewards {
+		rewards[i] = entities.DailyReward{CalendarID: calendar.ID, DayNumber: int64(i + 1)}
+	}
+
+	west, east := helper.LoadTimezone("-12:00"), helper.LoadTimezone("+14:00")
+
+	// 21:59 on the 9th at -12:00 and 23:59 on the 10th at +14:00
+	claimedAt := time.Date(2026, 3, 10, 9, 59, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		claimTimezone string
+		loc           *time.Location
+		now           time.Time
+
+		wantNewDay      bool
+		wantDaysAway    int64
+		wantNextResetAt time.Time
+	}{
+		{
+			name:            "hop east right after a claim in the west",
+			claimTimezone:   "-12:00",
+			loc:             east,
+			now:             claimedAt.Add(2 * time.Minute),
+			wantNewDay:      false,
+			wantNextResetAt: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "hop east once the day changed in the west",
+			claimTimezone:   "-12:00",
+			loc:             east,
+			now:             time.Date(2026, 3, 10, 12, 1, 0, 0, time.UTC),
+			wantNewDay:      true,
+			wantDaysAway:    1,
+			wantNextResetAt: time.Date(2026, 3, 11, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "hop west right after a claim in the east",
+			claimTimezone:   "+14:00",
+			loc:             west,
+			now:             claimedAt.Add(2 * time.Minute),
+			wantNewDay:      false,
+			wantNextResetAt: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "hop east again before the day changed in the west",
+			claimTimezone:   "-12:00",
+			loc:             east,
+			now:             time.Date(2026, 3, 10, 11, 59, 0, 0, time.UTC),
+			wantNewDay:      false,
+			wantNextResetAt: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "same timezone on the same day",
+			claimTimezone:   "-12:00",
+			loc:             west,
+			now:             claimedAt.Add(time.Hour),
+			wantNewDay:      false,
+			wantNextResetAt: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "claim made before the timezone was kept",
+			loc:             east,
+			now:             claimedAt.Add(2 * time.Minute),
+			wantNewDay:      true,
+			wantDaysAway:    1,
+			wantNextResetAt: time.Date(2026, 3, 11, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progression := &entities.UserDailyReward{
+				CalendarID:        &calendar.ID,
+				CycleClaims:       1,
+				CurrentStreak:     1,
+				LastClaimDate:     &claimedAt,
+				LastClaimTimezone: tt.claimTimezone,
+			}
+
+			status, _ := d.resolveDailyRewardStatus(calendar, rewards, progression, tt.loc, tt.now)
+
+			if status.IsNewDay != tt.wantNewDay || status.CanClaim != tt.wantNewDay {
+				t.Errorf("is new day = %v can claim = %v, want %v", status.IsNewDay, status.CanClaim, tt.wantNewDay)
+			}
+
+			if status.Streak.DaysAway != tt.wantDaysAway {
+				t.Errorf("days away = %d, want %d", status.Streak.DaysAway, tt.wantDaysAway)
+			}
+
+			if !status.NextResetAt.Equal(tt.wantNextResetAt) {
+				t.Errorf("next reset at = %s, want %s", status.NextResetAt.UTC(), tt.wantNextResetAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+const (
+	// maxSyncElapsed caps how long a single sync window can be, so a player who
+	// hasn't synced for days can't claim unlimited coins in one request
+	maxSyncElapsed = 24 * time.Hour
+
+	// syncEarningTolerance gives the client some room for timing and rounding differences
+	syncEarningTolerance = 1.1
+
+	// minPreparationTime mirrors the minimum process time used by calculateCurrentProcessTime
+	minPreparationTime = 0.1
+)
+
+// earningRate is the fastest rate a player could earn coins on their current stage,
+// coinsPerSecond includes the boosts active when the rate was calculated
+type earningRate struct {
+	stageID        *int64
+	coinsPerSecond float64
+	stageStartedAt *time.Time
+
+	// Kept to recalculate the rate while boosts start and end inside an earning window
+	config          *entities.GameStageConfig
+	kitchenProgress *entities.UserKitchenStageProgression
+	currentPhase    int64
+	idle            bool
+}
+
+// calculateMaxEarningRate computes the best possible coins per second for the player's latest stage.
+//
+// Every unlocked station is assumed to cook nonstop with all of its helpers, while the
+// amount of orders served at the same time is bounded by the number of tables
+func (g *gameUseCase) calculateMaxEarningRate(ctx context.Context, userID int64) (*earningRate, error) {
+	return g.calculateEarningRate(ctx, userID, false)
+}
+
+// calculateIdleEarningRate computes the coins per second the kitchen keeps earning while the player is away.
+//
+// On top of the limits of calculateMaxEarningRate, orders can only be served as fast as
+// customers arrive according to the stage customer config
+func (g *gameUseCase) calculateIdleEarningRate(ctx context.Context, userID int64) (*earningRate, error) {
+	return g.calculateEarningRate(ctx, userID, true)
+}
+
+func (g *gameUseCase) calculateEarningRate(ctx context.Context, userID int64, idle bool) (*earningRate, error) {
+	rate := &earningRate{}
+
+	latestStage, err := g.userProgressionUseCase.LatestStageProgression(ctx)
+	if errors.Is(err, apperror.ErrStageNotFound) || errors.Is(err, apperror.ErrStageNotStarted) {
+		return rate, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	rate.stageID = &latestStage.StageID
+	rate.stageStartedAt = latestStage.LastStartedAt
+
+	config, err := g.gameStageRepo.GetGameConfigByIDDB(ctx, latestStage.StageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil || config.KitchenConfig == nil {
+		return rate, nil
+	}
+
+	kitchenProgress, err := g.userProgressionRepo.GetUserKitchenProgressDB(ctx, userID, latestStage.StageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if kitchenProgress == nil {
+		return rate, nil
+	}
+
+	phaseProgress, err := g.userProgressionRepo.GetUserKitchenPhaseProgressionDB(ctx, userID, config.KitchenConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentPhase int64 = 1
+	if phaseProgress != nil && phaseProgress.CurrentPhase > 0 {
+		currentPhase = phaseProgress.CurrentPhase
+	}
+
+	boost, err := g.getBoostModifier(ctx, userID, helper.NowUTC())
+	if err != nil {
+		return nil, err
+	}
+
+	rate.config = config
+	rate.kitchenProgress = kitchenProgress
+	rate.currentPhase = currentPhase
+	rate.idle = idle
+	rate.coinsPerSecond = g.calculateKitchenEarningRate(config, kitchenProgress, currentPhase, idle, boost)
+
+	return rate, nil
+}
+
+// calculateCoinsBetween is how many coins the kitchen earns between from and to.
+//
+// The window is split wherever a boost of the player starts or ends, so a boost only
+// counts for the part of the window it was actually active
+func (g *gameUseCase) calculateCoinsBetween(ctx context.Context, userID int64, rate *earningRate, from time.Time, to time.Time) (float64, error) {
+	if rate.config == nil || rate.kitchenProgress == nil || !to.After(from) {
+		return 0, nil
+	}
+
+	boosts, err := g.boostUseCase.GetUserBoostsBetween(ctx, userID, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	boundaries := []time.Time{from, to}
+	for _, b := range boosts {
+		for _, t := range []time.Time{b.StartedAt, b.EndsAt} {
+			if t.After(from) && t.Before(to) {
+				boundaries = append(boundaries, t)
+			}
+		}
+	}
+
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+
+	var coins float64
+	for i := 1; i < len(boundaries); i++ {
+		start, end := boundaries[i-1], boundaries[i]
+		if !end.After(start) {
+			continue
+		}
+
+		boost := entities.NewBoostModifier(boosts, start)
+		coinsPerSecond := g.calculateKitchenEarningRate(rate.config, rate.kitchenProgress, rate.currentPhase, rate.idle, boost)
+		coins += coinsPerSecond * end.Sub(start).Seconds()
+	}
+
+	return coins, nil
+}
+
+// calculateKitchenEarningRate is the coins per second of the unlocked stations in the kitchen progress
+func (g *gameUseCase) calculateKitchenEarningRate(config *entities.GameStageConfig, kitchenProgress *entities.UserKitchenStageProgression, currentPhase int64, idle bool, boost entities.BoostModifier) float64 {
+	tableCount := g.getTableCount(config, currentPhase)
+
+	var totalRate, bestStationRate, totalOrderProfit float64
+	var stationCount int
+	for _, slug := range kitchenProgress.UnlockedStations {
+		station, exists := kitchenProgress.StationLevels[slug]
+		if !exists || station.Level == 0 {
+			continue
+		}
+
+		upgrade := kitchenProgress.EffectiveStationUpgrade(slug)
+		tableCount += upgrade.CustomerCount
+
+		stationRate := g.calculateStationEarningRate(station, upgrade, boost)
+		totalRate += stationRate * float64(1+upgrade.HelperCount)
+		bestStationRate = math.Max(bestStationRate, stationRate)
+
+		totalOrderProfit += g.calculateStationOrderProfit(station, boost)
+		stationCount++
+	}
+
+	// Stage-wide customers add tables, stage-wide helpers can cook for any station so they
+	// are counted at the best rate the tables can absorb
+	tableCount += kitchenProgress.KitchenUpgrades.CustomerCount
+	totalRate += bestStationRate * float64(kitchenProgress.KitchenUpgrades.HelperCount)
+
+	coinsPerSecond := math.Min(totalRate, bestStationRate*float64(tableCount))
+
+	if idle && stationCount > 0 {
+		coinsPerSecond = math.Min(coinsPerSecond, g.calculateCustomerDemandRate(config.CustomerConfig, totalOrderProfit/float64(stationCount)))
+	}
+
+	return coinsPerSecond
+}
+
+// calculateCustomerDemandRate = averageOrderProfit * maxCustomerOrderCount / customerSpawnTime
+func (g *gameUseCase) calculateCustomerDemandRate(config *entities.StageCustomerConfig, averageOrderProfit float64) float64 {
+	if config == nil || config.CustomerSpawnTime <= 0 {
+		return math.Inf(1)
+	}
+
+	orderCount := math.Max(float64(config.MaxCustomerOrderCount), 1)
+
+	return averageOrderProfit * orderCount / config.CustomerSpawnTime
+}
+
+// calculateStationOrderProfit is what a single order of the station pays including boosts.
+// The stored profit already carries the profit bonus, calculateProfit applies it when the station levels up
+func (g *gameUseCase) calculateStationOrderProfit(station entities.UserStationLevel, boost entities.BoostModifier) float64 {
+	return g.calculateBoostedProfit(float64(station.Profit), boost)
+}
+
+// calculateStationEarningRate = profit * boost / (preparationTime * reduceCookingTime)
+func (g *gameUseCase) calculateStationEarningRate(station entities.UserStationLevel, upgrade entities.UserStationUpgrade, boost entities.BoostModifier) float64 {
+	reduceCookingTime := 1.0
+	if upgrade.ReduceCookingTime > 0 && upgrade.ReduceCookingTime < 1 {
+		reduceCookingTime = upgrade.ReduceCookingTime
+	}
+
+	preparationTime := g.calculateBoostedPreparationTime(math.Max(station.PreparationTime*reduceCookingTime, minPreparationTime), boost)
+
+	return g.calculateStationOrderProfit(station, boost) / preparationTime
+}
+
+func (g *gameUseCase) getTableCount(config *entities.GameStageConfig, currentPhase int64) int64 {
+	tableCounts := config.KitchenConfig.TableCountPerPhases
+	if currentPhase > 0 && int(currentPhase) <= len(tableCounts) {
+		return tableCounts[currentPhase-1]
+	}
+
+	if config.CustomerConfig != nil && config.CustomerConfig.StartingOrderTableCount > 0 {
+		return config.CustomerConfig.StartingOrderTableCount
+	}
+
+	return 1
+}
+
+// calculateMaxCoinsEarned returns the coin cap for the window between since and now
+func (g *gameUseCase) calculateMaxCoinsEarned(ctx context.Context, userID int64, rate *earningRate, since *time.Time, now time.Time) (maxCoins int64, elapsed time.Duration, err error) {
+	if since == nil || rate.coinsPerSecond <= 0 {
+		return 0, 0, nil
+	}
+
+	elapsed = now.Sub(*since)
+	if elapsed < 0 {
+		return 0, 0, nil
+	}
+
+	if elapsed > maxSyncElapsed {
+		elapsed = maxSyncElapsed
+	}
+
+	coins, err := g.calculateCoinsBetween(ctx, userID, rate, now.Add(-elapsed), now)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	maxCoins = int64(math.Ceil(coins * syncEarningTolerance))
+
+	return maxCoins, elapsed, nil
+}
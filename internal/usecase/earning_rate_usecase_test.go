@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+)
+
+// newTestEarningStage is a kitchen with a single station whose profit was priced by the upgrade path
+// with a 1.5 profit bonus, so the stored profit is 10 * (1 + 1.5) = 25 and an order takes 5 seconds
+func newTestEarningStage(g *gameUseCase) (*entities.GameStageConfig, *entities.UserKitchenStageProgression) {
+	config := &entities.GameStageConfig{
+		KitchenConfig: &entities.StageKitchenConfig{
+			MaxLevel:              10,
+			UpgradeProfitMultiply: 150,
+			UpgradeCostMultiply:   200,
+			TableCountPerPhases:   []int64{4},
+		},
+		CustomerConfig: &entities.StageCustomerConfig{
+			CustomerSpawnTime:     1,
+			MaxCustomerOrderCount: 10,
+		},
+	}
+
+	progress := &entities.UserKitchenStageProgression{
+		UnlockedStations: []string{testStationSlug},
+		StationUpgrades: map[string]entities.UserStationUpgrade{
+			testStationSlug: {ProfitBonus: 1.5},
+		},
+	}
+
+	profit := g.calculateProfit(10, 1, config.KitchenConfig, 1, g.getProfitMultiplier(progress, testStationSlug))
+	progress.StationLevels = map[string]entities.UserStationLevel{
+		testStationSlug: {Level: 1, Profit: profit, PreparationTime: 5},
+	}
+
+	return config, progress
+}
+
+func TestCalculateKitchenEarningRateUsesStoredProfit(t *testing.T) {
+	g := &gameUseCase{}
+	config, progress := newTestEarningStage(g)
+
+	if profit := progress.StationLevels[testStationSlug].Profit; profit != 25 {
+		t.Fatalf("upgrade path profit = %d, want 25", profit)
+	}
+
+	tests := []struct {
+		name  string
+		idle  bool
+		boost entities.BoostModifier
+		want  float64
+	}{
+		{name: "active", want: 5},
+		{name: "idle", idle: true, want: 5},
+		{name: "profit boost", boost: entities.BoostModifier{ProfitMultiplier: 2}, want: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := g.calculateKitchenEarningRate(config, progress, 1, tt.idle, tt.boost)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("calculateKitchenEarningRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
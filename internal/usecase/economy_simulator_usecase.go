@@ -0,0 +1,568 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+// EconomySimulatorUseCase plays a stage with a simulated player so game designers can tune the kitchen config.
+// Prices, profits, phases and earning rates come from the same functions the game uses, it never touches player data
+type EconomySimulatorUseCase interface {
+	LoadStageConfig(ctx context.Context, slug string) (res *entities.EconomySimulationConfig, err error)
+	Simulate(config *entities.EconomySimulationConfig, params entities.EconomySimulationParams) (res *entities.EconomySimulationResult, err error)
+}
+
+type economySimulatorUseCase struct {
+	// game only provides the pricing and earning calculations, none of its repositories are used
+	game *gameUseCase
+
+	gameStageRepo     repositories.GameStageRepository
+	kitchenConfigRepo repositories.StageKitchenConfigRepository
+	rewardRepo        repositories.RewardRepository
+	foodItemRepo      repositories.FoodItemRepository
+}
+
+func NewEconomySimulatorUseCase(
+	gameStageRepo repositories.GameStageRepository,
+	kitchenConfigRepo repositories.StageKitchenConfigRepository,
+	rewardRepo repositories.RewardRepository,
+	foodItemRepo repositories.FoodItemRepository,
+) EconomySimulatorUseCase {
+	return &economySimulatorUseCase{
+		game:              &gameUseCase{},
+		gameStageRepo:     gameStageRepo,
+		kitchenConfigRepo: kitchenConfigRepo,
+		rewardRepo:        rewardRepo,
+		foodItemRepo:      foodItemRepo,
+	}
+}
+
+type simulationStation struct {
+	config    entities.SimulationStation
+	overrides map[int64]*entities.FoodItemOverrideLevel
+	unlocked  bool
+	profit    int64 // Profit of the current level, what the station actually earns per order
+}
+
+// simulationAction is a purchase the simulated player can make
+type simulationAction struct {
+	station *simulationStation
+	unlock  bool
+	cost    int64
+	level   entities.UserStationLevel // Stored station level after the purchase
+	profit  int64
+	result  *upgradeResult // nil for unlocks
+}
+
+type simulationState struct {
+	config      *entities.EconomySimulationConfig
+	params      entities.EconomySimulationParams
+	stageConfig *entities.GameStageConfig
+	stations    []*simulationStation
+	progress    *entities.UserKitchenStageProgression
+	phase       int64
+	claimed     map[int]bool // Index of the claimed phase rewards
+	coins       float64
+	elapsed     float64
+	earned      float64
+	spent       int64
+	result      *entities.EconomySimulationResult
+}
+
+func (s *economySimulatorUseCase) LoadStageConfig(ctx context.Context, slug string) (*entities.EconomySimulationConfig, error) {
+	stage, err := s.gameStageRepo.GetGameStageBySlugDB(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if stage == nil {
+		return nil, apperror.ErrStageNotFound
+	}
+
+	stageConfig, err := s.gameStageRepo.GetGameConfigByIDDB(ctx, stage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stageConfig == nil || stageConfig.KitchenConfig == nil || stageConfig.KitchenConfig.ID == 0 {
+		return nil, apperror.ErrMissingKitchenConfig
+	}
+
+	config := &entities.EconomySimulationConfig{
+		StageSlug:      stage.Slug,
+		StartingCoin:   stage.StartingCoin,
+		KitchenConfig:  stageConfig.KitchenConfig,
+		CustomerConfig: stageConfig.CustomerConfig,
+	}
+
+	for _, ks := range stageConfig.KitchenStations {
+		foodItem, err := s.foodItemRepo.GetFoodBySlugDB(ctx, ks.FoodItemSlug)
+		if err != nil {
+			return nil, err
+		}
+
+		if foodItem == nil {
+			return nil, apperror.ErrFoodItemNotFound
+		}
+
+		overrides, err := s.foodItemRepo.GetOverrideLevelDB(ctx, foodItem.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Stations = append(config.Stations, entities.SimulationStation{
+			Slug:           ks.FoodItemSlug,
+			Name:           ks.FoodName,
+			InitialCost:    ks.InitialCost,
+			InitialProfit:  ks.InitialProfit,
+			CookingTime:    ks.CookingTime,
+			AutoUnlock:     ks.AutoUnlock,
+			UnlockPhase:    ks.UnlockPhase,
+			OverrideLevels: overrides,
+		})
+	}
+
+	phaseRewards, err := s.kitchenConfigRepo.GetKitchenCompletionRewardsDB(ctx, stageConfig.KitchenConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, phaseReward := range phaseRewards {
+		phaseReward.Reward, err = s.rewardRepo.GetRewardByIDDB(ctx, phaseReward.RewardID)
+		if err != nil {
+			return nil, err
+		}
+
+		if phaseReward.Reward != nil && phaseReward.Reward.RewardType != nil && phaseReward.Reward.RewardType.Slug == entities.RewardTypeBundle.String() {
+			phaseReward.Reward.Items, err = s.rewardRepo.GetRewardBundleItemsDB(ctx, phaseReward.Reward.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		config.PhaseRewards = append(config.PhaseRewards, phaseReward)
+	}
+
+	return config, nil
+}
+
+func (s *economySimulatorUseCase) Simulate(config *entities.EconomySimulationConfig, params entities.EconomySimulationParams) (*entities.EconomySimulationResult, error) {
+	if config == nil || config.KitchenConfig == nil {
+		return nil, apperror.ErrMissingKitchenConfig
+	}
+
+	if config.KitchenConfig.MaxLevel <= 0 || len(config.Stations) == 0 {
+		return nil, apperror.ErrInvalidInput.WithDetails("kitchen config needs a max level and at least one station")
+	}
+
+	if !params.Strategy.IsValid() {
+		return nil, apperror.ErrorInvalidRequest("simulation strategy:", params.Strategy.String())
+	}
+
+	if params.MaxDuration <= 0 {
+		return nil, apperror.ErrInvalidInput.WithDetails("max duration must be positive")
+	}
+
+	state := s.newSimulationState(config, params)
+	maxSeconds := params.MaxDuration.Seconds()
+
+	for {
+		if s.isKitchenMaxed(state) {
+			timeToMax := state.elapsed
+			state.result.ReachedMaxLevel = true
+			state.result.TimeToMaxLevelSeconds = &timeToMax
+			state.result.StopReason = "all stations reached the max level"
+			break
+		}
+
+		actions := s.availableActions(state)
+		if len(actions) == 0 {
+			state.result.StopReason = "no station can be unlocked or upgraded"
+			break
+		}
+
+		action := s.chooseAction(state, actions)
+
+		rate := s.earningRate(state, nil)
+		if state.coins < float64(action.cost) {
+			if rate <= 0 {
+				state.result.StopReason = fmt.Sprintf("no income to afford %s", action.station.config.Slug)
+				break
+			}
+
+			wait := (float64(action.cost) - state.coins) / rate
+			if state.elapsed+wait > maxSeconds {
+				s.advance(state, maxSeconds-state.elapsed, rate)
+				state.result.StopReason = "max duration reached"
+				break
+			}
+
+			s.advance(state, wait, rate)
+			// Avoid floating point leftovers making the purchase fall a fraction of a coin short
+			state.coins = math.Max(state.coins, float64(action.cost))
+		}
+
+		s.purchase(state, action)
+	}
+
+	return s.buildSimulationResult(state), nil
+}
+
+func (s *economySimulatorUseCase) newSimulationState(config *entities.EconomySimulationConfig, params entities.EconomySimulationParams) *simulationState {
+	state := &simulationState{
+		config: config,
+		params: params,
+		stageConfig: &entities.GameStageConfig{
+			KitchenConfig:  config.KitchenConfig,
+			CustomerConfig: config.CustomerConfig,
+		},
+		progress: &entities.UserKitchenStageProgression{
+			StationLevels:   make(map[string]entities.UserStationLevel),
+			StationUpgrades: make(map[string]entities.UserStationUpgrade),
+		},
+		phase:   1,
+		claimed: make(map[int]bool),
+		coins:   float64(config.StartingCoin),
+		result: &entities.EconomySimulationResult{
+			StageSlug: config.StageSlug,
+			Strategy:  params.Strategy.String(),
+		},
+	}
+
+	for _, stationConfig := range config.Stations {
+		station := &simulationStation{
+			config:    stationConfig,
+			overrides: make(map[int64]*entities.FoodItemOverrideLevel),
+		}
+
+		for i := range stationConfig.OverrideLevels {
+			override := stationConfig.OverrideLevels[i]
+			station.overrides[override.Level] = &override
+		}
+
+		if stationConfig.AutoUnlock {
+			level := s.levelOneStation(station)
+			s.unlockStation(state, station, level)
+		}
+
+		state.stations = append(state.stations, station)
+	}
+
+	state.result.Phases = append(state.result.Phases, entities.SimulationPhaseSummary{
+		Phase:          state.phase,
+		CoinsPerSecond: s.earningRate(state, nil),
+	})
+
+	return state
+}
+
+// levelOneStation mirrors the stats a station gets when it is unlocked
+func (s *economySimulatorUseCase) levelOneStation(station *simulationStation) entities.UserStationLevel {
+	if override, exists := station.overrides[1]; exists {
+		return entities.UserStationLevel{
+			Level:           override.Level,
+			Cost:            override.Cost,
+			Profit:          override.Profit,
+			PreparationTime: override.PreparationTime,
+		}
+	}
+
+	return entities.UserStationLevel{
+		Level:           1,
+		Cost:            station.config.InitialCost,
+		Profit:          station.config.InitialProfit,
+		PreparationTime: station.config.CookingTime,
+	}
+}
+
+func (s *economySimulatorUseCase) unlockStation(state *simulationState, station *simulationStation, level entities.UserStationLevel) {
+	station.unlocked = true
+	station.profit = level.Profit
+	state.progress.UnlockedStations = append(state.progress.UnlockedStations, station.config.Slug)
+	state.progress.StationLevels[station.config.Slug] = level
+}
+
+func (s *economySimulatorUseCase) isKitchenMaxed(state *simulationState) bool {
+	for _, station := range state.stations {
+		if !station.unlocked || state.progress.StationLevels[station.config.Slug].Level < state.config.KitchenConfig.MaxLevel {
+			return false
+		}
+	}
+
+	return true
+}
+
+// availableActions prices the next purchase of every station, locked stations are only offered once their unlock phase is reached
+func (s *economySimulatorUseCase) availableActions(state *simulationState) []simulationAction {
+	var actions []simulationAction
+	for _, station := range state.stations {
+		if !station.unlocked {
+			if station.config.UnlockPhase > state.phase {
+				continue
+			}
+
+			level := s.levelOneStation(station)
+			actions = append(actions, simulationAction{
+				station: station,
+				unlock:  true,
+				cost:    station.config.InitialCost,
+				level:   level,
+				profit:  level.Profit,
+			})
+			continue
+		}
+
+		upgradeCtx := &upgradeContext{
+			slug:          station.config.Slug,
+			kitchenConfig: state.config.KitchenConfig,
+			userProgress:  state.progress,
+			userBalance:   &entities.UserBalance{Coin: int64(state.coins)},
+		}
+
+		if err := s.game.validateUpgradeRequirements(upgradeCtx); err != nil {
+			continue
+		}
+
+		overrideCurrentLevel, overrideNextLevel := s.game.applyOverrideLevels(
+			upgradeCtx,
+			station.overrides[upgradeCtx.currentStation.Level],
+			station.overrides[upgradeCtx.nextStation.Level],
+		)
+
+		result := s.game.calculateUpgradeMetrics(upgradeCtx, overrideCurrentLevel, overrideNextLevel)
+		actions = append(actions, simulationAction{
+			station: station,
+			cost:    result.upgradeCost,
+			level:   upgradeCtx.currentStation,
+			profit:  result.currentProfit,
+			result:  result,
+		})
+	}
+
+	return actions
+}
+
+func (s *economySimulatorUseCase) chooseAction(state *simulationState, actions []simulationAction) simulationAction {
+	cheapest := actions[0]
+	for _, action := range actions[1:] {
+		if action.cost < cheapest.cost {
+			cheapest = action
+		}
+	}
+
+	if state.params.Strategy != entities.SimulationStrategyBestROI {
+		return cheapest
+	}
+
+	// Best ROI is the highest earning rate gained per coin spent, purchases that don't raise
+	// the rate right away fall back to the cheapest one
+	currentRate := s.earningRate(state, nil)
+	best, bestROI := cheapest, 0.0
+	for i := range actions {
+		gain := s.earningRate(state, &actions[i]) - currentRate
+		roi := gain / math.Max(float64(actions[i].cost), 1)
+		if roi > bestROI {
+			best, bestROI = actions[i], roi
+		}
+	}
+
+	return best
+}
+
+// earningRate is the coins per second of the kitchen, optionally as if the action was already purchased
+func (s *economySimulatorUseCase) earningRate(state *simulationState, action *simulationAction) float64 {
+	phase := state.phase
+	progress := &entities.UserKitchenStageProgression{
+		StationLevels: make(map[string]entities.UserStationLevel),
+	}
+
+	for _, station := range state.stations {
+		slug := station.config.Slug
+		level := state.progress.StationLevels[slug]
+		profit := station.profit
+
+		if action != nil && action.station == station {
+			level = action.level
+			profit = action.profit
+		} else if !station.unlocked {
+			continue
+		}
+
+		progress.UnlockedStations = append(progress.UnlockedStations, slug)
+		progress.StationLevels[slug] = entities.UserStationLevel{
+			Level:           level.Level,
+			Profit:          profit,
+			PreparationTime: level.PreparationTime,
+		}
+	}
+
+	if action != nil && action.result != nil {
+		phase = max(phase, action.result.currentPhaseInfo.CurrentPhase)
+	}
+
+	// Boosts are bought or granted per player, the simulator tunes the base economy without them
+	return s.game.calculateKitchenEarningRate(state.stageConfig, progress, phase, state.params.CustomerLimited, entities.BoostModifier{})
+}
+
+func (s *economySimulatorUseCase) advance(state *simulationState, seconds float64, rate float64) {
+	earned := rate * seconds
+	state.coins += earned
+	state.earned += earned
+	state.elapsed += seconds
+
+	s.currentPhaseSummary(state).CoinsEarned += int64(earned)
+}
+
+func (s *economySimulatorUseCase) purchase(state *simulationState, action simulationAction) {
+	slug := action.station.config.Slug
+
+	state.coins -= float64(action.cost)
+	state.spent += action.cost
+
+	summary := s.currentPhaseSummary(state)
+	summary.CoinsSpent += action.cost
+	summary.Purchases++
+
+	eventType := entities.SimulationEventUpgrade
+	if action.unlock {
+		eventType = entities.SimulationEventUnlock
+		s.unlockStation(state, action.station, action.level)
+	} else {
+		action.station.profit = action.profit
+		state.progress.StationLevels[slug] = action.level
+	}
+
+	s.recordEvent(state, eventType, slug, action.level.Level, action.cost)
+
+	if action.result == nil {
+		return
+	}
+
+	// Same rules as handlePhaseTransition and handleMaxLevelRewards
+	if action.result.phaseTransitioned {
+		fromPhase := action.result.oldPhaseInfo.CurrentPhase
+		toPhase := action.result.currentPhaseInfo.CurrentPhase
+
+		if toPhase > state.phase {
+			state.phase = toPhase
+			state.result.Phases = append(state.result.Phases, entities.SimulationPhaseSummary{
+				Phase:            toPhase,
+				ReachedAtSeconds: state.elapsed,
+				CoinsPerSecond:   s.earningRate(state, nil),
+			})
+			s.recordEvent(state, entities.SimulationEventPhase, slug, action.level.Level, 0)
+		}
+
+		for i, phaseReward := range state.config.PhaseRewards {
+			if phaseReward.PhaseNumber >= fromPhase && phaseReward.PhaseNumber < toPhase {
+				s.grantPhaseReward(state, i)
+			}
+		}
+	}
+
+	if action.level.Level >= state.config.KitchenConfig.MaxLevel {
+		for i, phaseReward := range state.config.PhaseRewards {
+			if phaseReward.PhaseNumber >= action.result.currentPhaseInfo.CurrentPhase {
+				s.grantPhaseReward(state, i)
+			}
+		}
+	}
+}
+
+// calculateRewardCoins is how many coins a reward grants, the COIN items of a bundle included
+func (s *economySimulatorUseCase) calculateRewardCoins(reward *entities.Reward, rewardTypeSlug string, amount int64) int64 {
+	isCoin := func(slug string) bool {
+		rewardType, err := entities.ToRewardType(slug)
+		return err == nil && rewardType.RequiresBalanceUpdate() && rewardType.ToUserBalance() == entities.BalanceTypeCoin
+	}
+
+	if rewardTypeSlug != entities.RewardTypeBundle.String() {
+		if isCoin(rewardTypeSlug) {
+			return amount
+		}
+
+		return 0
+	}
+
+	var coins int64
+	if reward != nil {
+		for _, item := range reward.Items {
+			if item.RewardType != nil && isCoin(item.RewardType.Slug) {
+				coins += item.Amount
+			}
+		}
+	}
+
+	return coins
+}
+
+func (s *economySimulatorUseCase) grantPhaseReward(state *simulationState, index int) {
+	if state.claimed[index] {
+		return
+	}
+	state.claimed[index] = true
+
+	phaseReward := state.config.PhaseRewards[index]
+	grant := entities.SimulationRewardGrant{
+		Phase:            phaseReward.PhaseNumber,
+		GrantedAtSeconds: state.elapsed,
+	}
+
+	if phaseReward.Reward != nil {
+		grant.RewardSlug = phaseReward.Reward.Slug
+		grant.Amount = phaseReward.Reward.Amount
+
+		if phaseReward.Reward.RewardType != nil {
+			grant.RewardType = phaseReward.Reward.RewardType.Slug
+		}
+	}
+
+	if coins := s.calculateRewardCoins(phaseReward.Reward, grant.RewardType, grant.Amount); coins > 0 {
+		state.coins += float64(coins)
+		state.earned += float64(coins)
+		s.currentPhaseSummary(state).CoinsEarned += coins
+	}
+
+	state.result.Rewards = append(state.result.Rewards, grant)
+	s.recordEvent(state, entities.SimulationEventReward, grant.RewardSlug, 0, grant.Amount)
+}
+
+func (s *economySimulatorUseCase) recordEvent(state *simulationState, eventType entities.SimulationEventType, station string, level int64, amount int64) {
+	state.result.Events = append(state.result.Events, entities.SimulationEvent{
+		TimeSeconds:    state.elapsed,
+		Type:           eventType,
+		Station:        station,
+		Level:          level,
+		Phase:          state.phase,
+		Amount:         amount,
+		Coins:          int64(state.coins),
+		CoinsPerSecond: s.earningRate(state, nil),
+	})
+}
+
+func (s *economySimulatorUseCase) currentPhaseSummary(state *simulationState) *entities.SimulationPhaseSummary {
+	return &state.result.Phases[len(state.result.Phases)-1]
+}
+
+func (s *economySimulatorUseCase) buildSimulationResult(state *simulationState) *entities.EconomySimulationResult {
+	res := state.result
+	res.DurationSeconds = state.elapsed
+	res.TotalCoinsEarned = int64(state.earned)
+	res.TotalCoinsSpent = state.spent
+	res.FinalCoins = int64(state.coins)
+
+	for i := range res.Phases {
+		end := state.elapsed
+		if i+1 < len(res.Phases) {
+			end = res.Phases[i+1].ReachedAtSeconds
+		}
+		res.Phases[i].DurationSeconds = end - res.Phases[i].ReachedAtSeconds
+	}
+
+	return res
+}
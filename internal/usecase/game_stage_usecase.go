@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 
 	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/internal/repositories"
@@ -19,6 +20,9 @@ type GameStageUseCase interface {
 	CreateStageUpgrade(ctx context.Context, stageSlug string, upgradeTypes []string) error
 	GetStageUpgrades(ctx context.Context, stageSlug string, limit, offset int) ([]entities.StageUpgrade, int64, error)
 	UpdateStageUpgrades(ctx context.Context, stageSlug string, upgradeTypes []string) error
+
+	GetStageRequirements(ctx context.Context, stageID int64) ([]entities.StageCompletionRequirement, error)
+	UpdateStageRequirements(ctx context.Context, stageID int64, requirements []entities.StageCompletionRequirement) ([]entities.StageCompletionRequirement, error)
 }
 
 type gameStageUseCase struct {
@@ -32,6 +36,7 @@ type gameStageUseCase struct {
 	foodItemRepo       repositories.FoodItemRepository
 	upgradeRepo        repositories.UpgradeRepository
 	stageUpgradeRepo   repositories.StageUpgradeRepository
+	stageReqRepo       repositories.StageRequirementRepository
 }
 
 func NewGameStageUseCase(
@@ -45,6 +50,7 @@ func NewGameStageUseCase(
 	foodItemRepo repositories.FoodItemRepository,
 	upgradeRepo repositories.UpgradeRepository,
 	stageUpgradeRepo repositories.StageUpgradeRepository,
+	stageReqRepo repositories.StageRequirementRepository,
 ) GameStageUseCase {
 	return &gameStageUseCase{
 		gameStageRepo:      gameStageRepo,
@@ -57,6 +63,7 @@ func NewGameStageUseCase(
 		foodItemRepo:       foodItemRepo,
 		upgradeRepo:        upgradeRepo,
 		stageUpgradeRepo:   stageUpgradeRepo,
+		stageReqRepo:       stageReqRepo,
 	}
 }
 
@@ -414,6 +421,10 @@ func (u *gameStageUseCase) buildStageUpgrades(ctx context.Context, stageID int64
 		return nil, apperror.ErrorInvalidRequest("some upgrade slugs are invalid")
 	}
 
+	if err := u.validateStageUpgradePrerequisites(ctx, upgrades); err != nil {
+		return nil, err
+	}
+
 	// Build StageUpgrade entities
 	stageUpgrades := make([]entities.StageUpgrade, len(upgrades))
 	for i, upgrade := range upgrades {
@@ -426,6 +437,30 @@ func (u *gameStageUseCase) buildStageUpgrades(ctx context.Context, stageID int64
 	return stageUpgrades, nil
 }
 
+// validateStageUpgradePrerequisites purchases are kept per stage, so the stage must also sell
+// every upgrade required by the upgrades it sells
+func (u *gameStageUseCase) validateStageUpgradePrerequisites(ctx context.Context, upgrades []entities.Upgrade) error {
+	upgradeIDs := make([]int64, len(upgrades))
+	slugs := make(map[int64]string, len(upgrades))
+	for i, upgrade := range upgrades {
+		upgradeIDs[i] = upgrade.ID
+		slugs[upgrade.ID] = upgrade.Slug
+	}
+
+	prerequisites, err := u.upgradeRepo.GetUpgradePrerequisitesDB(ctx, upgradeIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range prerequisites {
+		if p.Type == entities.UpgradePrerequisiteUpgrade && !slices.Contains(upgradeIDs, p.RequiredUpgradeID) {
+			return apperror.ErrorInvalidRequest("upgrade", slugs[p.UpgradeID], "requires", p.RequiredUpgradeSlug, "which the stage doesn't sell")
+		}
+	}
+
+	return nil
+}
+
 // getStageUpgradeBySlug gets stage upgrade by slug
 func (u *gameStageUseCase) getStageUpgradeBySlug(ctx context.Context, stageSlug string) (*entities.GameStage, error) {
 	stage, err := u.gameStageRepo.GetGameStageBySlugDB(ctx, stageSlug)
@@ -439,3 +474,45 @@ func (u *gameStageUseCase) getStageUpgradeBySlug(ctx context.Context, stageSlug
 
 	return stage, nil
 }
+
+// GetStageRequirements gets the completion requirements of a stage
+func (u *gameStageUseCase) GetStageRequirements(ctx context.Context, stageID int64) ([]entities.StageCompletionRequirement, error) {
+	gameStage, err := u.gameStageRepo.GetGameStageByIDDB(ctx, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if gameStage == nil {
+		return nil, apperror.ErrorNotFound(fmt.Sprintf("game stage id %d", stageID))
+	}
+
+	return u.stageReqRepo.GetStageRequirementsDB(ctx, stageID)
+}
+
+// UpdateStageRequirements replaces the completion requirements of a stage with transaction
+func (u *gameStageUseCase) UpdateStageRequirements(ctx context.Context, stageID int64, requirements []entities.StageCompletionRequirement) ([]entities.StageCompletionRequirement, error) {
+	gameStage, err := u.gameStageRepo.GetGameStageByIDDB(ctx, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if gameStage == nil {
+		return nil, apperror.ErrorNotFound(fmt.Sprintf("game stage id %d", stageID))
+	}
+
+	err = u.stageReqRepo.StageRequirementWithTx(ctx, func(tx *sql.Tx) error {
+		stageReqTx := u.stageReqRepo.WithTx(tx)
+
+		err := stageReqTx.DeleteStageRequirementsDB(ctx, stageID)
+		if err != nil {
+			return err
+		}
+
+		return stageReqTx.BulkCreateStageRequirementsDB(ctx, requirements)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.stageReqRepo.GetStageRequirementsDB(ctx, stageID)
+}
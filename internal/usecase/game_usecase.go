@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/winartodev/cat-cafe/internal/dto"
 	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/internal/repositories"
@@ -12,7 +13,8 @@ import (
 )
 
 const (
-	upgradeTargetFood = "food"
+	// maxUpgradePreviewLevels bounds how many levels a single preview request can project
+	maxUpgradePreviewLevels = 100
 )
 
 // GameUseCase is used for interaction with player
@@ -22,11 +24,14 @@ type GameUseCase interface {
 
 	GetGameStages(ctx context.Context) (res []entities.UserGameStage, nextStage *entities.UserNextGameStageInfo, err error)
 	GetCurrentGameStage(ctx context.Context) (stage *entities.GameStage, config *entities.GameStageConfig, nextStage *entities.UserNextGameStageInfo, err error)
-	StartGameStage(ctx context.Context, slug string) (stage *entities.GameStage, config *entities.GameStageConfig, nextStage *entities.UserNextGameStageInfo, err error)
-	CompleteGameStage(ctx context.Context, slug string) (err error)
+	StartGameStage(ctx context.Context, slug string) (stage *entities.GameStage, config *entities.GameStageConfig, nextStage *entities.UserNextGameStageInfo, grant *entities.StageCoinGrant, err error)
+	CompleteGameStage(ctx context.Context, slug string) (grant *entities.StageCoinGrant, err error)
+
+	BoostOfflineEarnings(ctx context.Context, id int64) (res *entities.OfflineEarning, balance *entities.UserBalance, err error)
 
 	UnlockKitchenStation(ctx context.Context, slug string) (res *entities.UnlockKitchenStation, err error)
-	UpgradeKitchenStation(ctx context.Context, slug string) (res *entities.UpgradeKitchenStation, err error)
+	UpgradeKitchenStation(ctx context.Context, slug string, count int64, toMax bool) (res *entities.UpgradeKitchenStation, err error)
+	PreviewStationUpgrade(ctx context.Context, slug string, fromLevel int64, toLevel int64) (res *entities.StationUpgradePreview, err error)
 
 	GetStageUpgrades(ctx context.Context) (res []entities.UserStageUpgrade, err error)
 	PurchaseStageUpgrade(ctx context.Context, slug string) (res *entities.Upgrade, err error)
@@ -35,6 +40,9 @@ type GameUseCase interface {
 type gameUseCase struct {
 	userUseCase            UserUseCase
 	userProgressionUseCase UserProgressionUseCase
+	boostUseCase           BoostUseCase
+	rewardGrantUseCase     RewardGrantUseCase
+	dailyRewardUseCase     DailyRewardUseCase
 
 	userProgressionRepo repositories.UserProgressionRepository
 	userRepo            repositories.UserRepository
@@ -44,6 +52,8 @@ type gameUseCase struct {
 	kitchenConfigRepo   repositories.StageKitchenConfigRepository
 	rewardRepo          repositories.RewardRepository
 	stageUpgradeRepo    repositories.StageUpgradeRepository
+	stageReqRepo        repositories.StageRequirementRepository
+	upgradeRepo         repositories.UpgradeRepository
 }
 
 func NewGameUseCase(
@@ -57,6 +67,11 @@ func NewGameUseCase(
 	kitchenConfigRepo repositories.StageKitchenConfigRepository,
 	rewardRepo repositories.RewardRepository,
 	stageUpgradeRepo repositories.StageUpgradeRepository,
+	stageReqRepo repositories.StageRequirementRepository,
+	upgradeRepo repositories.UpgradeRepository,
+	boostUseCase BoostUseCase,
+	rewardGrantUseCase RewardGrantUseCase,
+	dailyRewardUseCase DailyRewardUseCase,
 ) GameUseCase {
 	return &gameUseCase{
 		userUseCase:            userUc,
@@ -69,6 +84,11 @@ func NewGameUseCase(
 		kitchenConfigRepo:      kitchenConfigRepo,
 		rewardRepo:             rewardRepo,
 		stageUpgradeRepo:       stageUpgradeRepo,
+		stageReqRepo:           stageReqRepo,
+		upgradeRepo:            upgradeRepo,
+		boostUseCase:           boostUseCase,
+		rewardGrantUseCase:     rewardGrantUseCase,
+		dailyRewardUseCase:     dailyRewardUseCase,
 	}
 }
 
@@ -78,16 +98,60 @@ func (g *gameUseCase) UpdateUserBalance(ctx context.Context, coinEarned int64) (
 		return nil, err
 	}
 
-	// TODO: should we validate earning rate ???
+	if coinEarned < 0 {
+		return nil, apperror.ErrInvalidInput
+	}
+
+	rate, err := g.calculateMaxEarningRate(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	err = g.userRepo.BalanceWithTx(ctx, func(tx *sql.Tx) error {
 		txRepo := g.userRepo.WithTx(tx)
 
-		if err := txRepo.UpdateUserBalanceWithTx(ctx, userID, entities.BalanceTypeCoin, coinEarned); err != nil {
+		// Lock the user row so concurrent syncs can't reuse the same window
+		lastSync, err := txRepo.GetLastSyncBalanceForUpdateDB(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		since := lastSync
+		if since == nil {
+			since = rate.stageStartedAt
+		}
+
+		now := helper.NowUTC()
+		maxCoins, elapsed, err := g.calculateMaxCoinsEarned(ctx, userID, rate, since, now)
+		if err != nil {
+			return err
+		}
+
+		coinCredited := coinEarned
+		if coinEarned > maxCoins {
+			coinCredited = maxCoins
+
+			err = txRepo.CreateBalanceSyncDiscrepancyDB(ctx, &entities.BalanceSyncDiscrepancy{
+				UserID:         userID,
+				StageID:        rate.stageID,
+				CoinsClaimed:   coinEarned,
+				CoinsAllowed:   maxCoins,
+				CoinsPerSecond: rate.coinsPerSecond,
+				ElapsedSeconds: elapsed.Seconds(),
+				LastSyncAt:     lastSync,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := txRepo.UpdateUserBalanceWithTx(ctx, userID, entities.BalanceTypeCoin, coinCredited, entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourceSyncBalance,
+		}); err != nil {
 			return err
 		}
 
-		if err := txRepo.UpdateLastSyncBalanceWithTx(ctx, userID, helper.NowUTC()); err != nil {
+		if err := txRepo.UpdateLastSyncBalanceWithTx(ctx, userID, now); err != nil {
 			return err
 		}
 
@@ -116,14 +180,29 @@ func (g *gameUseCase) GetUserGameData(ctx context.Context) (res *entities.Game,
 		return nil, err
 	}
 
-	isDailyRewardAvailable, err := g.userUseCase.IsDailyRewardAvailable(ctx, userID)
+	isDailyRewardAvailable, err := g.dailyRewardUseCase.IsDailyRewardAvailable(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	offlineEarning, err := g.claimOfflineEarnings(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &entities.Game{
+	res = &entities.Game{
 		DailyRewardAvailable: isDailyRewardAvailable,
-	}, nil
+		OfflineEarning:       offlineEarning,
+	}
+
+	if offlineEarning != nil {
+		res.UserBalance, err = g.userUseCase.GetUserBalance(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
 }
 
 func (g *gameUseCase) GetGameStages(ctx context.Context) (res []entities.UserGameStage, nextStage *entities.UserNextGameStageInfo, err error) {
@@ -155,6 +234,25 @@ func (g *gameUseCase) GetGameStages(ctx context.Context) (res []entities.UserGam
 
 	stages, nextStage := g.mapToUserGameStage(gameStages, latestProgress)
 
+	// The next stage can be unlocked early by an unlock_restaurant upgrade
+	if !latestProgress.IsComplete && nextStage != nil && nextStage.Status == entities.GSStatusLocked {
+		nextStageID, _ := g.getNextStageID(gameStages, latestProgress.StageID)
+
+		unlocked, err := g.userProgressionRepo.CheckStageProgressionExistsDB(ctx, userID, nextStageID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if unlocked {
+			nextStage.Status = entities.GSStatusAvailable
+			for i := range stages {
+				if stages[i].Slug == nextStage.Slug {
+					stages[i].Status = entities.GSStatusAvailable
+				}
+			}
+		}
+	}
+
 	return stages, nextStage, nil
 }
 
@@ -188,21 +286,21 @@ func (g *gameUseCase) GetCurrentGameStage(ctx context.Context) (stage *entities.
 	return stage, config, nextStage, nil
 }
 
-func (g *gameUseCase) StartGameStage(ctx context.Context, slug string) (stage *entities.GameStage, config *entities.GameStageConfig, nextStage *entities.UserNextGameStageInfo, err error) {
+func (g *gameUseCase) StartGameStage(ctx context.Context, slug string) (stage *entities.GameStage, config *entities.GameStageConfig, nextStage *entities.UserNextGameStageInfo, grant *entities.StageCoinGrant, err error) {
 	userID, err := helper.GetUserIDFromContext(ctx)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	stage, err = g.gameStageRepo.GetGameStageBySlugDB(ctx, slug)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	// Get user's available stages
 	userStages, nextStage, err := g.GetGameStages(ctx)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	// Check if user can access this stage
@@ -218,25 +316,42 @@ func (g *gameUseCase) StartGameStage(ctx context.Context, slug string) (stage *e
 	}
 
 	if !canAccess {
-		return nil, nil, nil, apperror.ErrStageLocked
+		return nil, nil, nil, nil, apperror.ErrStageLocked
 	}
 
 	config, err = g.gameStageRepo.GetGameConfigByIDDB(ctx, stage.ID)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
-	err = g.userProgressionUseCase.InitializeUserProgression(ctx, userID, stage.ID, config)
+	// Settle the idle income of the stage the player was on before resuming
+	offlineEarning, err := g.claimOfflineEarnings(ctx, userID)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+
+	startingCoinGranted, err := g.userProgressionUseCase.InitializeUserProgression(ctx, userID, stage, config)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
 
 	err = g.gatherUserProgressionData(ctx, userID, stage.ID, config)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
-	return stage, config, nextStage, nil
+	balance, err := g.userUseCase.GetUserBalance(ctx, userID)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	grant = &entities.StageCoinGrant{
+		Granted:        startingCoinGranted,
+		Balance:        balance,
+		OfflineEarning: offlineEarning,
+	}
+
+	return stage, config, nextStage, grant, nil
 }
 
 func (g *gameUseCase) gatherUserProgressionData(ctx context.Context, userID int64, stageID int64, config *entities.GameStageConfig) (err error) {
@@ -317,6 +432,11 @@ func (g *gameUseCase) gatherUserProgressionData(ctx context.Context, userID int6
 	// Attach user progression to config for DTO mapping
 	config.UserProgress = userKitchenProgress
 
+	config.Requirements, err = g.evaluateStageRequirements(ctx, userID, stageID, config)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -386,52 +506,101 @@ func (g *gameUseCase) mapToUserGameStage(stages []entities.GameStage, lastProgre
 	return res, nextStage
 }
 
-func (g *gameUseCase) CompleteGameStage(ctx context.Context, slug string) (err error) {
+// CompleteGameStage marks the stage as complete, credits its prize once and opens the next stage in one transaction
+func (g *gameUseCase) CompleteGameStage(ctx context.Context, slug string) (grant *entities.StageCoinGrant, err error) {
 	userID, err := helper.GetUserIDFromContext(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	stage, err := g.gameStageRepo.GetGameStageBySlugDB(ctx, slug)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if stage == nil {
-		return apperror.ErrRecordNotFound
+		return nil, apperror.ErrRecordNotFound
 	}
 
 	userStageProgress, err := g.userProgressionRepo.GetGameStageProgressionDB(ctx, userID, stage.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = g.validateLastProgression(userStageProgress, stage); err != nil {
-		return err
+		return nil, err
 	}
 
-	err = g.userProgressionRepo.MarkStageAsCompleteDB(ctx, userID, stage.ID)
+	requirements, err := g.evaluateStageRequirements(ctx, userID, stage.ID, nil)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err = g.validateStageRequirements(requirements); err != nil {
+		return nil, err
 	}
 
 	gameStages, err := g.gameStageRepo.GetActiveGameStagesDB(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for i, s := range gameStages {
-		if s.ID == stage.ID && i+1 < len(gameStages) {
-			nextStageID := gameStages[i+1].ID
-			_, err = g.userProgressionRepo.CreateGameStageProgressionDB(ctx, userID, nextStageID)
-			if err != nil && !errors.Is(err, apperror.ErrConflict) {
+	var prizeGranted int64
+	err = g.userProgressionRepo.WithUserProgressionTx(ctx, func(tx *sql.Tx) error {
+		userProgressionTx := g.userProgressionRepo.WithTx(tx)
+
+		err := userProgressionTx.MarkStageAsCompleteDB(ctx, userID, stage.ID)
+		if err != nil {
+			return err
+		}
+
+		claimed, err := userProgressionTx.ClaimStagePrizeDB(ctx, userID, stage.ID)
+		if err != nil {
+			return err
+		}
+
+		if claimed && stage.StagePrize > 0 {
+			err = g.userRepo.WithTx(tx).UpdateUserBalanceWithTx(ctx, userID, entities.BalanceTypeCoin, stage.StagePrize, entities.CurrencyLedgerSource{
+				Type: entities.CurrencySourceStagePrize,
+				Ref:  stage.Slug,
+			})
+			if err != nil {
 				return err
 			}
-			break
+
+			prizeGranted = stage.StagePrize
+		}
+
+		if nextStageID, ok := g.getNextStageID(gameStages, stage.ID); ok {
+			// A failed insert would abort the transaction, so check before creating
+			exists, err := userProgressionTx.CheckStageProgressionExistsDB(ctx, userID, nextStageID)
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				_, err = userProgressionTx.CreateGameStageProgressionDB(ctx, userID, nextStageID)
+				if err != nil {
+					return err
+				}
+			}
 		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	balance, err := g.userUseCase.GetUserBalance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.StageCoinGrant{
+		Granted: prizeGranted,
+		Balance: balance,
+	}, nil
 }
 
 func (g *gameUseCase) UnlockKitchenStation(ctx context.Context, slug string) (*entities.UnlockKitchenStation, error) {
@@ -468,44 +637,90 @@ func (g *gameUseCase) UnlockKitchenStation(ctx context.Context, slug string) (*e
 	return g.buildUnlockResponse(unlockCtx, result), nil
 }
 
-func (g *gameUseCase) UpgradeKitchenStation(ctx context.Context, slug string) (*entities.UpgradeKitchenStation, error) {
+// UpgradeKitchenStation upgrades the station by count levels, or by as many levels as the player can afford when toMax is set.
+// Every level is priced the same way as a single upgrade, and all of them are paid in one transaction
+func (g *gameUseCase) UpgradeKitchenStation(ctx context.Context, slug string, count int64, toMax bool) (*entities.UpgradeKitchenStation, error) {
 	userID, err := helper.GetUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if !toMax && count < 1 {
+		return nil, apperror.ErrorInvalidParam("count")
+	}
+
 	// Gather all required data
 	upgradeCtx, err := g.gatherUpgradeData(ctx, userID, slug)
 	if err != nil {
 		return nil, err
 	}
 
-	// Validate upgrade requirements
-	if err := g.validateUpgradeRequirements(upgradeCtx); err != nil {
+	// Price every level up front
+	steps, err := g.planUpgradeSteps(ctx, upgradeCtx, count, toMax)
+	if err != nil {
 		return nil, err
 	}
 
-	// process override level if any
-	overrideCurrentLevel, overrideNextLevel, err := g.proceedOverrideLevel(ctx, upgradeCtx)
+	// Execute upgrade transaction
+	result, err := g.executeUpgradeTransaction(ctx, upgradeCtx, steps)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate upgrade metrics
-	result := g.calculateUpgradeMetrics(upgradeCtx, overrideCurrentLevel, overrideNextLevel)
+	// Build and return response
+	res := g.buildUpgradeResponse(upgradeCtx, result)
+	res.LevelsUpgraded = int64(len(steps))
+	res.PhaseTransitions = result.phaseTransitions
 
-	// Check sufficient funds
-	if upgradeCtx.userBalance.Coin < result.upgradeCost {
-		return nil, apperror.ErrInsufficientCoins
+	return res, nil
+}
+
+// PreviewStationUpgrade projects the upgrades of a station between fromLevel and toLevel without spending anything.
+// A zero fromLevel starts at the next level, and a zero toLevel previews maxUpgradePreviewLevels levels
+func (g *gameUseCase) PreviewStationUpgrade(ctx context.Context, slug string, fromLevel int64, toLevel int64) (*entities.StationUpgradePreview, error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Execute upgrade transaction
-	if err := g.executeUpgradeTransaction(ctx, upgradeCtx, result); err != nil {
+	upgradeCtx, err := g.gatherUpgradeData(ctx, userID, slug)
+	if err != nil {
 		return nil, err
 	}
 
-	// Build and return response
-	return g.buildUpgradeResponse(upgradeCtx, result), nil
+	if upgradeCtx.userProgress == nil || !g.isStationUnlocked(upgradeCtx.userProgress.UnlockedStations, slug) {
+		return nil, apperror.ErrStationLocked
+	}
+
+	currentLevel := upgradeCtx.userProgress.StationLevels[slug].Level
+	if fromLevel <= currentLevel {
+		fromLevel = currentLevel + 1
+	}
+
+	if toLevel <= 0 {
+		toLevel = fromLevel + maxUpgradePreviewLevels - 1
+	}
+
+	if toLevel < fromLevel {
+		return nil, apperror.ErrorInvalidParam("to must not be lower than from")
+	}
+
+	if toLevel-fromLevel+1 > maxUpgradePreviewLevels {
+		return nil, apperror.ErrorInvalidParam(fmt.Sprintf("at most %d levels can be previewed at once", maxUpgradePreviewLevels))
+	}
+
+	levels, err := g.previewUpgradeLevels(ctx, upgradeCtx, fromLevel, toLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.StationUpgradePreview{
+		Name:         upgradeCtx.foodItem.Name,
+		Slug:         upgradeCtx.foodItem.Slug,
+		CurrentLevel: currentLevel,
+		MaxLevel:     upgradeCtx.kitchenConfig.MaxLevel,
+		Levels:       levels,
+	}, nil
 }
 
 func (g *gameUseCase) validateLastProgression(lastProgression *entities.UserGameStageProgression, stage *entities.GameStage) error {
@@ -533,6 +748,15 @@ func (g *gameUseCase) getSequenceByID(stages []entities.GameStage, id int64) int
 	return 0
 }
 
+func (g *gameUseCase) getNextStageID(stages []entities.GameStage, id int64) (int64, bool) {
+	for i, s := range stages {
+		if s.ID == id && i+1 < len(stages) {
+			return stages[i+1].ID, true
+		}
+	}
+	return 0, false
+}
+
 func (g *gameUseCase) setNextStageIfExists(stages []entities.GameStage, index int, status entities.GameStageStatus) *entities.UserNextGameStageInfo {
 	if index >= len(stages) {
 		return nil
@@ -546,7 +770,7 @@ func (g *gameUseCase) setNextStageIfExists(stages []entities.GameStage, index in
 }
 
 func (g *gameUseCase) GetStageUpgrades(ctx context.Context) (res []entities.UserStageUpgrade, err error) {
-	_, err = helper.GetUserIDFromContext(ctx)
+	userID, err := helper.GetUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -562,6 +786,16 @@ func (g *gameUseCase) GetStageUpgrades(ctx context.Context) (res []entities.User
 		return nil, err
 	}
 
+	kitchenProgress, err := g.userProgressionRepo.GetUserKitchenProgressDB(ctx, userID, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.evaluateUpgradeLocks(ctx, userID, stageID, stageUpgrades, kitchenProgress)
+	if err != nil {
+		return nil, err
+	}
+
 	return stageUpgrades, nil
 }
 
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+const (
+	// maxOfflineElapsed caps how long the kitchen keeps earning while the player is away
+	maxOfflineElapsed = 8 * time.Hour
+
+	// minOfflineElapsed skips short breaks, those are covered by the regular balance sync
+	minOfflineElapsed = time.Minute
+
+	// offlineEarningBonusMultiplier is applied when the player chooses to boost their offline earnings,
+	// e.g. after watching an ad. The bonus credits the difference on top of what was already granted
+	offlineEarningBonusMultiplier = 2
+
+	// offlineEarningBonusWindow is how long after being granted the offline earnings can still be boosted
+	offlineEarningBonusWindow = 30 * time.Minute
+)
+
+// claimOfflineEarnings grants the coins the kitchen earned since the last sync.
+//
+// The window is closed by moving the last sync forward, so the client can't claim the same
+// time again through UpdateUserBalance. Returns nil when there is nothing to grant
+func (g *gameUseCase) claimOfflineEarnings(ctx context.Context, userID int64) (*entities.OfflineEarning, error) {
+	rate, err := g.calculateIdleEarningRate(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rate.coinsPerSecond <= 0 {
+		return nil, nil
+	}
+
+	var earning *entities.OfflineEarning
+	err = g.userRepo.BalanceWithTx(ctx, func(tx *sql.Tx) error {
+		txRepo := g.userRepo.WithTx(tx)
+
+		// Lock the user row so a concurrent login or sync can't reuse the same window
+		lastSync, err := txRepo.GetLastSyncBalanceForUpdateDB(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		since := lastSync
+		if since == nil {
+			since = rate.stageStartedAt
+		}
+
+		if since == nil {
+			return nil
+		}
+
+		now := helper.NowUTC()
+		elapsed := now.Sub(*since)
+		if elapsed < minOfflineElapsed {
+			return nil
+		}
+
+		isCapped := elapsed > maxOfflineElapsed
+		if isCapped {
+			elapsed = maxOfflineElapsed
+		}
+
+		earned, err := g.calculateCoinsBetween(ctx, userID, rate, *since, since.Add(elapsed))
+		if err != nil {
+			return err
+		}
+
+		coins := int64(math.Floor(earned))
+		if coins <= 0 {
+			return nil
+		}
+
+		earning = &entities.OfflineEarning{
+			UserID:         userID,
+			StageID:        rate.stageID,
+			Coins:          coins,
+			CoinsPerSecond: rate.coinsPerSecond,
+			ElapsedSeconds: elapsed.Seconds(),
+			IsCapped:       isCapped,
+			CreatedAt:      now,
+		}
+
+		earning.ID, err = txRepo.CreateOfflineEarningDB(ctx, earning)
+		if err != nil {
+			return err
+		}
+
+		if err := txRepo.UpdateUserBalanceWithTx(ctx, userID, entities.BalanceTypeCoin, coins, entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourceOfflineEarning,
+			Ref:  fmt.Sprintf("offline_earning:%d", earning.ID),
+		}); err != nil {
+			return err
+		}
+
+		return txRepo.UpdateLastSyncBalanceWithTx(ctx, userID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if earning != nil {
+		_ = g.userRepo.DeleteUserRedis(ctx, userID)
+	}
+
+	return earning, nil
+}
+
+// BoostOfflineEarnings multiplies an offline earnings grant by offlineEarningBonusMultiplier,
+// it can only be done once per grant and only shortly after it was granted
+func (g *gameUseCase) BoostOfflineEarnings(ctx context.Context, id int64) (res *entities.OfflineEarning, balance *entities.UserBalance, err error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = g.userRepo.BalanceWithTx(ctx, func(tx *sql.Tx) error {
+		txRepo := g.userRepo.WithTx(tx)
+
+		earning, err := txRepo.GetOfflineEarningByIDForUpdateDB(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		if earning == nil {
+			return apperror.ErrRecordNotFound
+		}
+
+		if earning.IsBonusGranted() {
+			return apperror.ErrInvalidState.WithDetails("offline earnings were already boosted")
+		}
+
+		now := helper.NowUTC()
+		if now.Sub(earning.CreatedAt) > offlineEarningBonusWindow {
+			return apperror.ErrInvalidState.WithDetails("offline earnings can no longer be boosted")
+		}
+
+		bonusCoins := earning.Coins * (offlineEarningBonusMultiplier - 1)
+		if err := txRepo.UpdateOfflineEarningBonusDB(ctx, earning.ID, bonusCoins, now); err != nil {
+			return err
+		}
+
+		if err := txRepo.UpdateUserBalanceWithTx(ctx, userID, entities.BalanceTypeCoin, bonusCoins, entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourceOfflineBonus,
+			Ref:  fmt.Sprintf("offline_earning:%d", earning.ID),
+		}); err != nil {
+			return err
+		}
+
+		earning.BonusCoins = bonusCoins
+		earning.BonusGrantedAt = &now
+		res = earning
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_ = g.userRepo.DeleteUserRedis(ctx, userID)
+
+	balance, err = g.userUseCase.GetUserBalance(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, balance, nil
+}
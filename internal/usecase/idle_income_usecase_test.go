@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type fakeOfflineUserRepository struct {
+	repositories.UserRepository
+
+	lastSync *time.Time
+	granted  int64
+	source   entities.CurrencyLedgerSource
+}
+
+func (r *fakeOfflineUserRepository) WithTx(tx *sql.Tx) repositories.UserRepository { return r }
+
+func (r *fakeOfflineUserRepository) BalanceWithTx(ctx context.Context, fn func(txRepo *sql.Tx) error) error {
+	return fn(nil)
+}
+
+func (r *fakeOfflineUserRepository) GetLastSyncBalanceForUpdateDB(ctx context.Context, userID int64) (*time.Time, error) {
+	return r.lastSync, nil
+}
+
+func (r *fakeOfflineUserRepository) CreateOfflineEarningDB(ctx context.Context, data *entities.OfflineEarning) (int64, error) {
+	return 1, nil
+}
+
+func (r *fakeOfflineUserRepository) UpdateUserBalanceWithTx(ctx context.Context, userID int64, balanceType entities.UserBalanceType, amount int64, source entities.CurrencyLedgerSource) error {
+	r.granted += amount
+	r.source = source
+	return nil
+}
+
+func (r *fakeOfflineUserRepository) UpdateLastSyncBalanceWithTx(ctx context.Context, userID int64, lastSyncTime time.Time) error {
+	r.lastSync = &lastSyncTime
+	return nil
+}
+
+func (r *fakeOfflineUserRepository) DeleteUserRedis(ctx context.Context, userID int64) error {
+	return nil
+}
+
+type fakeLatestStageUseCase struct {
+	UserProgressionUseCase
+
+	latest *entities.UserGameStageProgression
+}
+
+func (u *fakeLatestStageUseCase) LatestStageProgression(ctx context.Context) (*entities.UserGameStageProgression, error) {
+	return u.latest, nil
+}
+
+type fakeStageConfigRepository struct {
+	repositories.GameStageRepository
+
+	config *entities.GameStageConfig
+}
+
+func (r *fakeStageConfigRepository) GetGameConfigByIDDB(ctx context.Context, stageID int64) (*entities.GameStageConfig, error) {
+	return r.config, nil
+}
+
+type fakeKitchenProgressRepository struct {
+	repositories.UserProgressionRepository
+
+	kitchen *entities.UserKitchenStageProgression
+}
+
+func (r *fakeKitchenProgressRepository) GetUserKitchenProgressDB(ctx context.Context, userID int64, stageID int64) (*entities.UserKitchenStageProgression, error) {
+	return r.kitchen, nil
+}
+
+func (r *fakeKitchenProgressRepository) GetUserKitchenPhaseProgressionDB(ctx context.Context, userID int64, kitchenConfigID int64) (*entities.UserKitchenPhaseProgression, error) {
+	return nil, nil
+}
+
+type fakeNoBoostUseCase struct {
+	BoostUseCase
+}
+
+func (u *fakeNoBoostUseCase) GetUserBoostsBetween(ctx context.Context, userID int64, from time.Time, to time.Time) ([]entities.UserBoost, error) {
+	return nil, nil
+}
+
+func TestClaimOfflineEarningsUsesUpgradePathProfit(t *testing.T) {
+	g := &gameUseCase{boostUseCase: &fakeNoBoostUseCase{}}
+	config, kitchen := newTestEarningStage(g)
+
+	lastSync := helper.NowUTC().Add(-time.Hour)
+	userRepo := &fakeOfflineUserRepository{lastSync: &lastSync}
+
+	g.userRepo = userRepo
+	g.userProgressionUseCase = &fakeLatestStageUseCase{latest: &entities.UserGameStageProgression{StageID: 1}}
+	g.gameStageRepo = &fakeStageConfigRepository{config: config}
+	g.userProgressionRepo = &fakeKitchenProgressRepository{kitchen: kitchen}
+
+	earning, err := g.claimOfflineEarnings(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("claimOfflineEarnings() error = %v", err)
+	}
+
+	// The station sells an order of its stored profit every preparation time, the bonus is not applied again
+	station := kitchen.StationLevels[testStationSlug]
+	want := station.Profit * 3600 / int64(station.PreparationTime)
+
+	if earning == nil || earning.Coins != want || userRepo.granted != want {
+		t.Fatalf("claimOfflineEarnings() = %+v granted %d, want %d coins", earning, userRepo.granted, want)
+	}
+
+	if userRepo.source.Type != entities.CurrencySourceOfflineEarning {
+		t.Errorf("ledger source = %s, want %s", userRepo.source.Type, entities.CurrencySourceOfflineEarning)
+	}
+
+	if !userRepo.lastSync.After(lastSync) {
+		t.Error("claimOfflineEarnings() did not close the window")
+	}
+}
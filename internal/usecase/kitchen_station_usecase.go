@@ -3,12 +3,15 @@ package usecase
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/internal/repositories"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
 )
 
 type unlockContext struct {
@@ -20,7 +23,9 @@ type unlockContext struct {
 	kitchenConfig     *entities.StageKitchenConfig
 	currentReward     *entities.KitchenPhaseCompletionRewards
 	userProgress      *entities.UserKitchenStageProgression
+	phaseProgress     *entities.UserKitchenPhaseProgression
 	userBalance       *entities.UserBalance
+	boost             entities.BoostModifier
 	foodOverrideLevel *entities.FoodItemOverrideLevel
 	currentStation    entities.UserStationLevel
 	nextStation       *entities.UserStationLevel
@@ -37,6 +42,7 @@ type upgradeContext struct {
 	userProgress    *entities.UserKitchenStageProgression
 	phaseProgress   *entities.UserKitchenPhaseProgression
 	userBalance     *entities.UserBalance
+	boost           entities.BoostModifier
 	previousStation entities.UserStationLevel
 	currentStation  entities.UserStationLevel
 	nextStation     *entities.UserStationLevel
@@ -65,6 +71,15 @@ type upgradeResult struct {
 	phaseTransitioned bool
 	newTableCount     int64
 	grantedRewards    []entities.PhaseRewardInfo
+	phaseTransitions  []entities.PhaseTransitionInfo
+}
+
+// upgradeStep is a single level of a bulk upgrade
+type upgradeStep struct {
+	previousStation entities.UserStationLevel
+	station         entities.UserStationLevel
+	nextStation     *entities.UserStationLevel
+	result          *upgradeResult
 }
 
 type phaseInfo struct {
@@ -121,6 +136,14 @@ func (g *gameUseCase) gatherUnlockData(ctx context.Context, userID int64, slug s
 		return nil, err
 	}
 
+	// Get user phase progress
+	if uctx.kitchenConfig != nil {
+		uctx.phaseProgress, err = g.userProgressionRepo.GetUserKitchenPhaseProgressionDB(ctx, userID, uctx.kitchenConfig.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Get user balance
 	uctx.userBalance, err = g.userUseCase.GetUserBalance(ctx, userID)
 	if err != nil {
@@ -130,6 +153,12 @@ func (g *gameUseCase) gatherUnlockData(ctx context.Context, userID int64, slug s
 		return nil, apperror.ErrRecordNotFound
 	}
 
+	// Get active boosts
+	uctx.boost, err = g.getBoostModifier(ctx, userID, helper.NowUTC())
+	if err != nil {
+		return nil, err
+	}
+
 	return uctx, nil
 }
 
@@ -189,6 +218,12 @@ func (g *gameUseCase) gatherUpgradeData(ctx context.Context, userID int64, slug
 		return nil, err
 	}
 
+	// Get active boosts
+	upgradeContext.boost, err = g.getBoostModifier(ctx, userID, helper.NowUTC())
+	if err != nil {
+		return nil, err
+	}
+
 	return upgradeContext, nil
 }
 
@@ -198,6 +233,16 @@ func (g *gameUseCase) validateUnlockRequirements(unlockContext *unlockContext) e
 		return apperror.ErrStationAlreadyUnlocked
 	}
 
+	// Check if the player has reached the phase the station unlocks at
+	var currentPhase int64 = 1
+	if unlockContext.phaseProgress != nil && unlockContext.phaseProgress.CurrentPhase > 0 {
+		currentPhase = unlockContext.phaseProgress.CurrentPhase
+	}
+
+	if unlockContext.kitchenStation.UnlockPhase > currentPhase {
+		return apperror.ErrStationPhaseLocked.WithDetails(fmt.Sprintf("requires phase %d, current phase %d", unlockContext.kitchenStation.UnlockPhase, currentPhase))
+	}
+
 	return nil
 }
 
@@ -243,7 +288,19 @@ func (g *gameUseCase) proceedOverrideLevel(ctx context.Context, upgradeCtx *upgr
 		return false, false, err
 	}
 
-	overrideCurrentLevel = false
+	// Check overrides for next level (level after the one we are upgrading to)
+	nextOverride, err := g.foodItemRepo.GetOverrideLevelByFoodItemIDAndLevelDB(ctx, upgradeCtx.foodItem.ID, int(upgradeCtx.nextStation.Level))
+	if err != nil {
+		return false, false, err
+	}
+
+	overrideCurrentLevel, overrideNextLevel = g.applyOverrideLevels(upgradeCtx, currentOverride, nextOverride)
+
+	return overrideCurrentLevel, overrideNextLevel, nil
+}
+
+// applyOverrideLevels replaces the calculated stats of the target and the following level with the designer overrides, if any
+func (g *gameUseCase) applyOverrideLevels(upgradeCtx *upgradeContext, currentOverride *entities.FoodItemOverrideLevel, nextOverride *entities.FoodItemOverrideLevel) (overrideCurrentLevel bool, overrideNextLevel bool) {
 	if currentOverride != nil {
 		upgradeCtx.currentStation = entities.UserStationLevel{
 			Level:           currentOverride.Level,
@@ -254,13 +311,6 @@ func (g *gameUseCase) proceedOverrideLevel(ctx context.Context, upgradeCtx *upgr
 		overrideCurrentLevel = true
 	}
 
-	// Check overrides for next level (level after the one we are upgrading to)
-	nextOverride, err := g.foodItemRepo.GetOverrideLevelByFoodItemIDAndLevelDB(ctx, upgradeCtx.foodItem.ID, int(upgradeCtx.nextStation.Level))
-	if err != nil {
-		return overrideCurrentLevel, false, err
-	}
-
-	overrideNextLevel = false
 	if nextOverride != nil {
 		upgradeCtx.nextStation = &entities.UserStationLevel{
 			Level:           nextOverride.Level,
@@ -271,7 +321,7 @@ func (g *gameUseCase) proceedOverrideLevel(ctx context.Context, upgradeCtx *upgr
 		overrideNextLevel = true
 	}
 
-	return overrideCurrentLevel, overrideNextLevel, nil
+	return overrideCurrentLevel, overrideNextLevel
 }
 
 func (g *gameUseCase) isStationUnlocked(unlockedStations []string, slug string) bool {
@@ -358,11 +408,7 @@ func (g *gameUseCase) calculateUpgradeMetrics(upgradeContext *upgradeContext, is
 }
 
 func (g *gameUseCase) getReduceCookingTimeMultiplier(progress *entities.UserKitchenStageProgression, slug string) float64 {
-	if progress == nil || progress.StationUpgrades == nil {
-		return 1.0
-	}
-
-	if upgrade, exists := progress.StationUpgrades[slug]; exists && upgrade.ReduceCookingTime > 0 {
+	if upgrade := progress.EffectiveStationUpgrade(slug); upgrade.ReduceCookingTime > 0 {
 		return upgrade.ReduceCookingTime
 	}
 
@@ -370,17 +416,42 @@ func (g *gameUseCase) getReduceCookingTimeMultiplier(progress *entities.UserKitc
 }
 
 func (g *gameUseCase) getProfitMultiplier(progress *entities.UserKitchenStageProgression, slug string) float64 {
-	if progress == nil || progress.StationUpgrades == nil {
-		return 0
-	}
-
-	if upgrade, exists := progress.StationUpgrades[slug]; exists && upgrade.ProfitBonus > 0 {
+	if upgrade := progress.EffectiveStationUpgrade(slug); upgrade.ProfitBonus > 0 {
 		return upgrade.ProfitBonus
 	}
 
 	return 0
 }
 
+// getBoostModifier combines the boosts of the player that are active at t
+func (g *gameUseCase) getBoostModifier(ctx context.Context, userID int64, t time.Time) (entities.BoostModifier, error) {
+	boosts, err := g.boostUseCase.GetUserBoostsBetween(ctx, userID, t, t.Add(time.Nanosecond))
+	if err != nil {
+		return entities.BoostModifier{}, err
+	}
+
+	return entities.NewBoostModifier(boosts, t), nil
+}
+
+// calculateBoostedProfit = profit * boostProfitMultiplier.
+// Boosts only apply while they are active, they are never saved into the station profit
+func (g *gameUseCase) calculateBoostedProfit(profit float64, boost entities.BoostModifier) float64 {
+	if boost.ProfitMultiplier > 0 {
+		return profit * boost.ProfitMultiplier
+	}
+
+	return profit
+}
+
+// calculateBoostedPreparationTime drops to minPreparationTime while instant cook is active
+func (g *gameUseCase) calculateBoostedPreparationTime(preparationTime float64, boost entities.BoostModifier) float64 {
+	if boost.InstantCook {
+		return minPreparationTime
+	}
+
+	return preparationTime
+}
+
 // calculateUpgradeCost: basePrice * (upgradeCostMultiply/100)^(level-1) * phaseMultiplier
 func (g *gameUseCase) calculateUpgradeCost(
 	basePrice int64,
@@ -505,7 +576,10 @@ func (g *gameUseCase) executeUnlockTransaction(ctx context.Context, unlockContex
 		result.nextProfit = unlockContext.nextStation.Profit
 
 		// Deduct coins
-		if err := userRepo.UpdateUserBalanceWithTx(ctx, unlockContext.userID, entities.BalanceTypeCoin, -result.unlockCost); err != nil {
+		if err := userRepo.UpdateUserBalanceWithTx(ctx, unlockContext.userID, entities.BalanceTypeCoin, -result.unlockCost, entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourceStationUnlock,
+			Ref:  unlockContext.slug,
+		}); err != nil {
 			return err
 		}
 
@@ -530,14 +604,102 @@ func (g *gameUseCase) executeUnlockTransaction(ctx context.Context, unlockContex
 	})
 }
 
-func (g *gameUseCase) executeUpgradeTransaction(ctx context.Context, upgradeContext *upgradeContext, result *upgradeResult) error {
-	return g.userProgressionRepo.WithUserProgressionTx(ctx, func(tx *sql.Tx) error {
+// priceNextUpgrade prices the upgrade to the level after the one stored in the player progression.
+// Both the real upgrade and the preview go through here, so their numbers can't drift apart
+func (g *gameUseCase) priceNextUpgrade(ctx context.Context, upgradeContext *upgradeContext) (*upgradeResult, error) {
+	// Validate upgrade requirements
+	if err := g.validateUpgradeRequirements(upgradeContext); err != nil {
+		return nil, err
+	}
+
+	// process override level if any
+	overrideCurrentLevel, overrideNextLevel, err := g.proceedOverrideLevel(ctx, upgradeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate upgrade metrics
+	return g.calculateUpgradeMetrics(upgradeContext, overrideCurrentLevel, overrideNextLevel), nil
+}
+
+// planUpgradeSteps prices the upgrades level by level, exactly like repeated single upgrades would.
+// A count beyond the max level is capped, and toMax stops at the first level the player can't afford
+func (g *gameUseCase) planUpgradeSteps(ctx context.Context, upgradeContext *upgradeContext, count int64, toMax bool) ([]upgradeStep, error) {
+	var steps []upgradeStep
+	balance := upgradeContext.userBalance.Coin
+
+	for toMax || int64(len(steps)) < count {
+		result, err := g.priceNextUpgrade(ctx, upgradeContext)
+		if err != nil {
+			if len(steps) > 0 && errors.Is(err, apperror.ErrMaxLevelReached) {
+				break
+			}
+			return nil, err
+		}
+
+		// Check sufficient funds
+		if upgradeContext.userBalance.Coin < result.upgradeCost {
+			if toMax && len(steps) > 0 {
+				break
+			}
+
+			return nil, apperror.ErrInsufficientCoins
+		}
+
+		steps = append(steps, upgradeStep{
+			previousStation: upgradeContext.previousStation,
+			station:         upgradeContext.currentStation,
+			nextStation:     upgradeContext.nextStation,
+			result:          result,
+		})
+
+		// The next level is priced from this one
+		upgradeContext.userProgress.StationLevels[upgradeContext.slug] = upgradeContext.currentStation
+		upgradeContext.userBalance.Coin = result.newCoinBalance
+	}
+
+	// Planning may have looked one level past the last planned upgrade, so restore the state of that upgrade
+	last := steps[len(steps)-1]
+	upgradeContext.userBalance.Coin = balance
+	upgradeContext.previousStation = last.previousStation
+	upgradeContext.currentStation = last.station
+	upgradeContext.nextStation = last.nextStation
+	upgradeContext.userProgress.StationLevels[upgradeContext.slug] = last.station
+
+	return steps, nil
+}
+
+func (g *gameUseCase) executeUpgradeTransaction(ctx context.Context, upgradeContext *upgradeContext, steps []upgradeStep) (*upgradeResult, error) {
+	first := steps[0]
+	last := steps[len(steps)-1]
+
+	// The summary keeps the values of the last level and adds up what was paid and granted on the way
+	summary := *last.result
+	summary.oldPhaseInfo = first.result.oldPhaseInfo
+	summary.upgradeCost = 0
+	summary.grantedRewards = nil
+	summary.phaseTransitioned = false
+	summary.newTableCount = 0
+	for _, step := range steps {
+		summary.upgradeCost += step.result.upgradeCost
+	}
+	summary.newCoinBalance = upgradeContext.userBalance.Coin - summary.upgradeCost
+
+	ref := fmt.Sprintf("%s:%d", upgradeContext.slug, last.station.Level)
+	if len(steps) > 1 {
+		ref = fmt.Sprintf("%s:%d-%d", upgradeContext.slug, first.station.Level, last.station.Level)
+	}
+
+	err := g.userProgressionRepo.WithUserProgressionTx(ctx, func(tx *sql.Tx) error {
 		userRepo := g.userRepo.WithTx(tx)
 		userProgressionRepo := g.userProgressionRepo.WithTx(tx)
 		kitchenConfigRepo := g.kitchenConfigRepo.WithTx(tx)
 
 		// Deduct coins
-		if err := userRepo.UpdateUserBalanceWithTx(ctx, upgradeContext.userID, entities.BalanceTypeCoin, -result.upgradeCost); err != nil {
+		if err := userRepo.UpdateUserBalanceWithTx(ctx, upgradeContext.userID, entities.BalanceTypeCoin, -summary.upgradeCost, entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourceStationUpgrade,
+			Ref:  ref,
+		}); err != nil {
 			return err
 		}
 
@@ -546,19 +708,37 @@ func (g *gameUseCase) executeUpgradeTransaction(ctx context.Context, upgradeCont
 			return err
 		}
 
-		// Handle phase transition
-		if result.phaseTransitioned {
-			if err := g.handlePhaseTransition(ctx, tx, upgradeContext, result); err != nil {
+		// Handle phase transitions
+		for _, step := range steps {
+			if !step.result.phaseTransitioned {
+				continue
+			}
+
+			if err := g.handlePhaseTransition(ctx, tx, upgradeContext, step.result); err != nil {
 				return err
 			}
+
+			upgradeContext.phaseProgress.CurrentPhase = step.result.currentPhaseInfo.CurrentPhase
+
+			summary.phaseTransitioned = true
+			summary.newTableCount = step.result.newTableCount
+			summary.grantedRewards = append(summary.grantedRewards, step.result.grantedRewards...)
+			summary.phaseTransitions = append(summary.phaseTransitions, entities.PhaseTransitionInfo{
+				Level:         step.station.Level,
+				FromPhase:     step.result.oldPhaseInfo.CurrentPhase,
+				ToPhase:       step.result.currentPhaseInfo.CurrentPhase,
+				NewTableCount: step.result.newTableCount,
+			})
 		}
 
 		// Handle max level rewards
 		if upgradeContext.currentStation.Level >= upgradeContext.kitchenConfig.MaxLevel {
-			if err := g.handleMaxLevelRewards(ctx, tx, upgradeContext, result); err != nil {
-				// Log but don't fail the transaction
-				fmt.Printf("Error collecting all phase rewards: %v\n", err)
+			// A reward that can not be granted fails the upgrade, so it is never dropped
+			if err := g.handleMaxLevelRewards(ctx, tx, upgradeContext, last.result); err != nil {
+				return err
 			}
+
+			summary.grantedRewards = append(summary.grantedRewards, last.result.grantedRewards...)
 		}
 
 		reward, err := g.fetchAndSetCurrentReward(ctx, kitchenConfigRepo, upgradeContext.kitchenConfig.ID, upgradeContext.phaseProgress.CompletedPhases)
@@ -570,6 +750,11 @@ func (g *gameUseCase) executeUpgradeTransaction(ctx context.Context, upgradeCont
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
 }
 
 func (g *gameUseCase) fetchAndSetCurrentReward(ctx context.Context, kitchenConfigRepo repositories.StageKitchenConfigRepository, kitchenConfigID int64, completedPhases []int64) (*entities.KitchenPhaseCompletionRewards, error) {
@@ -648,8 +833,6 @@ func (g *gameUseCase) handlePhaseTransition(ctx context.Context, tx *sql.Tx, upg
 		result.currentPhaseInfo.CurrentPhase,
 	)
 	if err != nil {
-		fmt.Printf("Error collecting phase rewards: %v\n", err)
-		result.grantedRewards = []entities.PhaseRewardInfo{}
 		return err
 	}
 
@@ -775,7 +958,6 @@ func (g *gameUseCase) collectPhaseCompletionRewards(
 				// Grant the reward
 				rewardInfo, err := g.grantPhaseReward(ctx, tx, userID, kitchenConfig.ID, &phaseReward)
 				if err != nil {
-					// Log error but continue with other rewards
 					return nil, err
 				}
 
@@ -796,7 +978,6 @@ func (g *gameUseCase) grantPhaseReward(
 	phaseReward *entities.KitchenPhaseCompletionRewards,
 ) (entities.PhaseRewardInfo, error) {
 	rewardRepo := g.rewardRepo.WithTx(tx)
-	userRepo := g.userRepo.WithTx(tx)
 	userProgression := g.userProgressionRepo.WithTx(tx)
 
 	// Get reward details
@@ -809,22 +990,14 @@ func (g *gameUseCase) grantPhaseReward(
 		return entities.PhaseRewardInfo{}, apperror.ErrRecordNotFound
 	}
 
-	rewardType := reward.RewardType
-	rewardTypeEnum, err := entities.ToRewardType(rewardType.Slug)
+	err = g.rewardGrantUseCase.GrantRewardWithTx(ctx, tx, userID, reward, entities.CurrencyLedgerSource{
+		Type: entities.CurrencySourcePhaseReward,
+		Ref:  fmt.Sprintf("kitchen_config:%d:phase:%d", kitchenConfigID, phaseReward.PhaseNumber),
+	})
 	if err != nil {
 		return entities.PhaseRewardInfo{}, err
 	}
 
-	if rewardTypeEnum.RequiresBalanceUpdate() {
-		balanceType := rewardTypeEnum.ToUserBalance()
-		err = userRepo.UpdateUserBalanceWithTx(ctx, userID, balanceType, reward.Amount)
-		if err != nil {
-			return entities.PhaseRewardInfo{}, err
-		}
-	} else if rewardTypeEnum.IsSentExternally() {
-		// TODO: Call External API to give GoPay Coin to player
-	}
-
 	// Record that reward was claimed
 	err = userProgression.CreateUserKitchenClaimRewardDB(
 		ctx,
@@ -841,10 +1014,11 @@ func (g *gameUseCase) grantPhaseReward(
 
 	return entities.PhaseRewardInfo{
 		PhaseNumber: int(phaseReward.PhaseNumber),
-		RewardType:  rewardTypeEnum.String(),
+		RewardType:  reward.RewardType.Slug,
 		RewardSlug:  reward.Slug,
 		RewardName:  reward.Name,
 		Amount:      reward.Amount,
+		Items:       reward.Items,
 	}, nil
 }
 
@@ -891,7 +1065,7 @@ func (g *gameUseCase) collectAllRemainingPhaseRewards(
 			// Check if already claimed
 			claimed, err := userProgression.IsPhaseRewardAlreadyClaimedDB(ctx, userID, kitchenConfig.ID, phaseReward.PhaseNumber, phaseReward.RewardID)
 			if err != nil {
-				continue
+				return nil, err
 			}
 
 			if claimed {
@@ -901,8 +1075,7 @@ func (g *gameUseCase) collectAllRemainingPhaseRewards(
 			// Grant the reward
 			rewardInfo, err := g.grantPhaseReward(ctx, tx, userID, kitchenConfig.ID, &phaseReward)
 			if err != nil {
-				fmt.Printf("Error granting reward: %v\n", err)
-				continue
+				return nil, err
 			}
 
 			rewardInfos = append(rewardInfos, rewardInfo)
@@ -917,10 +1090,11 @@ func (g *gameUseCase) buildUnlockResponse(unlockContext *unlockContext, result *
 		Name: unlockContext.foodItem.Name,
 		Slug: unlockContext.foodItem.Slug,
 
-		CurrentLevel:      unlockContext.currentStation.Level,
-		CurrentProfit:     result.currentProfit,
-		CurrentPrepTime:   unlockContext.currentStation.PreparationTime,
-		ProfitPerSecond:   float64(result.currentProfit) / unlockContext.currentStation.PreparationTime,
+		CurrentLevel:    unlockContext.currentStation.Level,
+		CurrentProfit:   result.currentProfit,
+		CurrentPrepTime: unlockContext.currentStation.PreparationTime,
+		ProfitPerSecond: g.calculateBoostedProfit(float64(result.currentProfit), unlockContext.boost) /
+			g.calculateBoostedPreparationTime(unlockContext.currentStation.PreparationTime, unlockContext.boost),
 		CurrentTableCount: 0,
 		CurrentRewards: &entities.PhaseRewardInfo{
 			RewardType: unlockContext.currentReward.Reward.RewardType.Slug,
@@ -958,7 +1132,8 @@ func (g *gameUseCase) buildUpgradeResponse(upgradeContext *upgradeContext, resul
 		CurrentLevel:    upgradeContext.currentStation.Level,
 		CurrentProfit:   result.currentProfit,
 		CurrentPrepTime: result.preparationTime,
-		ProfitPerSecond: float64(result.currentProfit) / result.preparationTime,
+		ProfitPerSecond: g.calculateBoostedProfit(float64(result.currentProfit), upgradeContext.boost) /
+			g.calculateBoostedPreparationTime(result.preparationTime, upgradeContext.boost),
 
 		NextLevel:  upgradeContext.nextStation.Level,
 		NextCost:   result.nextUpgradeCost,
@@ -982,3 +1157,43 @@ func (g *gameUseCase) buildUpgradeResponse(upgradeContext *upgradeContext, resul
 		GrantedRewards: result.grantedRewards,
 	}
 }
+
+// previewUpgradeLevels walks the same upgrade path as planUpgradeSteps without checking the balance or saving anything
+func (g *gameUseCase) previewUpgradeLevels(ctx context.Context, upgradeContext *upgradeContext, fromLevel int64, toLevel int64) ([]entities.UpgradePreviewLevel, error) {
+	var levels []entities.UpgradePreviewLevel
+	var cumulativeCost int64
+
+	for upgradeContext.userProgress.StationLevels[upgradeContext.slug].Level < toLevel {
+		result, err := g.priceNextUpgrade(ctx, upgradeContext)
+		if err != nil {
+			if errors.Is(err, apperror.ErrMaxLevelReached) {
+				break
+			}
+			return nil, err
+		}
+
+		cumulativeCost += result.upgradeCost
+
+		if upgradeContext.currentStation.Level >= fromLevel {
+			var tableCount int64
+			if int(result.currentPhaseInfo.CurrentPhase) <= len(upgradeContext.kitchenConfig.TableCountPerPhases) {
+				tableCount = upgradeContext.kitchenConfig.TableCountPerPhases[result.currentPhaseInfo.CurrentPhase-1]
+			}
+
+			levels = append(levels, entities.UpgradePreviewLevel{
+				Level:           upgradeContext.currentStation.Level,
+				Cost:            result.upgradeCost,
+				CumulativeCost:  cumulativeCost,
+				Profit:          result.currentProfit,
+				PreparationTime: result.preparationTime,
+				Phase:           result.currentPhaseInfo.CurrentPhase,
+				TableCount:      tableCount,
+			})
+		}
+
+		// The next level is priced from this one
+		upgradeContext.userProgress.StationLevels[upgradeContext.slug] = upgradeContext.currentStation
+	}
+
+	return levels, nil
+}
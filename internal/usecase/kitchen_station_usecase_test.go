@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+const testStationSlug = "latte"
+
+// fakeFoodItemRepository only serves the designer override levels
+type fakeFoodItemRepository struct {
+	repositories.FoodItemRepository
+
+	overrides map[int64]*entities.FoodItemOverrideLevel
+}
+
+func (r *fakeFoodItemRepository) GetOverrideLevelByFoodItemIDAndLevelDB(ctx context.Context, foodItemID int64, level int) (*entities.FoodItemOverrideLevel, error) {
+	return r.overrides[int64(level)], nil
+}
+
+func newTestGameUseCase(overrides ...entities.FoodItemOverrideLevel) *gameUseCase {
+	repo := &fakeFoodItemRepository{overrides: map[int64]*entities.FoodItemOverrideLevel{}}
+	for i := range overrides {
+		repo.overrides[overrides[i].Level] = &overrides[i]
+	}
+
+	return &gameUseCase{foodItemRepo: repo}
+}
+
+// newTestUpgradeContext puts the station at level with a base cost of 10 and a base profit of 5.
+//
+// Levels 1-3 are phase 1, levels 4-6 are phase 2 and levels 7-10 are phase 3, so the cost to
+// reach level L is 10 * 2^(L-2) * phase cost multiplier
+func newTestUpgradeContext(level int64, coin int64) *upgradeContext {
+	return &upgradeContext{
+		slug:     testStationSlug,
+		foodItem: &entities.FoodItem{ID: 1, Slug: testStationSlug},
+		kitchenConfig: &entities.StageKitchenConfig{
+			MaxLevel:                    10,
+			UpgradeCostMultiply:         200,
+			UpgradeProfitMultiply:       150,
+			TransitionPhaseLevels:       []int64{0, 3, 6},
+			PhaseProfitMultipliers:      []float64{1, 1.5, 2},
+			PhaseUpgradeCostMultipliers: []float64{1, 2, 3},
+			TableCountPerPhases:         []int64{2, 4, 6},
+		},
+		userProgress: &entities.UserKitchenStageProgression{
+			UnlockedStations: []string{testStationSlug},
+			StationLevels: map[string]entities.UserStationLevel{
+				testStationSlug: {Level: level, Cost: 10, Profit: 5, PreparationTime: 4},
+			},
+		},
+		userBalance: &entities.UserBalance{Coin: coin},
+	}
+}
+
+// testLevelCost is the cost to reach the level without overrides
+var testLevelCost = map[int64]int64{2: 10, 3: 20, 4: 80, 5: 160, 6: 320, 7: 960, 8: 1920, 9: 3840, 10: 7680}
+
+func TestPriceNextUpgrade(t *testing.T) {
+	override := entities.FoodItemOverrideLevel{Level: 5, Cost: 100, Profit: 40, PreparationTime: 3}
+
+	tests := []struct {
+		name      string
+		level     int64
+		overrides []entities.FoodItemOverrideLevel
+		locked    bool
+
+		wantErr          error
+		wantLevel        int64
+		wantCost         int64
+		wantNextCost     int64
+		wantPhase        int64
+		wantTransitioned bool
+	}{
+		{
+			name:         "first upgrade",
+			level:        1,
+			wantLevel:    2,
+			wantCost:     10,
+			wantNextCost: 20,
+			wantPhase:    1,
+		},
+		{
+			name:             "into the next phase",
+			level:            3,
+			wantLevel:        4,
+			wantCost:         80,
+			wantNextCost:     160,
+			wantPhase:        2,
+			wantTransitioned: true,
+		},
+		{
+			name:             "override on the next level",
+			level:            3,
+			overrides:        []entities.FoodItemOverrideLevel{override},
+			wantLevel:        4,
+			wantCost:         80,
+			wantNextCost:     100,
+			wantPhase:        2,
+			wantTransitioned: true,
+		},
+		{
+			name:         "override on the target level",
+			level:        4,
+			overrides:    []entities.FoodItemOverrideLevel{override},
+			wantLevel:    5,
+			wantCost:     100,
+			wantNextCost: 3200,
+			wantPhase:    2,
+		},
+		{
+			name:    "max level",
+			level:   10,
+			wantErr: apperror.ErrMaxLevelReached,
+		},
+		{
+			name:    "locked station",
+			level:   1,
+			locked:  true,
+			wantErr: apperror.ErrStationLocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upgradeCtx := newTestUpgradeContext(tt.level, 0)
+			if tt.locked {
+				upgradeCtx.userProgress.UnlockedStations = nil
+			}
+
+			result, err := newTestGameUseCase(tt.overrides...).priceNextUpgrade(context.Background(), upgradeCtx)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("priceNextUpgrade() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("priceNextUpgrade() error = %v", err)
+			}
+
+			if upgradeCtx.currentStation.Level != tt.wantLevel {
+				t.Errorf("level = %d, want %d", upgradeCtx.currentStation.Level, tt.wantLevel)
+			}
+
+			if result.upgradeCost != tt.wantCost || result.nextUpgradeCost != tt.wantNextCost {
+				t.Errorf("cost = %d next cost = %d, want %d and %d", result.upgradeCost, result.nextUpgradeCost, tt.wantCost, tt.wantNextCost)
+			}
+
+			if result.currentPhaseInfo.CurrentPhase != tt.wantPhase || result.phaseTransitioned != tt.wantTransitioned {
+				t.Errorf("phase = %d transitioned = %v, want %d and %v", result.currentPhaseInfo.CurrentPhase, result.phaseTransitioned, tt.wantPhase, tt.wantTransitioned)
+			}
+
+			if result.newCoinBalance != -tt.wantCost {
+				t.Errorf("new coin balance = %d, want %d", result.newCoinBalance, -tt.wantCost)
+			}
+		})
+	}
+}
+
+func TestPlanUpgradeSteps(t *testing.T) {
+	override := entities.FoodItemOverrideLevel{Level: 5, Cost: 100, Profit: 40, PreparationTime: 3}
+
+	tests := []struct {
+		name      string
+		level     int64
+		coin      int64
+		count     int64
+		toMax     bool
+		overrides []entities.FoodItemOverrideLevel
+
+		wantErr    error
+		wantLevels []int64
+		wantCosts  []int64
+	}{
+		{
+			name:       "single upgrade",
+			level:      1,
+			coin:       10,
+			count:      1,
+			wantLevels: []int64{2},
+			wantCosts:  []int64{10},
+		},
+		{
+			name:       "count past the max level is capped",
+			level:      7,
+			coin:       1_000_000,
+			count:      10,
+			wantLevels: []int64{8, 9, 10},
+			wantCosts:  []int64{1920, 3840, 7680},
+		},
+		{
+			name:    "count at the max level",
+			level:   10,
+			coin:    1_000_000,
+			count:   5,
+			wantErr: apperror.ErrMaxLevelReached,
+		},
+		{
+			name:    "count the player can't afford",
+			level:   1,
+			coin:    29,
+			count:   2,
+			wantErr: apperror.ErrInsufficientCoins,
+		},
+		{
+			name:       "to max stops at the first level the player can't afford",
+			level:      1,
+			coin:       10 + 20 + 80 + 159,
+			toMax:      true,
+			wantLevels: []int64{2, 3, 4},
+			wantCosts:  []int64{10, 20, 80},
+		},
+		{
+			name:       "to max with enough coins reaches the max level",
+			level:      8,
+			coin:       3840 + 7680,
+			toMax:      true,
+			wantLevels: []int64{9, 10},
+			wantCosts:  []int64{3840, 7680},
+		},
+		{
+			name:    "to max without coins for a single level",
+			level:   1,
+			coin:    9,
+			toMax:   true,
+			wantErr: apperror.ErrInsufficientCoins,
+		},
+		{
+			name:       "override level in the middle of the range",
+			level:      3,
+			coin:       1_000_000,
+			count:      3,
+			overrides:  []entities.FoodItemOverrideLevel{override},
+			wantLevels: []int64{4, 5, 6},
+			wantCosts:  []int64{80, 100, 3200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upgradeCtx := newTestUpgradeContext(tt.level, tt.coin)
+
+			steps, err := newTestGameUseCase(tt.overrides...).planUpgradeSteps(context.Background(), upgradeCtx, tt.count, tt.toMax)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("planUpgradeSteps() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("planUpgradeSteps() error = %v", err)
+			}
+
+			if len(steps) != len(tt.wantLevels) {
+				t.Fatalf("planUpgradeSteps() planned %d steps, want %d", len(steps), len(tt.wantLevels))
+			}
+
+			var totalCost int64
+			for i, step := range steps {
+				if step.station.Level != tt.wantLevels[i] || step.result.upgradeCost != tt.wantCosts[i] {
+					t.Errorf("step %d = level %d cost %d, want level %d cost %d", i, step.station.Level, step.result.upgradeCost, tt.wantLevels[i], tt.wantCosts[i])
+				}
+				totalCost += step.result.upgradeCost
+			}
+
+			if totalCost > tt.coin {
+				t.Errorf("planned %d coins with a balance of %d", totalCost, tt.coin)
+			}
+
+			// The context is left on the last planned upgrade with the balance untouched
+			last := steps[len(steps)-1]
+			if upgradeCtx.currentStation != last.station || upgradeCtx.userProgress.StationLevels[testStationSlug] != last.station {
+				t.Errorf("context station = %+v, want %+v", upgradeCtx.currentStation, last.station)
+			}
+
+			if upgradeCtx.userBalance.Coin != tt.coin {
+				t.Errorf("context coin = %d, want %d", upgradeCtx.userBalance.Coin, tt.coin)
+			}
+		})
+	}
+}
+
+func TestPreviewUpgradeLevelsMatchesPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     int64
+		toLevel   int64
+		overrides []entities.FoodItemOverrideLevel
+	}{
+		{name: "across two phase transitions", level: 2, toLevel: 9},
+		{name: "up to the max level", level: 1, toLevel: 10},
+		{
+			name:      "with an override level",
+			level:     2,
+			toLevel:   9,
+			overrides: []entities.FoodItemOverrideLevel{{Level: 5, Cost: 100, Profit: 40, PreparationTime: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGameUseCase(tt.overrides...)
+			ctx := context.Background()
+
+			steps, err := g.planUpgradeSteps(ctx, newTestUpgradeContext(tt.level, 1_000_000), tt.toLevel-tt.level, false)
+			if err != nil {
+				t.Fatalf("planUpgradeSteps() error = %v", err)
+			}
+
+			levels, err := g.previewUpgradeLevels(ctx, newTestUpgradeContext(tt.level, 0), tt.level+1, tt.toLevel)
+			if err != nil {
+				t.Fatalf("previewUpgradeLevels() error = %v", err)
+			}
+
+			if len(levels) != len(steps) {
+				t.Fatalf("preview has %d levels, plan has %d steps", len(levels), len(steps))
+			}
+
+			var cumulativeCost int64
+			phases := map[int64]bool{}
+			for i, level := range levels {
+				step := steps[i]
+				cumulativeCost += step.result.upgradeCost
+				phases[level.Phase] = true
+
+				if level.Level != step.station.Level ||
+					level.Cost != step.result.upgradeCost ||
+					level.Profit != step.result.currentProfit ||
+					level.PreparationTime != step.result.preparationTime ||
+					level.Phase != step.result.currentPhaseInfo.CurrentPhase {
+					t.Errorf("preview level %+v does not match planned step %+v", level, *step.result)
+				}
+
+				if level.CumulativeCost != cumulativeCost {
+					t.Errorf("level %d cumulative cost = %d, want %d", level.Level, level.CumulativeCost, cumulativeCost)
+				}
+
+				if want, ok := testLevelCost[level.Level]; ok && len(tt.overrides) == 0 && level.Cost != want {
+					t.Errorf("level %d cost = %d, want %d", level.Level, level.Cost, want)
+				}
+			}
+
+			if len(phases) != 3 {
+				t.Errorf("preview crossed phases %v, want 3 phases", phases)
+			}
+		})
+	}
+}
+
+func TestPreviewUpgradeLevelsFromLevel(t *testing.T) {
+	levels, err := newTestGameUseCase().previewUpgradeLevels(context.Background(), newTestUpgradeContext(1, 0), 4, 6)
+	if err != nil {
+		t.Fatalf("previewUpgradeLevels() error = %v", err)
+	}
+
+	if len(levels) != 3 || levels[0].Level != 4 || levels[2].Level != 6 {
+		t.Fatalf("previewUpgradeLevels() = %+v, want levels 4 to 6", levels)
+	}
+
+	// Levels before fromLevel still count towards the cumulative cost
+	if want := int64(10 + 20 + 80); levels[0].CumulativeCost != want {
+		t.Errorf("level 4 cumulative cost = %d, want %d", levels[0].CumulativeCost, want)
+	}
+
+	if levels[0].TableCount != 4 {
+		t.Errorf("level 4 table count = %d, want 4", levels[0].TableCount)
+	}
+}
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+type MailUseCase interface {
+	SendMail(ctx context.Context, data entities.Mail, rewardSlugs []string) (res *entities.Mail, err error)
+	GetMails(ctx context.Context, limit, offset int) (res []entities.Mail, totalRows int64, err error)
+	GetMailByID(ctx context.Context, id int64) (res *entities.Mail, err error)
+
+	GetUserMails(ctx context.Context, limit, offset int) (res []entities.UserMail, totalRows int64, unread int64, err error)
+	ReadUserMail(ctx context.Context, id int64) (res *entities.UserMail, err error)
+	ClaimUserMail(ctx context.Context, id int64) (res *entities.UserMail, newBalance *entities.UserBalance, err error)
+}
+
+type mailUseCase struct {
+	userUseCase        UserUseCase
+	rewardUseCase      RewardUseCase
+	rewardGrantUseCase RewardGrantUseCase
+	mailRepo           repositories.MailRepository
+	userRepo           repositories.UserRepository
+}
+
+func NewMailUseCase(
+	mailRepo repositories.MailRepository,
+	userRepo repositories.UserRepository,
+	userUseCase UserUseCase,
+	rewardUseCase RewardUseCase,
+	rewardGrantUseCase RewardGrantUseCase,
+) MailUseCase {
+	return &mailUseCase{
+		userUseCase:        userUseCase,
+		rewardUseCase:      rewardUseCase,
+		rewardGrantUseCase: rewardGrantUseCase,
+		mailRepo:           mailRepo,
+		userRepo:           userRepo,
+	}
+}
+
+// SendMail the admin is read from the context. A mail to everyone is saved once and shows up in
+// every mailbox when it is read, only a mail to a list of users is written per recipient
+func (m *mailUseCase) SendMail(ctx context.Context, data entities.Mail, rewardSlugs []string) (*entities.Mail, error) {
+	adminUserID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !data.Audience.IsValid() {
+		return nil, apperror.ErrorInvalidRequest("audience:", data.Audience.String())
+	}
+
+	data.Title = strings.TrimSpace(data.Title)
+	if data.Title == "" {
+		return nil, apperror.ErrorInvalidRequest("title is required")
+	}
+
+	if data.ExpiresAt != nil && !data.ExpiresAt.After(helper.NowUTC()) {
+		return nil, apperror.ErrorInvalidRequest("expires_at must be in the future")
+	}
+
+	if data.Audience == entities.MailAudienceUsers && len(data.UserIDs) == 0 {
+		return nil, apperror.ErrorInvalidRequest("user_ids are required when sending to users")
+	}
+
+	rewardIDs := make([]int64, 0, len(rewardSlugs))
+	data.Attachments = make([]entities.Reward, 0, len(rewardSlugs))
+	for _, slug := range rewardSlugs {
+		reward, err := m.rewardUseCase.GetRewardBySlug(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+
+		if !reward.IsActive {
+			return nil, apperror.ErrorInvalidRequest("reward is not active:", slug)
+		}
+
+		rewardIDs = append(rewardIDs, reward.ID)
+		data.Attachments = append(data.Attachments, *reward)
+	}
+
+	data.CreatedBy = &adminUserID
+
+	var id int64
+	err = m.mailRepo.MailWithTx(ctx, func(tx *sql.Tx) error {
+		mailRepoTx := m.mailRepo.WithTx(tx)
+
+		id, err = mailRepoTx.CreateMailDB(ctx, data)
+		if err != nil {
+			return err
+		}
+
+		err = mailRepoTx.CreateMailAttachmentsDB(ctx, id, rewardIDs)
+		if err != nil {
+			return err
+		}
+
+		if data.Audience != entities.MailAudienceUsers {
+			return nil
+		}
+
+		recipients, err := mailRepoTx.CreateUserMailsDB(ctx, id, data.UserIDs)
+		if err != nil {
+			return err
+		}
+
+		if recipients == 0 {
+			return apperror.ErrorInvalidRequest("none of the user_ids is a user")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.GetMailByID(ctx, id)
+}
+
+func (m *mailUseCase) GetMails(ctx context.Context, limit, offset int) (res []entities.Mail, totalRows int64, err error) {
+	res, err = m.mailRepo.GetMailsDB(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mailIDs := make([]int64, 0, len(res))
+	for _, mail := range res {
+		mailIDs = append(mailIDs, mail.ID)
+	}
+
+	attachments, err := m.getAttachments(ctx, mailIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range res {
+		res[i].Attachments = attachments[res[i].ID]
+	}
+
+	totalRows, err = m.mailRepo.CountMailsDB(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, totalRows, nil
+}
+
+func (m *mailUseCase) GetMailByID(ctx context.Context, id int64) (res *entities.Mail, err error) {
+	res, err = m.mailRepo.GetMailByIDDB(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := m.getAttachments(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+
+	res.Attachments = attachments[id]
+
+	return res, nil
+}
+
+// GetUserMails gets the mailbox of the player, newest first, with the number of unread mails
+func (m *mailUseCase) GetUserMails(ctx context.Context, limit, offset int) (res []entities.UserMail, totalRows int64, unread int64, err error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	now := helper.NowUTC()
+	res, err = m.mailRepo.GetUserMailsDB(ctx, userID, now, limit, offset)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	mailIDs := make([]int64, 0, len(res))
+	for _, mail := range res {
+		mailIDs = append(mailIDs, mail.ID)
+	}
+
+	attachments, err := m.getAttachments(ctx, mailIDs)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	for i := range res {
+		res[i].Attachments = attachments[res[i].ID]
+	}
+
+	totalRows, unread, err = m.mailRepo.CountUserMailsDB(ctx, userID, now)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return res, totalRows, unread, nil
+}
+
+// ReadUserMail gets a mail of the player and marks it as read
+func (m *mailUseCase) ReadUserMail(ctx context.Context, id int64) (res *entities.UserMail, err error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := helper.NowUTC()
+	res, err = m.mailRepo.GetUserMailByIDDB(ctx, userID, id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.ReadAt == nil {
+		err = m.mailRepo.MarkUserMailReadDB(ctx, userID, id, now)
+		if err != nil {
+			return nil, err
+		}
+
+		res.ReadAt = &now
+	}
+
+	attachments, err := m.getAttachments(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+
+	res.Attachments = attachments[id]
+
+	return res, nil
+}
+
+// ClaimUserMail grants every reward attached to the mail at once, a mail can be claimed once and
+// not after it expired
+func (m *mailUseCase) ClaimUserMail(ctx context.Context, id int64) (res *entities.UserMail, newBalance *entities.UserBalance, err error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := helper.NowUTC()
+	err = m.userRepo.BalanceWithTx(ctx, func(tx *sql.Tx) error {
+		mailRepoTx := m.mailRepo.WithTx(tx)
+
+		// Lock the user row, claims of the same player are applied one after another
+		user, err := m.userRepo.WithTx(tx).GetUserByIDForUpdateDB(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			return apperror.ErrUserNotFound
+		}
+
+		res, err = mailRepoTx.GetUserMailByIDDB(ctx, userID, id, now)
+		if err != nil {
+			return err
+		}
+
+		if res.ClaimedAt != nil {
+			return apperror.ErrMailAlreadyClaimed
+		}
+
+		attachments, err := mailRepoTx.GetMailAttachmentsDB(ctx, []int64{id})
+		if err != nil {
+			return err
+		}
+
+		res.Attachments = attachments[id]
+		if len(res.Attachments) == 0 {
+			return apperror.ErrInvalidState.WithDetails("mail has nothing to claim")
+		}
+
+		err = mailRepoTx.ClaimUserMailDB(ctx, userID, id, now)
+		if errors.Is(err, apperror.ErrNoUpdateRecord) {
+			return apperror.ErrMailAlreadyClaimed
+		} else if err != nil {
+			return err
+		}
+
+		for i := range res.Attachments {
+			err = m.rewardGrantUseCase.GrantRewardWithTx(ctx, tx, userID, &res.Attachments[i], entities.CurrencyLedgerSource{
+				Type: entities.CurrencySourceMail,
+				Ref:  fmt.Sprintf("mail:%d", id),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if res.ReadAt == nil {
+			res.ReadAt = &now
+		}
+		res.ClaimedAt = &now
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_ = m.userRepo.DeleteUserRedis(ctx, userID)
+
+	newBalance, err = m.userUseCase.GetUserBalance(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, newBalance, nil
+}
+
+// getAttachments gets the rewards attached to the mails with the items of the bundles
+func (m *mailUseCase) getAttachments(ctx context.Context, mailIDs []int64) (res map[int64][]entities.Reward, err error) {
+	res, err = m.mailRepo.GetMailAttachmentsDB(ctx, mailIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rewards := range res {
+		for i := range rewards {
+			if err := m.rewardUseCase.LoadBundleItems(ctx, &rewards[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return res, nil
+}
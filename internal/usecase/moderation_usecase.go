@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/jwt"
+)
+
+type ModerationUseCase interface {
+	SuspendUser(ctx context.Context, userID int64, data entities.UserSuspension) (res *entities.UserSuspension, err error)
+	LiftSuspension(ctx context.Context, userID int64) (err error)
+	GetUserSuspensions(ctx context.Context, userID int64) (res []entities.UserSuspension, err error)
+
+	// CheckUserAccess rejects disabled accounts and players with an active suspension or ban
+	CheckUserAccess(ctx context.Context, user *entities.User) (err error)
+}
+
+type moderationUseCase struct {
+	moderationRepo repositories.ModerationRepository
+	sessionRepo    repositories.SessionRepository
+	userRepo       repositories.UserRepository
+	jwt_           *jwt.JWT
+}
+
+func NewModerationUseCase(
+	moderationRepo repositories.ModerationRepository,
+	sessionRepo repositories.SessionRepository,
+	userRepo repositories.UserRepository,
+	jwt_ *jwt.JWT,
+) ModerationUseCase {
+	return &moderationUseCase{
+		moderationRepo: moderationRepo,
+		sessionRepo:    sessionRepo,
+		userRepo:       userRepo,
+		jwt_:           jwt_,
+	}
+}
+
+// SuspendUser the admin is read from the context, every session of the player is revoked at once
+func (m *moderationUseCase) SuspendUser(ctx context.Context, userID int64, data entities.UserSuspension) (*entities.UserSuspension, error) {
+	adminUserID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !data.Type.IsValid() {
+		return nil, apperror.ErrorInvalidRequest("suspension type:", data.Type.String())
+	}
+
+	data.Reason = strings.TrimSpace(data.Reason)
+	if data.Reason == "" {
+		return nil, apperror.ErrorInvalidRequest("reason is required")
+	}
+
+	now := helper.NowUTC()
+	if data.ExpiresAt != nil && !data.ExpiresAt.After(now) {
+		return nil, apperror.ErrorInvalidRequest("expires_at must be in the future")
+	}
+
+	user, err := m.userRepo.GetUserByIDDB(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	data.UserID = userID
+	data.CreatedBy = &adminUserID
+	data.CreatedAt = now
+
+	var revokedSessionIDs []int64
+	err = m.moderationRepo.ModerationWithTx(ctx, func(tx *sql.Tx) error {
+		id, err := m.moderationRepo.WithTx(tx).CreateSuspensionDB(ctx, &data)
+		if err != nil {
+			return err
+		}
+
+		if id == nil {
+			return apperror.ErrFailedRetrieveID
+		}
+
+		data.ID = *id
+
+		revokedSessionIDs, err = m.sessionRepo.WithTx(tx).RevokeSessionsByUserIDDB(ctx, userID, entities.SessionRevokedBySuspension)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sessionID := range revokedSessionIDs {
+		_ = m.sessionRepo.SetSessionRevokedRedis(ctx, sessionID, m.jwt_.GetTokenDuration())
+	}
+
+	_ = m.userRepo.DeleteUserRedis(ctx, userID)
+
+	return &data, nil
+}
+
+func (m *moderationUseCase) LiftSuspension(ctx context.Context, userID int64) error {
+	adminUserID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	lifted, err := m.moderationRepo.LiftSuspensionsByUserIDDB(ctx, userID, adminUserID)
+	if err != nil {
+		return err
+	}
+
+	if lifted == 0 {
+		return apperror.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (m *moderationUseCase) GetUserSuspensions(ctx context.Context, userID int64) ([]entities.UserSuspension, error) {
+	user, err := m.userRepo.GetUserByIDDB(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	return m.moderationRepo.GetSuspensionsByUserIDDB(ctx, userID)
+}
+
+func (m *moderationUseCase) CheckUserAccess(ctx context.Context, user *entities.User) error {
+	if !user.IsActive {
+		return apperror.ErrAccountDisabled
+	}
+
+	suspension, err := m.moderationRepo.GetActiveSuspensionByUserIDDB(ctx, user.ID, helper.NowUTC())
+	if err != nil {
+		return err
+	}
+
+	if suspension != nil {
+		return suspension.Err()
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+	"github.com/winartodev/cat-cafe/pkg/payout"
+)
+
+const (
+	// payoutLease is how long a claimed payout is hidden from other workers while it is being sent
+	payoutLease = 5 * time.Minute
+
+	// payoutMaxAttempts is how many times a payout is sent before it is moved to dead
+	payoutMaxAttempts = 8
+
+	// payoutBaseBackoff doubles after every failed attempt, up to payoutMaxBackoff
+	payoutBaseBackoff = 30 * time.Second
+	payoutMaxBackoff  = time.Hour
+
+	defaultPayoutBatchSize = 50
+)
+
+type PayoutUseCase interface {
+	CreatePayoutWithTx(ctx context.Context, tx *sql.Tx, userID int64, reward *entities.Reward, source entities.CurrencyLedgerSource) (res *entities.Payout, err error)
+	ProcessDuePayouts(ctx context.Context, limit int) (res *entities.PayoutRun, err error)
+
+	GetPayouts(ctx context.Context, status entities.PayoutStatus, limit, offset int) (res []entities.Payout, totalRows int64, err error)
+	GetPayoutByID(ctx context.Context, id int64) (res *entities.Payout, err error)
+	RetryPayout(ctx context.Context, id int64) (res *entities.Payout, err error)
+}
+
+type payoutUseCase struct {
+	payoutRepo repositories.PayoutRepository
+	provider   payout.Provider
+}
+
+// NewPayoutUseCase creates the payout outbox, provider is only needed to process the payouts
+func NewPayoutUseCase(payoutRepo repositories.PayoutRepository, provider payout.Provider) PayoutUseCase {
+	return &payoutUseCase{
+		payoutRepo: payoutRepo,
+		provider:   provider,
+	}
+}
+
+// CreatePayoutWithTx queues an external reward in the transaction of the claim,
+// so the payout exists if and only if the claim was saved
+func (p *payoutUseCase) CreatePayoutWithTx(ctx context.Context, tx *sql.Tx, userID int64, reward *entities.Reward, source entities.CurrencyLedgerSource) (res *entities.Payout, err error) {
+	if reward.Amount <= 0 {
+		return nil, apperror.ErrInvalidState.WithDetails("payout amount must be greater than 0")
+	}
+
+	currency := entities.RewardTypeGoPayCoin.String()
+	if reward.RewardType != nil {
+		currency = reward.RewardType.Slug
+	}
+
+	data := entities.Payout{
+		UserID:     userID,
+		RewardID:   reward.ID,
+		RewardSlug: reward.Slug,
+		Currency:   currency,
+		Amount:     reward.Amount,
+		Source:     source,
+		Status:     entities.PayoutStatusPending,
+	}
+
+	data.ID, err = p.payoutRepo.WithTx(tx).CreatePayoutDB(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// ProcessDuePayouts sends up to limit payouts that are due to the provider.
+//
+// Failed payouts are retried with an exponential backoff, rejected payouts and payouts that
+// ran out of attempts are moved to dead. Every attempt carries the same idempotency key
+func (p *payoutUseCase) ProcessDuePayouts(ctx context.Context, limit int) (res *entities.PayoutRun, err error) {
+	if p.provider == nil {
+		return nil, errors.New("payout: no provider configured")
+	}
+
+	if limit <= 0 {
+		limit = defaultPayoutBatchSize
+	}
+
+	now := helper.NowUTC()
+	payouts, err := p.payoutRepo.ClaimDuePayoutsDB(ctx, now, now.Add(payoutLease), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &entities.PayoutRun{Claimed: len(payouts)}
+	for _, data := range payouts {
+		p.sendPayout(ctx, &data)
+
+		err = p.payoutRepo.UpdatePayoutAttemptDB(ctx, data)
+		if errors.Is(err, apperror.ErrNoUpdateRecord) {
+			// The lease ran out and another worker claimed the payout, its outcome wins
+			continue
+		} else if err != nil {
+			return res, err
+		}
+
+		switch data.Status {
+		case entities.PayoutStatusSucceeded:
+			res.Succeeded++
+		case entities.PayoutStatusDead:
+			res.Dead++
+		default:
+			res.Retrying++
+		}
+	}
+
+	return res, nil
+}
+
+// sendPayout calls the provider and sets the outcome of the attempt on data
+func (p *payoutUseCase) sendPayout(ctx context.Context, data *entities.Payout) {
+	result, err := p.provider.Send(ctx, payout.Request{
+		IdempotencyKey: data.IdempotencyKey(),
+		UserID:         data.UserID,
+		Currency:       data.Currency,
+		Amount:         data.Amount,
+	})
+
+	now := helper.NowUTC()
+	if err == nil {
+		data.Status = entities.PayoutStatusSucceeded
+		data.LastError = ""
+		data.ProviderRef = result.Reference
+		data.CompletedAt = &now
+		return
+	}
+
+	data.LastError = err.Error()
+	if errors.Is(err, payout.ErrRejected) || data.Attempts >= payoutMaxAttempts {
+		data.Status = entities.PayoutStatusDead
+		return
+	}
+
+	data.NextAttemptAt = now.Add(p.calculatePayoutBackoff(data.Attempts))
+}
+
+// calculatePayoutBackoff = payoutBaseBackoff * 2^(attempts-1), capped at payoutMaxBackoff
+func (p *payoutUseCase) calculatePayoutBackoff(attempts int64) time.Duration {
+	backoff := payoutBaseBackoff
+	for i := int64(1); i < attempts && backoff < payoutMaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, payoutMaxBackoff)
+}
+
+func (p *payoutUseCase) GetPayouts(ctx context.Context, status entities.PayoutStatus, limit, offset int) (res []entities.Payout, totalRows int64, err error) {
+	if status != "" && !status.IsValid() {
+		return nil, 0, apperror.ErrorInvalidRequest("payout status:", status.String())
+	}
+
+	res, err = p.payoutRepo.GetPayoutsDB(ctx, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalRows, err = p.payoutRepo.CountPayoutsDB(ctx, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, totalRows, nil
+}
+
+func (p *payoutUseCase) GetPayoutByID(ctx context.Context, id int64) (res *entities.Payout, err error) {
+	return p.payoutRepo.GetPayoutByIDDB(ctx, id)
+}
+
+// RetryPayout moves a dead payout back to pending, the worker sends it again on its next run
+func (p *payoutUseCase) RetryPayout(ctx context.Context, id int64) (res *entities.Payout, err error) {
+	data, err := p.payoutRepo.GetPayoutByIDDB(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Status != entities.PayoutStatusDead {
+		return nil, apperror.ErrInvalidState.WithDetails("only dead payouts can be retried")
+	}
+
+	err = p.payoutRepo.RetryDeadPayoutDB(ctx, id, helper.NowUTC())
+	if errors.Is(err, apperror.ErrNoUpdateRecord) {
+		return nil, apperror.ErrInvalidState.WithDetails("only dead payouts can be retried")
+	} else if err != nil {
+		return nil, err
+	}
+
+	return p.payoutRepo.GetPayoutByIDDB(ctx, id)
+}
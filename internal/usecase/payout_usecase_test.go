@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/payout"
+)
+
+// fakePayoutRepository keeps payouts in memory, claiming counts the attempt like the real query
+type fakePayoutRepository struct {
+	repositories.PayoutRepository
+
+	payouts map[int64]*entities.Payout
+	lost    map[int64]bool
+}
+
+func newFakePayoutRepository(payouts ...entities.Payout) *fakePayoutRepository {
+	repo := &fakePayoutRepository{
+		payouts: map[int64]*entities.Payout{},
+		lost:    map[int64]bool{},
+	}
+
+	for i := range payouts {
+		repo.payouts[payouts[i].ID] = &payouts[i]
+	}
+
+	return repo
+}
+
+func (r *fakePayoutRepository) ClaimDuePayoutsDB(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) (res []entities.Payout, err error) {
+	for id := int64(1); id <= int64(len(r.payouts)) && len(res) < limit; id++ {
+		data, ok := r.payouts[id]
+		if !ok || data.Status != entities.PayoutStatusPending || data.NextAttemptAt.After(now) {
+			continue
+		}
+
+		data.Attempts++
+		data.NextAttemptAt = leaseUntil
+		res = append(res, *data)
+	}
+
+	return res, nil
+}
+
+func (r *fakePayoutRepository) UpdatePayoutAttemptDB(ctx context.Context, data entities.Payout) (err error) {
+	if r.lost[data.ID] {
+		return apperror.ErrNoUpdateRecord
+	}
+
+	r.payouts[data.ID] = &data
+
+	return nil
+}
+
+func newPendingPayout(id int64, attempts int64) entities.Payout {
+	return entities.Payout{
+		ID:       id,
+		UserID:   100 + id,
+		Currency: entities.RewardTypeGoPayCoin.String(),
+		Amount:   5000,
+		Status:   entities.PayoutStatusPending,
+		Attempts: attempts,
+	}
+}
+
+func TestProcessDuePayouts(t *testing.T) {
+	tests := []struct {
+		name      string
+		attempts  int64
+		failCount int
+		reject    bool
+		lost      bool
+
+		wantStatus   entities.PayoutStatus
+		wantAttempts int64
+		wantRun      entities.PayoutRun
+	}{
+		{
+			name:         "success",
+			wantStatus:   entities.PayoutStatusSucceeded,
+			wantAttempts: 1,
+			wantRun:      entities.PayoutRun{Claimed: 1, Succeeded: 1},
+		},
+		{
+			name:         "retryable failure",
+			attempts:     2,
+			failCount:    1,
+			wantStatus:   entities.PayoutStatusPending,
+			wantAttempts: 3,
+			wantRun:      entities.PayoutRun{Claimed: 1, Retrying: 1},
+		},
+		{
+			name:         "rejected",
+			reject:       true,
+			wantStatus:   entities.PayoutStatusDead,
+			wantAttempts: 1,
+			wantRun:      entities.PayoutRun{Claimed: 1, Dead: 1},
+		},
+		{
+			name:         "out of attempts",
+			attempts:     payoutMaxAttempts - 1,
+			failCount:    payoutMaxAttempts,
+			wantStatus:   entities.PayoutStatusDead,
+			wantAttempts: payoutMaxAttempts,
+			wantRun:      entities.PayoutRun{Claimed: 1, Dead: 1},
+		},
+		{
+			name:         "lease lost to another worker",
+			lost:         true,
+			wantStatus:   entities.PayoutStatusPending,
+			wantAttempts: 1,
+			wantRun:      entities.PayoutRun{Claimed: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakePayoutRepository(newPendingPayout(1, tt.attempts))
+			repo.lost[1] = tt.lost
+
+			provider := payout.NewFakeProvider(tt.failCount)
+			key := repo.payouts[1].IdempotencyKey()
+			provider.Reject[key] = tt.reject
+
+			p := NewPayoutUseCase(repo, provider).(*payoutUseCase)
+
+			before := time.Now()
+			run, err := p.ProcessDuePayouts(context.Background(), 10)
+			if err != nil {
+				t.Fatalf("ProcessDuePayouts() error = %v", err)
+			}
+			after := time.Now()
+
+			if *run != tt.wantRun {
+				t.Fatalf("ProcessDuePayouts() run = %+v, want %+v", *run, tt.wantRun)
+			}
+
+			got := repo.payouts[1]
+			if got.Status != tt.wantStatus || got.Attempts != tt.wantAttempts {
+				t.Fatalf("payout status = %s attempts = %d, want %s attempts = %d", got.Status, got.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+
+			if tt.lost {
+				return
+			}
+
+			switch got.Status {
+			case entities.PayoutStatusSucceeded:
+				if got.ProviderRef != "fake-"+key || got.CompletedAt == nil || got.LastError != "" {
+					t.Fatalf("succeeded payout = %+v", got)
+				}
+
+				if paid := provider.Paid(); len(paid) != 1 || paid[0].IdempotencyKey != key {
+					t.Fatalf("provider paid = %+v", paid)
+				}
+
+			case entities.PayoutStatusPending:
+				backoff := p.calculatePayoutBackoff(tt.wantAttempts)
+				if got.NextAttemptAt.Before(before.Add(backoff)) || got.NextAttemptAt.After(after.Add(backoff)) {
+					t.Fatalf("next attempt at = %s, want %s after the attempt", got.NextAttemptAt, backoff)
+				}
+
+				if got.LastError == "" {
+					t.Fatal("retrying payout has no last error")
+				}
+
+			case entities.PayoutStatusDead:
+				if got.LastError == "" || got.CompletedAt != nil {
+					t.Fatalf("dead payout = %+v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessDuePayoutsSkipsPayoutsNotDue(t *testing.T) {
+	notDue := newPendingPayout(2, 1)
+	notDue.NextAttemptAt = time.Now().Add(time.Hour)
+
+	repo := newFakePayoutRepository(newPendingPayout(1, 0), notDue)
+	provider := payout.NewFakeProvider(0)
+
+	run, err := NewPayoutUseCase(repo, provider).ProcessDuePayouts(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("ProcessDuePayouts() error = %v", err)
+	}
+
+	if run.Claimed != 1 || run.Succeeded != 1 {
+		t.Fatalf("ProcessDuePayouts() run = %+v", *run)
+	}
+
+	if provider.Attempts(notDue.IdempotencyKey()) != 0 {
+		t.Fatal("payout that is not due was sent")
+	}
+}
+
+func TestCalculatePayoutBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int64
+		want     time.Duration
+	}{
+		{attempts: 1, want: payoutBaseBackoff},
+		{attempts: 2, want: 2 * payoutBaseBackoff},
+		{attempts: 3, want: 4 * payoutBaseBackoff},
+		{attempts: 7, want: 64 * payoutBaseBackoff},
+		{attempts: 8, want: payoutMaxBackoff},
+		{attempts: 40, want: payoutMaxBackoff},
+	}
+
+	p := &payoutUseCase{}
+	for _, tt := range tests {
+		if got := p.calculatePayoutBackoff(tt.attempts); got != tt.want {
+			t.Errorf("calculatePayoutBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
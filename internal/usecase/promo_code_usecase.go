@@ -0,0 +1,382 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/helper"
+)
+
+const (
+	// promoCodeAlphabet leaves out 0, O, 1 and I so codes are easy to type. It has 32 characters, so a
+	// random byte maps to it without bias
+	promoCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+	promoCodeRandomLength   = 10
+	maxPromoCodeBatchSize   = 50000
+	maxPromoCodeBatchRounds = 5
+)
+
+type PromoCodeUseCase interface {
+	CreatePromoCode(ctx context.Context, data entities.PromoCode, rewardSlug string) (res *entities.PromoCode, err error)
+	GetPromoCodes(ctx context.Context, batchID *int64, limit, offset int) (res []entities.PromoCode, totalRows int64, err error)
+	GetPromoCodeByID(ctx context.Context, id int64) (res *entities.PromoCode, err error)
+
+	CreatePromoCodeBatch(ctx context.Context, data entities.PromoCodeBatch, rewardSlug string) (res *entities.PromoCodeBatch, err error)
+	GetPromoCodeBatches(ctx context.Context, limit, offset int) (res []entities.PromoCodeBatch, totalRows int64, err error)
+	GetPromoCodeBatchByID(ctx context.Context, id int64) (res *entities.PromoCodeBatch, err error)
+	GetPromoCodeBatchCodes(ctx context.Context, id int64) (batch *entities.PromoCodeBatch, res []entities.PromoCode, err error)
+
+	RedeemPromoCode(ctx context.Context, code string) (res *entities.PromoCodeRedemption, newBalance *entities.UserBalance, err error)
+}
+
+type promoCodeUseCase struct {
+	userUseCase        UserUseCase
+	rewardUseCase      RewardUseCase
+	rewardGrantUseCase RewardGrantUseCase
+	promoCodeRepo      repositories.PromoCodeRepository
+	rewardRepo         repositories.RewardRepository
+	userRepo           repositories.UserRepository
+}
+
+func NewPromoCodeUseCase(
+	promoCodeRepo repositories.PromoCodeRepository,
+	rewardRepo repositories.RewardRepository,
+	userRepo repositories.UserRepository,
+	userUseCase UserUseCase,
+	rewardUseCase RewardUseCase,
+	rewardGrantUseCase RewardGrantUseCase,
+) PromoCodeUseCase {
+	return &promoCodeUseCase{
+		userUseCase:        userUseCase,
+		rewardUseCase:      rewardUseCase,
+		rewardGrantUseCase: rewardGrantUseCase,
+		promoCodeRepo:      promoCodeRepo,
+		rewardRepo:         rewardRepo,
+		userRepo:           userRepo,
+	}
+}
+
+// CreatePromoCode the admin is read from the context. A code with MaxRedemptions 1 is single-use,
+// without MaxRedemptions there is no global cap
+func (p *promoCodeUseCase) CreatePromoCode(ctx context.Context, data entities.PromoCode, rewardSlug string) (*entities.PromoCode, error) {
+	adminUserID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Code = entities.NormalizePromoCode(data.Code)
+	if !entities.IsValidPromoCode(data.Code) {
+		return nil, apperror.ErrorInvalidRequest(fmt.Sprintf("code must be %d to %d letters, digits or dashes", entities.MinPromoCodeLength, entities.MaxPromoCodeLength))
+	}
+
+	if data.MaxRedemptions != nil && *data.MaxRedemptions < 1 {
+		return nil, apperror.ErrorInvalidRequest("max_redemptions must be at least 1")
+	}
+
+	if data.MaxPerUser < 1 {
+		return nil, apperror.ErrorInvalidRequest("max_per_user must be at least 1")
+	}
+
+	if err := p.validatePromoCodeWindow(data.StartsAt, data.EndsAt); err != nil {
+		return nil, err
+	}
+
+	reward, err := p.getActiveReward(ctx, rewardSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	data.RewardID = reward.ID
+	data.CreatedBy = &adminUserID
+
+	id, err := p.promoCodeRepo.CreatePromoCodeDB(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.promoCodeRepo.GetPromoCodeByIDDB(ctx, id)
+}
+
+func (p *promoCodeUseCase) GetPromoCodes(ctx context.Context, batchID *int64, limit, offset int) (res []entities.PromoCode, totalRows int64, err error) {
+	res, err = p.promoCodeRepo.GetPromoCodesDB(ctx, batchID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalRows, err = p.promoCodeRepo.CountPromoCodesDB(ctx, batchID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, totalRows, nil
+}
+
+func (p *promoCodeUseCase) GetPromoCodeByID(ctx context.Context, id int64) (res *entities.PromoCode, err error) {
+	return p.promoCodeRepo.GetPromoCodeByIDDB(ctx, id)
+}
+
+// CreatePromoCodeBatch generates Size unique single-use codes made of the prefix and random characters.
+// The batch is saved at once, either every code is created or none
+func (p *promoCodeUseCase) CreatePromoCodeBatch(ctx context.Context, data entities.PromoCodeBatch, rewardSlug string) (*entities.PromoCodeBatch, error) {
+	adminUserID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" {
+		return nil, apperror.ErrorInvalidRequest("name is required")
+	}
+
+	data.Prefix = entities.NormalizePromoCode(data.Prefix)
+	if !entities.IsValidPromoCodePrefix(data.Prefix) {
+		return nil, apperror.ErrorInvalidRequest(fmt.Sprintf("prefix must be up to %d letters, digits or dashes", entities.MaxPromoCodePrefixLength))
+	}
+
+	if data.Size < 1 || data.Size > maxPromoCodeBatchSize {
+		return nil, apperror.ErrorInvalidRequest(fmt.Sprintf("size must be between 1 and %d", maxPromoCodeBatchSize))
+	}
+
+	if err := p.validatePromoCodeWindow(data.StartsAt, data.EndsAt); err != nil {
+		return nil, err
+	}
+
+	reward, err := p.getActiveReward(ctx, rewardSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	data.RewardID = reward.ID
+	data.CreatedBy = &adminUserID
+
+	err = p.promoCodeRepo.PromoCodeWithTx(ctx, func(tx *sql.Tx) error {
+		promoCodeRepoTx := p.promoCodeRepo.WithTx(tx)
+
+		data.ID, err = promoCodeRepoTx.CreatePromoCodeBatchDB(ctx, data)
+		if err != nil {
+			return err
+		}
+
+		// Codes that collide with existing ones are skipped by the insert and generated again
+		remaining := data.Size
+		for round := 0; remaining > 0; round++ {
+			if round == maxPromoCodeBatchRounds {
+				return apperror.ErrInvalidState.WithDetails("could not generate enough unique codes, use a longer prefix")
+			}
+
+			codes, err := generatePromoCodes(data.Prefix, remaining)
+			if err != nil {
+				return err
+			}
+
+			created, err := promoCodeRepoTx.CreatePromoCodeBatchCodesDB(ctx, data, codes)
+			if err != nil {
+				return err
+			}
+
+			remaining -= created
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.promoCodeRepo.GetPromoCodeBatchByIDDB(ctx, data.ID)
+}
+
+func (p *promoCodeUseCase) GetPromoCodeBatches(ctx context.Context, limit, offset int) (res []entities.PromoCodeBatch, totalRows int64, err error) {
+	res, err = p.promoCodeRepo.GetPromoCodeBatchesDB(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalRows, err = p.promoCodeRepo.CountPromoCodeBatchesDB(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, totalRows, nil
+}
+
+func (p *promoCodeUseCase) GetPromoCodeBatchByID(ctx context.Context, id int64) (res *entities.PromoCodeBatch, err error) {
+	return p.promoCodeRepo.GetPromoCodeBatchByIDDB(ctx, id)
+}
+
+// GetPromoCodeBatchCodes gets the batch with all of its codes, for the export
+func (p *promoCodeUseCase) GetPromoCodeBatchCodes(ctx context.Context, id int64) (batch *entities.PromoCodeBatch, res []entities.PromoCode, err error) {
+	batch, err = p.promoCodeRepo.GetPromoCodeBatchByIDDB(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err = p.promoCodeRepo.GetPromoCodesByBatchIDDB(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return batch, res, nil
+}
+
+// RedeemPromoCode grants the reward of the code to the player. The user row and the code row are
+// locked, so concurrent redemptions of the same code or by the same player are applied one after another
+// and neither the global cap nor the per-user limit can be passed
+func (p *promoCodeUseCase) RedeemPromoCode(ctx context.Context, code string) (res *entities.PromoCodeRedemption, newBalance *entities.UserBalance, err error) {
+	userID, err := helper.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	code = entities.NormalizePromoCode(code)
+	if !entities.IsValidPromoCode(code) {
+		return nil, nil, apperror.ErrPromoCodeInvalid
+	}
+
+	now := helper.NowUTC()
+	err = p.userRepo.BalanceWithTx(ctx, func(tx *sql.Tx) error {
+		promoCodeRepoTx := p.promoCodeRepo.WithTx(tx)
+
+		user, err := p.userRepo.WithTx(tx).GetUserByIDForUpdateDB(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			return apperror.ErrUserNotFound
+		}
+
+		promoCode, err := promoCodeRepoTx.GetPromoCodeByCodeForUpdateDB(ctx, code)
+		if err != nil {
+			return err
+		}
+
+		if promoCode == nil || !promoCode.IsActive {
+			return apperror.ErrPromoCodeInvalid
+		}
+
+		if !promoCode.IsValidAt(now) {
+			return apperror.ErrPromoCodeNotValidNow
+		}
+
+		redemptions, err := promoCodeRepoTx.CountUserPromoCodeRedemptionsDB(ctx, promoCode.ID, userID)
+		if err != nil {
+			return err
+		}
+
+		if redemptions >= promoCode.MaxPerUser {
+			return apperror.ErrPromoCodeRedeemed
+		}
+
+		if promoCode.IsExhausted() {
+			return apperror.ErrPromoCodeExhausted
+		}
+
+		reward, err := p.rewardRepo.WithTx(tx).GetRewardByIDDB(ctx, promoCode.RewardID)
+		if err != nil {
+			return err
+		}
+
+		if reward == nil || !reward.IsActive {
+			return apperror.ErrPromoCodeInvalid
+		}
+
+		err = promoCodeRepoTx.CreatePromoCodeRedemptionDB(ctx, promoCode.ID, userID)
+		if err != nil {
+			return err
+		}
+
+		err = p.rewardGrantUseCase.GrantRewardWithTx(ctx, tx, userID, reward, entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourcePromoCode,
+			Ref:  fmt.Sprintf("promo_code:%d", promoCode.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		promoCode.Redemptions++
+		promoCode.Reward = reward
+		res = &entities.PromoCodeRedemption{
+			Code:   promoCode,
+			Reward: reward,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_ = p.userRepo.DeleteUserRedis(ctx, userID)
+
+	newBalance, err = p.userUseCase.GetUserBalance(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, newBalance, nil
+}
+
+func (p *promoCodeUseCase) validatePromoCodeWindow(startsAt, endsAt *time.Time) error {
+	if endsAt == nil {
+		return nil
+	}
+
+	if !endsAt.After(helper.NowUTC()) {
+		return apperror.ErrorInvalidRequest("ends_at must be in the future")
+	}
+
+	if startsAt != nil && !endsAt.After(*startsAt) {
+		return apperror.ErrorInvalidRequest("ends_at must be after starts_at")
+	}
+
+	return nil
+}
+
+func (p *promoCodeUseCase) getActiveReward(ctx context.Context, slug string) (*entities.Reward, error) {
+	reward, err := p.rewardUseCase.GetRewardBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reward.IsActive {
+		return nil, apperror.ErrorInvalidRequest("reward is not active:", slug)
+	}
+
+	return reward, nil
+}
+
+// generatePromoCodes generates count distinct codes, they may still collide with codes in the database
+func generatePromoCodes(prefix string, count int64) ([]string, error) {
+	seen := make(map[string]struct{}, count)
+	codes := make([]string, 0, count)
+	buf := make([]byte, promoCodeRandomLength)
+
+	for int64(len(codes)) < count {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+
+		for i, b := range buf {
+			buf[i] = promoCodeAlphabet[int(b)%len(promoCodeAlphabet)]
+		}
+
+		code := prefix + string(buf)
+		if _, ok := seen[code]; ok {
+			continue
+		}
+
+		seen[code] = struct{}{}
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
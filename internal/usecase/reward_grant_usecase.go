@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+// RewardGrantUseCase gives rewards to players, every path that grants a reward goes through it
+// so each reward type is handled the same way wherever it is granted
+type RewardGrantUseCase interface {
+	GrantRewardWithTx(ctx context.Context, tx *sql.Tx, userID int64, reward *entities.Reward, source entities.CurrencyLedgerSource) (err error)
+}
+
+type rewardGrantUseCase struct {
+	boostUseCase        BoostUseCase
+	payoutUseCase       PayoutUseCase
+	rewardRepo          repositories.RewardRepository
+	userRepo            repositories.UserRepository
+	userProgressionRepo repositories.UserProgressionRepository
+}
+
+func NewRewardGrantUseCase(
+	boostUseCase BoostUseCase,
+	payoutUseCase PayoutUseCase,
+	rewardRepo repositories.RewardRepository,
+	userRepo repositories.UserRepository,
+	userProgressionRepo repositories.UserProgressionRepository,
+) RewardGrantUseCase {
+	return &rewardGrantUseCase{
+		boostUseCase:        boostUseCase,
+		payoutUseCase:       payoutUseCase,
+		rewardRepo:          rewardRepo,
+		userRepo:            userRepo,
+		userProgressionRepo: userProgressionRepo,
+	}
+}
+
+// GrantRewardWithTx grants the reward in the transaction of the caller, so the reward is given
+// if and only if the claim is saved. A bundle grants every item or, when one fails, none of them,
+// the items are set on the reward so the caller can show them. The caller is expected to clear the
+// cached user after the commit
+func (r *rewardGrantUseCase) GrantRewardWithTx(ctx context.Context, tx *sql.Tx, userID int64, reward *entities.Reward, source entities.CurrencyLedgerSource) (err error) {
+	if reward == nil || reward.RewardType == nil {
+		return apperror.ErrRecordNotFound
+	}
+
+	rewardTypeEnum, err := entities.ToRewardType(reward.RewardType.Slug)
+	if err != nil {
+		return err
+	}
+
+	if !rewardTypeEnum.IsBundle() {
+		return r.grantItemWithTx(ctx, tx, userID, reward, rewardTypeEnum, reward.Amount, nil, source)
+	}
+
+	items, err := r.rewardRepo.WithTx(tx).GetRewardBundleItemsDB(ctx, reward.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		return apperror.ErrInvalidState.WithDetails(fmt.Sprintf("bundle %s has no items", reward.Slug))
+	}
+
+	reward.Items = items
+	for _, item := range items {
+		itemTypeEnum, err := entities.ToRewardType(item.RewardType.Slug)
+		if err != nil {
+			return err
+		}
+
+		// Payouts keep the bundle as their reward, with the currency and amount of the item
+		itemReward := &entities.Reward{
+			ID:         reward.ID,
+			Slug:       reward.Slug,
+			Name:       reward.Name,
+			Amount:     item.Amount,
+			IsActive:   reward.IsActive,
+			RewardType: item.RewardType,
+		}
+
+		err = r.grantItemWithTx(ctx, tx, userID, itemReward, itemTypeEnum, item.Amount, item.BoostID, source)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// grantItemWithTx grants amount of a single reward type. BOOST rewards activate the boost linked to
+// the reward, unless boostID is set by the item of a bundle
+func (r *rewardGrantUseCase) grantItemWithTx(ctx context.Context, tx *sql.Tx, userID int64, reward *entities.Reward, rewardTypeEnum entities.RewardTypeSlug, amount int64, boostID *int64, source entities.CurrencyLedgerSource) (err error) {
+	if rewardTypeEnum.RequiresBalanceUpdate() {
+		// For COIN and GEM, update user balance in database
+		return r.userRepo.WithTx(tx).UpdateUserBalanceWithTx(ctx, userID, rewardTypeEnum.ToUserBalance(), amount, source)
+	} else if rewardTypeEnum.IsBoost() {
+		if boostID != nil {
+			_, err = r.boostUseCase.GrantBoostWithTx(ctx, tx, userID, *boostID, amount, source)
+		} else {
+			_, err = r.boostUseCase.GrantRewardBoostWithTx(ctx, tx, userID, reward, source)
+		}
+
+		return err
+	} else if rewardTypeEnum.IsStreakFreeze() {
+		// Granted freezes are not capped, only purchases are
+		_, err = r.userProgressionRepo.WithTx(tx).AddStreakFreezesDB(ctx, userID, amount)
+		return err
+	} else if rewardTypeEnum.IsSentExternally() {
+		// Paid by the payout worker once the claim is committed
+		_, err = r.payoutUseCase.CreatePayoutWithTx(ctx, tx, userID, reward, source)
+		return err
+	}
+
+	return apperror.ErrUnknownRewardType
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+
 	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/internal/repositories"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
@@ -17,15 +19,18 @@ type RewardUseCase interface {
 	CreateReward(ctx context.Context, data entities.Reward) (res *entities.Reward, err error)
 	GetRewards(ctx context.Context, limit, offset int) (res []entities.Reward, totalRow int64, err error)
 	GetRewardBySlug(ctx context.Context, slug string) (res *entities.Reward, err error)
+	LoadBundleItems(ctx context.Context, rewards ...*entities.Reward) (err error)
 }
 
 type rewardUseCase struct {
 	rewardRepo repositories.RewardRepository
+	boostRepo  repositories.BoostRepository
 }
 
-func NewRewardUseCase(rewardRepo repositories.RewardRepository) RewardUseCase {
+func NewRewardUseCase(rewardRepo repositories.RewardRepository, boostRepo repositories.BoostRepository) RewardUseCase {
 	return &rewardUseCase{
 		rewardRepo: rewardRepo,
+		boostRepo:  boostRepo,
 	}
 }
 
@@ -100,18 +105,109 @@ func (r *rewardUseCase) CreateReward(ctx context.Context, data entities.Reward)
 
 	data.RewardType = rewardType
 
-	id, err := r.rewardRepo.CreateRewardDB(ctx, data)
+	if rewardType.Slug == entities.RewardTypeBundle.String() {
+		if err := r.resolveBundleItems(ctx, data.Items); err != nil {
+			return nil, err
+		}
+
+		// A bundle grants its items, its own amount is not used
+		data.Amount = 0
+	} else if len(data.Items) > 0 {
+		return nil, apperror.ErrorInvalidRequest("only", entities.RewardTypeBundle.String(), "rewards have items")
+	}
+
+	// The bundle and its items are saved together, a bundle is never left without items
+	err = r.rewardRepo.RewardWithTx(ctx, func(tx *sql.Tx) error {
+		rewardRepoTx := r.rewardRepo.WithTx(tx)
+
+		id, err := rewardRepoTx.CreateRewardDB(ctx, data)
+		if err != nil {
+			return err
+		}
+
+		if id == nil {
+			return apperror.ErrFailedRetrieveID
+		}
+
+		data.ID = *id
+
+		return rewardRepoTx.CreateRewardBundleItemsDB(ctx, data.ID, data.Items)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if id == nil {
-		return nil, apperror.ErrFailedRetrieveID
+	return &data, nil
+}
+
+// resolveBundleItems checks the items of a bundle and looks up their reward types and boosts.
+// Bundles can't contain other bundles, and every BOOST item names the boost it activates
+func (r *rewardUseCase) resolveBundleItems(ctx context.Context, items []entities.RewardBundleItem) error {
+	if len(items) == 0 {
+		return apperror.ErrorInvalidRequest("a bundle needs at least one item")
 	}
 
-	data.ID = *id
+	for i := range items {
+		item := &items[i]
+		if item.RewardType == nil {
+			return apperror.ErrorInvalidRequest("bundle item reward type is required")
+		}
 
-	return &data, nil
+		rewardTypeEnum, err := entities.ToRewardType(item.RewardType.Slug)
+		if err != nil {
+			return apperror.ErrorInvalidRequest("bundle item reward type:", item.RewardType.Slug)
+		}
+
+		if rewardTypeEnum.IsBundle() {
+			return apperror.ErrorInvalidRequest("a bundle can't contain another bundle")
+		}
+
+		if item.Amount <= 0 {
+			return apperror.ErrorInvalidRequest("bundle item amount must be greater than 0")
+		}
+
+		item.RewardType, err = r.GetRewardTypeBySlug(ctx, item.RewardType.Slug)
+		if err != nil {
+			return err
+		}
+
+		if !rewardTypeEnum.IsBoost() {
+			if item.BoostSlug != "" {
+				return apperror.ErrorInvalidRequest("only", entities.RewardTypeBoost.String(), "bundle items have a boost")
+			}
+
+			continue
+		}
+
+		if item.BoostSlug == "" {
+			return apperror.ErrorInvalidRequest("a", entities.RewardTypeBoost.String(), "bundle item needs a boost")
+		}
+
+		boost, err := r.boostRepo.GetBoostBySlugDB(ctx, item.BoostSlug)
+		if err != nil {
+			return err
+		}
+
+		item.BoostID = &boost.ID
+	}
+
+	return nil
+}
+
+// LoadBundleItems sets the items of the bundle rewards, other rewards are left as they are
+func (r *rewardUseCase) LoadBundleItems(ctx context.Context, rewards ...*entities.Reward) (err error) {
+	for _, reward := range rewards {
+		if reward == nil || reward.RewardType == nil || reward.RewardType.Slug != entities.RewardTypeBundle.String() {
+			continue
+		}
+
+		reward.Items, err = r.rewardRepo.GetRewardBundleItemsDB(ctx, reward.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (r *rewardUseCase) GetRewardBySlug(ctx context.Context, slug string) (res *entities.Reward, err error) {
@@ -124,6 +220,10 @@ func (r *rewardUseCase) GetRewardBySlug(ctx context.Context, slug string) (res *
 		return nil, apperror.ErrRecordNotFound
 	}
 
+	if err := r.LoadBundleItems(ctx, reward); err != nil {
+		return nil, err
+	}
+
 	return reward, nil
 }
 
@@ -133,6 +233,12 @@ func (r *rewardUseCase) GetRewards(ctx context.Context, limit, offset int) (res
 		return nil, 0, err
 	}
 
+	for i := range res {
+		if err := r.LoadBundleItems(ctx, &res[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+
 	totalRow, err = r.rewardRepo.CountRewardDB(ctx)
 	if err != nil {
 		return nil, 0, err
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+// evaluateStageRequirements checks every completion requirement of the stage against the player progression
+func (g *gameUseCase) evaluateStageRequirements(ctx context.Context, userID int64, stageID int64, config *entities.GameStageConfig) ([]entities.StageRequirementProgress, error) {
+	requirements, err := g.stageReqRepo.GetStageRequirementsDB(ctx, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(requirements) == 0 {
+		return nil, nil
+	}
+
+	if config == nil {
+		config, err = g.gameStageRepo.GetGameConfigByIDDB(ctx, stageID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]entities.StageRequirementProgress, 0, len(requirements))
+	for _, requirement := range requirements {
+		var current int64
+
+		switch requirement.Type {
+		case entities.StageRequirementAllStationsLevel:
+			current, err = g.lowestStationLevel(ctx, userID, stageID, config)
+		case entities.StageRequirementKitchenPhase:
+			current, err = g.currentKitchenPhase(ctx, userID, config)
+		case entities.StageRequirementUpgradesPurchased:
+			current, err = g.purchasedUpgradeCount(ctx, userID, stageID)
+		default:
+			return nil, apperror.ErrorInvalidRequest("stage requirement type:", requirement.Type.String())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, entities.StageRequirementProgress{
+			Type:    requirement.Type,
+			Target:  requirement.Target,
+			Current: current,
+			IsMet:   current >= requirement.Target,
+		})
+	}
+
+	return res, nil
+}
+
+// validateStageRequirements fails with the list of unmet requirements in the error details
+func (g *gameUseCase) validateStageRequirements(progress []entities.StageRequirementProgress) error {
+	var unmet []string
+	for _, p := range progress {
+		if !p.IsMet {
+			unmet = append(unmet, fmt.Sprintf("%s %d/%d", p.Type, p.Current, p.Target))
+		}
+	}
+
+	if len(unmet) > 0 {
+		return apperror.ErrStageRequirementsNotMet.WithDetails(strings.Join(unmet, ", "))
+	}
+
+	return nil
+}
+
+// lowestStationLevel a station that is still locked counts as level 0
+func (g *gameUseCase) lowestStationLevel(ctx context.Context, userID int64, stageID int64, config *entities.GameStageConfig) (int64, error) {
+	if config == nil || len(config.KitchenStations) == 0 {
+		return 0, nil
+	}
+
+	kitchenProgress, err := g.userProgressionRepo.GetUserKitchenProgressDB(ctx, userID, stageID)
+	if err != nil {
+		return 0, err
+	}
+
+	if kitchenProgress == nil {
+		return 0, nil
+	}
+
+	var lowest int64 = -1
+	for _, station := range config.KitchenStations {
+		level := kitchenProgress.StationLevels[station.FoodItemSlug].Level
+		if lowest < 0 || level < lowest {
+			lowest = level
+		}
+	}
+
+	return lowest, nil
+}
+
+func (g *gameUseCase) currentKitchenPhase(ctx context.Context, userID int64, config *entities.GameStageConfig) (int64, error) {
+	if config == nil || config.KitchenConfig == nil {
+		return 0, nil
+	}
+
+	phaseProgress, err := g.userProgressionRepo.GetUserKitchenPhaseProgressionDB(ctx, userID, config.KitchenConfig.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	if phaseProgress == nil {
+		return 0, nil
+	}
+
+	return phaseProgress.CurrentPhase, nil
+}
+
+func (g *gameUseCase) purchasedUpgradeCount(ctx context.Context, userID int64, stageID int64) (int64, error) {
+	purchased, err := g.userProgressionRepo.GetPurchasedStageUpgradesDB(ctx, userID, stageID)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(purchased)), nil
+}
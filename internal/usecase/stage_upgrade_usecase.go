@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
 )
 
@@ -52,6 +53,11 @@ func (g *gameUseCase) gatherStageUpgradeData(ctx context.Context, userID int64,
 		return nil, err
 	}
 
+	err = g.evaluateUpgradeLocks(ctx, userID, suctx.stageID, suctx.activeStageUpgrades, suctx.userProgress)
+	if err != nil {
+		return nil, err
+	}
+
 	suctx.userBalance, err = g.userUseCase.GetUserBalance(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -70,9 +76,17 @@ func (g *gameUseCase) validateStageUpgrade(ctx *stageUpgradeContext) error {
 	}
 
 	for _, stageUpgrade := range ctx.activeStageUpgrades {
-		if stageUpgrade.Upgrade.Slug == ctx.stageUpgrade.Upgrade.Slug && stageUpgrade.IsPurchased {
+		if stageUpgrade.Upgrade.Slug != ctx.stageUpgrade.Upgrade.Slug {
+			continue
+		}
+
+		if stageUpgrade.IsPurchased {
 			return apperror.ErrorInvalidRequest(fmt.Sprintf("upgrade with name %s already purchased", ctx.stageUpgrade.Upgrade.Name))
 		}
+
+		if err := g.validateUpgradePrerequisites(stageUpgrade); err != nil {
+			return err
+		}
 	}
 
 	upgradeCost := ctx.stageUpgrade.Upgrade.Cost
@@ -105,7 +119,10 @@ func (g *gameUseCase) executeStageUpgradeTransaction(ctx context.Context, upgrad
 		userProgressionTx := g.userProgressionRepo.WithTx(tx)
 		userRepoTx := g.userRepo.WithTx(tx)
 
-		err = userRepoTx.UpdateUserBalanceWithTx(ctx, userID, upgradeContext.balanceType, -upgrade.Cost)
+		err = userRepoTx.UpdateUserBalanceWithTx(ctx, userID, upgradeContext.balanceType, -upgrade.Cost, entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourceStageUpgrade,
+			Ref:  upgrade.Slug,
+		})
 		if err != nil {
 			return err
 		}
@@ -121,52 +138,94 @@ func (g *gameUseCase) executeStageUpgradeTransaction(ctx context.Context, upgrad
 			return err
 		}
 
-		if upgradeEffect.Target == upgradeTargetFood {
-			progress := upgradeContext.userProgress
-			if progress == nil {
-				progress = &entities.UserKitchenStageProgression{
-					UserID:          userID,
-					StageID:         stageID,
-					StationUpgrades: make(map[string]entities.UserStationUpgrade),
-				}
+		progress := upgradeContext.userProgress
+		if progress == nil {
+			progress = &entities.UserKitchenStageProgression{
+				UserID:          userID,
+				StageID:         stageID,
+				StationUpgrades: make(map[string]entities.UserStationUpgrade),
 			}
+		}
 
+		switch upgradeEffect.Target {
+		case entities.UpgradeEffectTargetFood:
 			stationUpgrade := progress.StationUpgrades
 			if stationUpgrade == nil {
 				stationUpgrade = make(map[string]entities.UserStationUpgrade)
 			}
 
 			foodSlug := upgradeEffect.TargetName
-			currentBonus := stationUpgrade[foodSlug]
+			stationUpgrade[foodSlug] = g.applyUpgradeEffect(stationUpgrade[foodSlug], upgradeEffect)
+			progress.StationUpgrades = stationUpgrade
 
-			if currentBonus.ProfitBonus == 0 {
-				currentBonus.ProfitBonus = 1.0
-			}
+			return userProgressionTx.UpdateKitchenStationUpgradeDB(ctx, userID, stageID, stationUpgrade)
+
+		case entities.UpgradeEffectTargetAllFood,
+			entities.UpgradeEffectTargetHelper,
+			entities.UpgradeEffectTargetCustomer:
+			// Stage-wide effects are kept apart from the stations, so stations unlocked later get them too
+			progress.KitchenUpgrades = g.applyUpgradeEffect(progress.KitchenUpgrades, upgradeEffect)
+
+			return userProgressionTx.UpdateKitchenUpgradeDB(ctx, userID, stageID, progress.KitchenUpgrades)
 
-			if currentBonus.ReduceCookingTime == 0 && (upgradeEffect.Unit == entities.UpgradeEffectUnitMultiplier || upgradeEffect.Unit == entities.UpgradeEffectUnitPercentage) {
-				currentBonus.ReduceCookingTime = 1.0
+		case entities.UpgradeEffectTargetRestaurant:
+			if upgradeEffect.Type == entities.UpgradeEffectTypeUnlockRestaurant {
+				return g.unlockNextStage(ctx, userProgressionTx, userID, stageID)
 			}
+		}
+
+		return nil
+	})
+}
 
-			switch upgradeEffect.Type {
-			case entities.UpgradeEffectTypeAddHelper:
-				currentBonus.HelperCount += int64(upgradeEffect.Value)
+func (g *gameUseCase) applyUpgradeEffect(current entities.UserStationUpgrade, upgradeEffect entities.UpgradeEffect) entities.UserStationUpgrade {
+	if current.ProfitBonus == 0 {
+		current.ProfitBonus = 1.0
+	}
 
-			case entities.UpgradeEffectTypeAddCustomer:
-				currentBonus.CustomerCount += int64(upgradeEffect.Value)
+	if current.ReduceCookingTime == 0 && (upgradeEffect.Unit == entities.UpgradeEffectUnitMultiplier || upgradeEffect.Unit == entities.UpgradeEffectUnitPercentage) {
+		current.ReduceCookingTime = 1.0
+	}
 
-			case entities.UpgradeEffectTypeReduceCookingTime:
-				currentBonus.ReduceCookingTime = upgradeEffect.CalculateNewValue(currentBonus.ReduceCookingTime)
+	switch upgradeEffect.Type {
+	case entities.UpgradeEffectTypeAddHelper:
+		current.HelperCount += int64(upgradeEffect.Value)
 
-			case entities.UpgradeEffectTypeProfit:
-				currentBonus.ProfitBonus = upgradeEffect.CalculateNewValue(currentBonus.ProfitBonus)
-			}
+	case entities.UpgradeEffectTypeAddCustomer:
+		current.CustomerCount += int64(upgradeEffect.Value)
 
-			stationUpgrade[foodSlug] = currentBonus
-			progress.StationUpgrades = stationUpgrade
+	case entities.UpgradeEffectTypeReduceCookingTime:
+		current.ReduceCookingTime = upgradeEffect.CalculateNewValue(current.ReduceCookingTime)
 
-			return userProgressionTx.UpdateKitchenStationUpgradeDB(ctx, userID, stageID, stationUpgrade)
-		}
+	case entities.UpgradeEffectTypeProfit:
+		current.ProfitBonus = upgradeEffect.CalculateNewValue(current.ProfitBonus)
+	}
 
-		return nil
-	})
+	return current
+}
+
+// unlockNextStage makes the stage after the current one available without completing the current one
+func (g *gameUseCase) unlockNextStage(ctx context.Context, userProgressionTx repositories.UserProgressionRepository, userID int64, stageID int64) error {
+	gameStages, err := g.gameStageRepo.GetActiveGameStagesDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	nextStageID, exists := g.getNextStageID(gameStages, stageID)
+	if !exists {
+		return apperror.ErrInvalidState.WithDetails("there is no stage after the current one to unlock")
+	}
+
+	// A failed insert would abort the transaction, so check before creating
+	unlocked, err := userProgressionTx.CheckStageProgressionExistsDB(ctx, userID, nextStageID)
+	if err != nil {
+		return err
+	}
+
+	if unlocked {
+		return apperror.ErrInvalidState.WithDetails("the next stage is already unlocked")
+	}
+
+	_, err = userProgressionTx.CreateGameStageProgressionDB(ctx, userID, nextStageID)
+	return err
 }
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+// evaluateUpgradeLocks sets the lock state of every upgrade of the stage from its prerequisites.
+// Purchased upgrades are never locked, even when a prerequisite was added after the purchase
+func (g *gameUseCase) evaluateUpgradeLocks(ctx context.Context, userID int64, stageID int64, upgrades []entities.UserStageUpgrade, progress *entities.UserKitchenStageProgression) error {
+	if len(upgrades) == 0 {
+		return nil
+	}
+
+	upgradeIDs := make([]int64, 0, len(upgrades))
+	purchased := make(map[int64]bool)
+	for _, upgrade := range upgrades {
+		upgradeIDs = append(upgradeIDs, upgrade.Upgrade.ID)
+		if upgrade.IsPurchased {
+			purchased[upgrade.Upgrade.ID] = true
+		}
+	}
+
+	prerequisites, err := g.upgradeRepo.GetUpgradePrerequisitesDB(ctx, upgradeIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(prerequisites) == 0 {
+		return nil
+	}
+
+	prerequisitesByUpgrade := make(map[int64][]entities.UpgradePrerequisite)
+	var needsPhase bool
+	for _, prerequisite := range prerequisites {
+		prerequisitesByUpgrade[prerequisite.UpgradeID] = append(prerequisitesByUpgrade[prerequisite.UpgradeID], prerequisite)
+		if prerequisite.Type == entities.UpgradePrerequisiteKitchenPhase {
+			needsPhase = true
+		}
+	}
+
+	var phase int64
+	if needsPhase {
+		phase, err = g.stageKitchenPhase(ctx, userID, stageID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range upgrades {
+		if upgrades[i].IsPurchased {
+			continue
+		}
+
+		reasons := g.unmetUpgradePrerequisites(prerequisitesByUpgrade[upgrades[i].Upgrade.ID], purchased, progress, phase)
+		upgrades[i].LockReasons = reasons
+		upgrades[i].IsLocked = len(reasons) > 0
+	}
+
+	return nil
+}
+
+// unmetUpgradePrerequisites a station that is still locked counts as level 0
+func (g *gameUseCase) unmetUpgradePrerequisites(
+	prerequisites []entities.UpgradePrerequisite,
+	purchased map[int64]bool,
+	progress *entities.UserKitchenStageProgression,
+	phase int64,
+) []entities.UpgradeLockReason {
+	var reasons []entities.UpgradeLockReason
+	for _, prerequisite := range prerequisites {
+		switch prerequisite.Type {
+		case entities.UpgradePrerequisiteUpgrade:
+			if !purchased[prerequisite.RequiredUpgradeID] {
+				reasons = append(reasons, entities.UpgradeLockReason{
+					Type:    prerequisite.Type,
+					Upgrade: prerequisite.RequiredUpgradeSlug,
+				})
+			}
+
+		case entities.UpgradePrerequisiteStationLevel:
+			var level int64
+			if progress != nil {
+				level = progress.StationLevels[prerequisite.FoodItemSlug].Level
+			}
+
+			if level < prerequisite.Target {
+				reasons = append(reasons, entities.UpgradeLockReason{
+					Type:    prerequisite.Type,
+					Station: prerequisite.FoodItemSlug,
+					Target:  prerequisite.Target,
+					Current: level,
+				})
+			}
+
+		case entities.UpgradePrerequisiteKitchenPhase:
+			if phase < prerequisite.Target {
+				reasons = append(reasons, entities.UpgradeLockReason{
+					Type:    prerequisite.Type,
+					Target:  prerequisite.Target,
+					Current: phase,
+				})
+			}
+		}
+	}
+
+	return reasons
+}
+
+// validateUpgradePrerequisites fails with the list of unmet prerequisites in the error details
+func (g *gameUseCase) validateUpgradePrerequisites(upgrade entities.UserStageUpgrade) error {
+	if !upgrade.IsLocked {
+		return nil
+	}
+
+	unmet := make([]string, 0, len(upgrade.LockReasons))
+	for _, reason := range upgrade.LockReasons {
+		switch reason.Type {
+		case entities.UpgradePrerequisiteUpgrade:
+			unmet = append(unmet, fmt.Sprintf("%s %s", reason.Type, reason.Upgrade))
+		case entities.UpgradePrerequisiteStationLevel:
+			unmet = append(unmet, fmt.Sprintf("%s %s %d/%d", reason.Type, reason.Station, reason.Current, reason.Target))
+		default:
+			unmet = append(unmet, fmt.Sprintf("%s %d/%d", reason.Type, reason.Current, reason.Target))
+		}
+	}
+
+	return apperror.ErrUpgradeLocked.WithDetails(strings.Join(unmet, ", "))
+}
+
+func (g *gameUseCase) stageKitchenPhase(ctx context.Context, userID int64, stageID int64) (int64, error) {
+	kitchenConfig, err := g.kitchenConfigRepo.GetKitchenConfigByStageIDDB(ctx, stageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return g.currentKitchenPhase(ctx, userID, &entities.GameStageConfig{KitchenConfig: kitchenConfig})
+}
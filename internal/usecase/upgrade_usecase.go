@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"slices"
+	"strings"
 
 	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/internal/repositories"
@@ -13,6 +16,9 @@ type UpgradeUseCase interface {
 	GetUpgrades(ctx context.Context, limit, offset int) (res []entities.Upgrade, totalRows int64, err error)
 	GetUpgradeByID(ctx context.Context, id int64) (res *entities.Upgrade, err error)
 	UpdateUpgrade(ctx context.Context, id int64, data entities.Upgrade) (res *entities.Upgrade, err error)
+
+	GetUpgradePrerequisites(ctx context.Context, id int64) (res []entities.UpgradePrerequisite, err error)
+	UpdateUpgradePrerequisites(ctx context.Context, id int64, prerequisites []entities.UpgradePrerequisite) (res []entities.UpgradePrerequisite, err error)
 }
 
 type upgradeUseCase struct {
@@ -102,3 +108,197 @@ func (u *upgradeUseCase) resolveEffectTargetID(ctx context.Context, data *entiti
 
 	return nil
 }
+
+// GetUpgradePrerequisites gets the prerequisites of an upgrade
+func (u *upgradeUseCase) GetUpgradePrerequisites(ctx context.Context, id int64) (res []entities.UpgradePrerequisite, err error) {
+	_, err = u.upgradeRepo.GetUpgradeByIDDB(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.upgradeRepo.GetUpgradePrerequisitesDB(ctx, []int64{id})
+}
+
+// UpdateUpgradePrerequisites replaces the prerequisites of an upgrade with transaction,
+// rejecting the ones that would make the upgrade require itself
+func (u *upgradeUseCase) UpdateUpgradePrerequisites(ctx context.Context, id int64, prerequisites []entities.UpgradePrerequisite) (res []entities.UpgradePrerequisite, err error) {
+	upgrade, err := u.upgradeRepo.GetUpgradeByIDDB(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.resolvePrerequisiteTargetIDs(ctx, upgrade, prerequisites); err != nil {
+		return nil, err
+	}
+
+	if err := u.validatePrerequisiteCycle(ctx, upgrade, prerequisites); err != nil {
+		return nil, err
+	}
+
+	err = u.upgradeRepo.UpgradeWithTx(ctx, func(tx *sql.Tx) error {
+		upgradeTx := u.upgradeRepo.WithTx(tx)
+
+		err := upgradeTx.DeleteUpgradePrerequisitesDB(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return upgradeTx.BulkCreateUpgradePrerequisitesDB(ctx, prerequisites)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.upgradeRepo.GetUpgradePrerequisitesDB(ctx, []int64{id})
+}
+
+func (u *upgradeUseCase) resolvePrerequisiteTargetIDs(ctx context.Context, upgrade *entities.Upgrade, prerequisites []entities.UpgradePrerequisite) error {
+	var slugs []string
+	for _, p := range prerequisites {
+		if p.Type == entities.UpgradePrerequisiteUpgrade {
+			slugs = append(slugs, p.RequiredUpgradeSlug)
+		}
+	}
+
+	upgradeIDs := make(map[string]int64)
+	if len(slugs) > 0 {
+		upgrades, err := u.upgradeRepo.GetUpgradesBySlugsDB(ctx, slugs)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range upgrades {
+			upgradeIDs[v.Slug] = v.ID
+		}
+	}
+
+	for i := range prerequisites {
+		p := &prerequisites[i]
+
+		switch p.Type {
+		case entities.UpgradePrerequisiteUpgrade:
+			if p.RequiredUpgradeSlug == upgrade.Slug {
+				return apperror.ErrorInvalidRequest("upgrade", upgrade.Slug, "can't require itself")
+			}
+
+			requiredID, exists := upgradeIDs[p.RequiredUpgradeSlug]
+			if !exists {
+				return apperror.ErrorNotFound("upgrade", "slug", p.RequiredUpgradeSlug)
+			}
+
+			p.RequiredUpgradeID = requiredID
+
+		case entities.UpgradePrerequisiteStationLevel:
+			foodItem, err := u.foodItemRepo.GetFoodBySlugDB(ctx, p.FoodItemSlug)
+			if err != nil {
+				return err
+			}
+
+			if foodItem == nil {
+				return apperror.ErrorNotFound("food item:", p.FoodItemSlug)
+			}
+
+			p.FoodItemID = foodItem.ID
+		}
+	}
+
+	return u.validatePrerequisiteStages(ctx, upgrade, prerequisites)
+}
+
+// validatePrerequisiteStages purchases are kept per stage, so a required upgrade must be sold in every stage
+// selling the upgrade, otherwise the upgrade stays locked in the stages missing it
+func (u *upgradeUseCase) validatePrerequisiteStages(ctx context.Context, upgrade *entities.Upgrade, prerequisites []entities.UpgradePrerequisite) error {
+	upgradeIDs := []int64{upgrade.ID}
+	for _, p := range prerequisites {
+		if p.Type == entities.UpgradePrerequisiteUpgrade {
+			upgradeIDs = append(upgradeIDs, p.RequiredUpgradeID)
+		}
+	}
+
+	if len(upgradeIDs) == 1 {
+		return nil
+	}
+
+	stageIDs, err := u.upgradeRepo.GetUpgradeStageIDsDB(ctx, upgradeIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range prerequisites {
+		if p.Type != entities.UpgradePrerequisiteUpgrade {
+			continue
+		}
+
+		for _, stageID := range stageIDs[upgrade.ID] {
+			if !slices.Contains(stageIDs[p.RequiredUpgradeID], stageID) {
+				return apperror.ErrorInvalidRequest("upgrade", p.RequiredUpgradeSlug, "is not sold in every stage selling", upgrade.Slug)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validatePrerequisiteCycle walks the upgrade prerequisites of every upgrade, with the new ones
+// in place of the current ones of the upgrade, and fails with the path when it leads back to the upgrade
+func (u *upgradeUseCase) validatePrerequisiteCycle(ctx context.Context, upgrade *entities.Upgrade, prerequisites []entities.UpgradePrerequisite) error {
+	existing, err := u.upgradeRepo.GetUpgradePrerequisitesByTypeDB(ctx, entities.UpgradePrerequisiteUpgrade)
+	if err != nil {
+		return err
+	}
+
+	graph := make(map[int64][]int64)
+	slugs := map[int64]string{upgrade.ID: upgrade.Slug}
+	for _, p := range existing {
+		if p.UpgradeID == upgrade.ID {
+			continue
+		}
+
+		graph[p.UpgradeID] = append(graph[p.UpgradeID], p.RequiredUpgradeID)
+		slugs[p.RequiredUpgradeID] = p.RequiredUpgradeSlug
+	}
+
+	for _, p := range prerequisites {
+		if p.Type == entities.UpgradePrerequisiteUpgrade {
+			graph[upgrade.ID] = append(graph[upgrade.ID], p.RequiredUpgradeID)
+			slugs[p.RequiredUpgradeID] = p.RequiredUpgradeSlug
+		}
+	}
+
+	visited := make(map[int64]bool)
+	var path []int64
+
+	var leadsBack func(id int64) bool
+	leadsBack = func(id int64) bool {
+		path = append(path, id)
+		for _, next := range graph[id] {
+			if next == upgrade.ID {
+				path = append(path, next)
+				return true
+			}
+
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+
+			if leadsBack(next) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+
+		return false
+	}
+
+	if !leadsBack(upgrade.ID) {
+		return nil
+	}
+
+	names := make([]string, 0, len(path))
+	for _, id := range path {
+		names = append(names, slugs[id])
+	}
+
+	return apperror.ErrorInvalidRequest("upgrade prerequisites form a cycle:", strings.Join(names, " -> "))
+}
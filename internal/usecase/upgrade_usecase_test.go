@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/winartodev/cat-cafe/internal/entities"
+	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+// fakeStagedUpgradeRepository knows the upgrades by slug and the stages selling them
+type fakeStagedUpgradeRepository struct {
+	repositories.UpgradeRepository
+
+	upgrades []entities.Upgrade
+	stages   map[int64][]int64
+}
+
+func (r *fakeStagedUpgradeRepository) GetUpgradesBySlugsDB(ctx context.Context, slugs []string) (res []entities.Upgrade, err error) {
+	for _, upgrade := range r.upgrades {
+		for _, slug := range slugs {
+			if upgrade.Slug == slug {
+				res = append(res, upgrade)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (r *fakeStagedUpgradeRepository) GetUpgradeStageIDsDB(ctx context.Context, upgradeIDs []int64) (map[int64][]int64, error) {
+	res := make(map[int64][]int64)
+	for _, id := range upgradeIDs {
+		res[id] = r.stages[id]
+	}
+
+	return res, nil
+}
+
+func TestResolvePrerequisiteTargetIDsStages(t *testing.T) {
+	repo := &fakeStagedUpgradeRepository{
+		upgrades: []entities.Upgrade{
+			{ID: 1, Slug: "faster-oven"},
+			{ID: 2, Slug: "bigger-oven"},
+			{ID: 3, Slug: "second-stage-oven"},
+			{ID: 4, Slug: "unsold-oven"},
+		},
+		stages: map[int64][]int64{
+			1: {1, 2},
+			2: {1, 2},
+			3: {2},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		upgrade  entities.Upgrade
+		required string
+		wantErr  bool
+	}{
+		{name: "sold in the same stages", upgrade: repo.upgrades[0], required: "bigger-oven"},
+		{name: "sold in fewer stages", upgrade: repo.upgrades[0], required: "second-stage-oven", wantErr: true},
+		{name: "sold in more stages", upgrade: repo.upgrades[2], required: "faster-oven"},
+		{name: "upgrade not sold yet", upgrade: repo.upgrades[3], required: "second-stage-oven"},
+		{name: "required upgrade not sold", upgrade: repo.upgrades[1], required: "unsold-oven", wantErr: true},
+	}
+
+	u := &upgradeUseCase{upgradeRepo: repo}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prerequisites := []entities.UpgradePrerequisite{{
+				UpgradeID:           tt.upgrade.ID,
+				Type:                entities.UpgradePrerequisiteUpgrade,
+				RequiredUpgradeSlug: tt.required,
+			}}
+
+			err := u.resolvePrerequisiteTargetIDs(context.Background(), &tt.upgrade, prerequisites)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("resolvePrerequisiteTargetIDs() error = %v", err)
+				}
+
+				return
+			}
+
+			var appErr *apperror.AppError
+			if !errors.As(err, &appErr) || appErr.StatusCode != http.StatusBadRequest {
+				t.Fatalf("resolvePrerequisiteTargetIDs() error = %v, want a bad request", err)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package usecase
 
 import (
+	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/internal/repositories"
+	"github.com/winartodev/cat-cafe/pkg/identity"
 	"github.com/winartodev/cat-cafe/pkg/jwt"
+	"github.com/winartodev/cat-cafe/pkg/payout"
 )
 
 type UseCase struct {
@@ -16,12 +19,19 @@ type UseCase struct {
 	FoodItemUseCase        FoodItemUseCase
 	UpgradeUseCase         UpgradeUseCase
 	TutorialUseCase        TutorialUseCase
+	CurrencyLedgerUseCase  CurrencyLedgerUseCase
+	ModerationUseCase      ModerationUseCase
+	BoostUseCase           BoostUseCase
+	PayoutUseCase          PayoutUseCase
+	MailUseCase            MailUseCase
+	PromoCodeUseCase       PromoCodeUseCase
 }
 
-func SetUpUseCase(repo repositories.Repository, jwt_ *jwt.JWT) *UseCase {
+func SetUpUseCase(repo repositories.Repository, jwt_ *jwt.JWT, identityProvider identity.Provider, payoutProvider payout.Provider, dailyRewardSettings entities.DailyRewardSettings) *UseCase {
 	userProgressionUC := NewUserProgressionUseCase(
 		repo.UserProgressionRepository,
 		repo.FoodItemRepository,
+		repo.UserRepository,
 	)
 
 	userUC := NewUserUseCase(
@@ -31,6 +41,27 @@ func SetUpUseCase(repo repositories.Repository, jwt_ *jwt.JWT) *UseCase {
 
 	rewardUC := NewRewardUseCase(
 		repo.RewardRepository,
+		repo.BoostRepository,
+	)
+
+	boostUC := NewBoostUseCase(
+		userUC,
+		repo.BoostRepository,
+		repo.RewardRepository,
+		repo.UserRepository,
+	)
+
+	payoutUC := NewPayoutUseCase(
+		repo.PayoutRepository,
+		payoutProvider,
+	)
+
+	rewardGrantUC := NewRewardGrantUseCase(
+		boostUC,
+		payoutUC,
+		repo.RewardRepository,
+		repo.UserRepository,
+		repo.UserProgressionRepository,
 	)
 
 	dailyRewardUC := NewDailyRewardUseCase(
@@ -39,6 +70,8 @@ func SetUpUseCase(repo repositories.Repository, jwt_ *jwt.JWT) *UseCase {
 		repo.UserRepository,
 		userUC,
 		rewardUC,
+		rewardGrantUC,
+		dailyRewardSettings,
 	)
 
 	foodItemUC := NewFoodItemUseCase(
@@ -61,6 +94,7 @@ func SetUpUseCase(repo repositories.Repository, jwt_ *jwt.JWT) *UseCase {
 		repo.FoodItemRepository,
 		repo.UpgradeRepository,
 		repo.StageUpgradeRepository,
+		repo.StageRequirementRepository,
 	)
 
 	gameUC := NewGameUseCase(
@@ -74,19 +108,56 @@ func SetUpUseCase(repo repositories.Repository, jwt_ *jwt.JWT) *UseCase {
 		repo.StageKitchenConfigRepository,
 		repo.RewardRepository,
 		repo.StageUpgradeRepository,
+		repo.StageRequirementRepository,
+		repo.UpgradeRepository,
+		boostUC,
+		rewardGrantUC,
+		dailyRewardUC,
+	)
+
+	moderationUC := NewModerationUseCase(
+		repo.ModerationRepository,
+		repo.SessionRepository,
+		repo.UserRepository,
+		jwt_,
 	)
 
 	authUC := NewAuthUseCase(
 		userUC,
 		gameUC,
+		moderationUC,
 		repo.UserRepository,
+		repo.SessionRepository,
 		jwt_,
+		identityProvider,
 	)
 
 	tutorialUC := NewTutorialUseCase(
 		repo.TutorialRepository,
 	)
 
+	currencyLedgerUC := NewCurrencyLedgerUseCase(
+		repo.CurrencyLedgerRepository,
+		repo.UserRepository,
+	)
+
+	mailUC := NewMailUseCase(
+		repo.MailRepository,
+		repo.UserRepository,
+		userUC,
+		rewardUC,
+		rewardGrantUC,
+	)
+
+	promoCodeUC := NewPromoCodeUseCase(
+		repo.PromoCodeRepository,
+		repo.RewardRepository,
+		repo.UserRepository,
+		userUC,
+		rewardUC,
+		rewardGrantUC,
+	)
+
 	return &UseCase{
 		UserUseCase:            userUC,
 		UserProgressionUseCase: userProgressionUC,
@@ -98,5 +169,11 @@ func SetUpUseCase(repo repositories.Repository, jwt_ *jwt.JWT) *UseCase {
 		FoodItemUseCase:        foodItemUC,
 		UpgradeUseCase:         upgradeUC,
 		TutorialUseCase:        tutorialUC,
+		CurrencyLedgerUseCase:  currencyLedgerUC,
+		ModerationUseCase:      moderationUC,
+		BoostUseCase:           boostUC,
+		PayoutUseCase:          payoutUC,
+		MailUseCase:            mailUC,
+		PromoCodeUseCase:       promoCodeUC,
 	}
 }
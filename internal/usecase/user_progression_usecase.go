@@ -11,7 +11,7 @@ import (
 )
 
 type UserProgressionUseCase interface {
-	InitializeUserProgression(ctx context.Context, userID int64, stageID int64, config *entities.GameStageConfig) (err error)
+	InitializeUserProgression(ctx context.Context, userID int64, stage *entities.GameStage, config *entities.GameStageConfig) (startingCoinGranted int64, err error)
 	LatestStageProgression(ctx context.Context) (res *entities.UserGameStageProgression, err error)
 	DailyRewardProgression(ctx context.Context, userID int64) (res *entities.UserDailyReward, err error)
 	GetActiveStageUpgrade(ctx context.Context, stageID int64) (res []entities.UserStageUpgrade, err error)
@@ -20,16 +20,25 @@ type UserProgressionUseCase interface {
 type userProgressionUseCase struct {
 	userProgressionRepo repositories.UserProgressionRepository
 	foodItemRepo        repositories.FoodItemRepository
+	userRepo            repositories.UserRepository
 }
 
-func NewUserProgressionUseCase(userProgressionRepo repositories.UserProgressionRepository, foodItemRepo repositories.FoodItemRepository) UserProgressionUseCase {
+func NewUserProgressionUseCase(
+	userProgressionRepo repositories.UserProgressionRepository,
+	foodItemRepo repositories.FoodItemRepository,
+	userRepo repositories.UserRepository,
+) UserProgressionUseCase {
 	return &userProgressionUseCase{
 		userProgressionRepo: userProgressionRepo,
 		foodItemRepo:        foodItemRepo,
+		userRepo:            userRepo,
 	}
 }
 
-func (u *userProgressionUseCase) InitializeUserProgression(ctx context.Context, userID int64, stageID int64, config *entities.GameStageConfig) (err error) {
+// InitializeUserProgression sets up the stage progress and credits the starting coins on the first start only
+func (u *userProgressionUseCase) InitializeUserProgression(ctx context.Context, userID int64, stage *entities.GameStage, config *entities.GameStageConfig) (startingCoinGranted int64, err error) {
+	stageID := stage.ID
+
 	err = u.userProgressionRepo.WithUserProgressionTx(ctx, func(tx *sql.Tx) error {
 		userProgressionTx := u.userProgressionRepo.WithTx(tx)
 
@@ -48,13 +57,32 @@ func (u *userProgressionUseCase) InitializeUserProgression(ctx context.Context,
 			return err
 		}
 
+		claimed, err := userProgressionTx.ClaimStageStartingCoinDB(ctx, userID, stageID)
+		if err != nil {
+			return err
+		}
+
+		if !claimed || stage.StartingCoin <= 0 {
+			return nil
+		}
+
+		err = u.userRepo.WithTx(tx).UpdateUserBalanceWithTx(ctx, userID, entities.BalanceTypeCoin, stage.StartingCoin, entities.CurrencyLedgerSource{
+			Type: entities.CurrencySourceStageStart,
+			Ref:  stage.Slug,
+		})
+		if err != nil {
+			return err
+		}
+
+		startingCoinGranted = stage.StartingCoin
+
 		return nil
 	})
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return startingCoinGranted, nil
 }
 
 func (u *userProgressionUseCase) getOrCreateKitchenProgress(
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"time"
 
 	"github.com/winartodev/cat-cafe/internal/entities"
 	"github.com/winartodev/cat-cafe/internal/repositories"
@@ -16,7 +15,6 @@ type UserUseCase interface {
 	GetUserDailyRewardByID(ctx context.Context, userID int64) (res *entities.UserDailyReward, err error)
 	GetUserBalance(ctx context.Context, userID int64) (res *entities.UserBalance, err error)
 	GetUserByEmail(ctx context.Context, email string) (res *entities.User, err error)
-	IsDailyRewardAvailable(ctx context.Context, userID int64) (isAvailable bool, err error)
 }
 
 type userUseCase struct {
@@ -127,25 +125,3 @@ func (c *userUseCase) GetUserByEmail(ctx context.Context, email string) (res *en
 
 	return user, nil
 }
-
-func (c *userUseCase) IsDailyRewardAvailable(ctx context.Context, userID int64) (isAvailable bool, err error) {
-	progression, err := c.GetUserDailyRewardByID(ctx, userID)
-	if err != nil {
-		return false, err
-	}
-
-	now := helper.NowUTC()
-	today := now.Truncate(24 * time.Hour)
-
-	if progression == nil {
-		progression = &entities.UserDailyReward{}
-	} else if progression.LastClaimDate != nil {
-		// Check if user already claimed today
-		lastClaim := progression.LastClaimDate.UTC().Truncate(24 * time.Hour)
-		if today.Equal(lastClaim) {
-			return false, nil
-		}
-	}
-
-	return true, nil
-}
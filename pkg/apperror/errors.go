@@ -25,23 +25,39 @@ var (
 	ErrInsufficientGems       = NewAppError("INSUFFICIENT_COINS", "Insufficient gems to complete this action", http.StatusBadRequest)
 	ErrStationAlreadyUnlocked = NewAppError("STATION_ALREADY_UNLOCKED", "Station is already unlocked", http.StatusBadRequest)
 	ErrAlreadyClaimed         = NewAppError("ALREADY_CLAIMED", "Daily reward already claimed today", http.StatusBadRequest)
+	ErrMailAlreadyClaimed     = NewAppError("MAIL_ALREADY_CLAIMED", "Mail attachments already claimed", http.StatusBadRequest)
+	ErrPromoCodeInvalid       = NewAppError("PROMO_CODE_INVALID", "Promo code does not exist or is no longer active", http.StatusBadRequest)
+	ErrPromoCodeNotValidNow   = NewAppError("PROMO_CODE_NOT_VALID_NOW", "Promo code can not be redeemed at this time", http.StatusBadRequest)
+	ErrPromoCodeExhausted     = NewAppError("PROMO_CODE_EXHAUSTED", "Promo code has reached its redemption limit", http.StatusBadRequest)
+	ErrPromoCodeRedeemed      = NewAppError("PROMO_CODE_REDEEMED", "Promo code was already redeemed", http.StatusBadRequest)
 	ErrUnknownRewardType      = NewAppError("UNKNOWN_REWARD_TYPE", "Unknown reward type", http.StatusBadRequest)
 	ErrUserNotStartedGame     = NewAppError("USER_NOT_STARTED_GAME", "User has not started the game", http.StatusBadRequest)
 	ErrMissingKitchenConfig   = NewAppError("MISSING_KITCHEN_CONFIG", "Missing kitchen config", http.StatusBadRequest)
+	ErrIdempotencyKeyReused   = NewAppError("IDEMPOTENCY_KEY_REUSED", "Idempotency key was already used for a different request", http.StatusBadRequest)
 
 	// --- 401 - UNAUTHORIZED ERRORS ---
 
-	ErrUnauthorized      = NewAppError("UNAUTHORIZED", "Authentication required", http.StatusUnauthorized)
-	ErrInvalidToken      = NewAppError("INVALID_TOKEN", "Invalid or expired token", http.StatusUnauthorized)
-	ErrTokenExpired      = NewAppError("TOKEN_EXPIRED", "Token has expired", http.StatusUnauthorized)
-	ErrTokenRevoked      = NewAppError("TOKEN_REVOKED", "Token has been revoked", http.StatusUnauthorized)
-	ErrMissingAuthHeader = NewAppError("MISSING_AUTH_HEADER", "Missing authorization header", http.StatusUnauthorized)
+	ErrUnauthorized        = NewAppError("UNAUTHORIZED", "Authentication required", http.StatusUnauthorized)
+	ErrInvalidToken        = NewAppError("INVALID_TOKEN", "Invalid or expired token", http.StatusUnauthorized)
+	ErrTokenExpired        = NewAppError("TOKEN_EXPIRED", "Token has expired", http.StatusUnauthorized)
+	ErrTokenRevoked        = NewAppError("TOKEN_REVOKED", "Token has been revoked", http.StatusUnauthorized)
+	ErrMissingAuthHeader   = NewAppError("MISSING_AUTH_HEADER", "Missing authorization header", http.StatusUnauthorized)
+	ErrInvalidAuthCode     = NewAppError("INVALID_AUTH_CODE", "Authorization code is invalid or expired", http.StatusUnauthorized)
+	ErrInvalidIDToken      = NewAppError("INVALID_ID_TOKEN", "Identity token could not be verified", http.StatusUnauthorized)
+	ErrInvalidRefreshToken = NewAppError("INVALID_REFRESH_TOKEN", "Refresh token is invalid or expired", http.StatusUnauthorized)
+	ErrRefreshTokenReused  = NewAppError("REFRESH_TOKEN_REUSED", "Refresh token was already used, the session has been revoked", http.StatusUnauthorized)
 
 	// --- 403 - FORBIDDEN ERRORS ---
 
-	ErrAccessDenied  = NewAppError("ACCESS_DENIED", "You don't have permission to access this resource", http.StatusForbidden)
-	ErrStationLocked = NewAppError("STATION_LOCKED", "Station must be unlocked before upgrading", http.StatusForbidden)
-	ErrStageLocked   = NewAppError("STAGE_LOCKED", "Stage is locked", http.StatusForbidden)
+	ErrAccessDenied            = NewAppError("ACCESS_DENIED", "You don't have permission to access this resource", http.StatusForbidden)
+	ErrStationLocked           = NewAppError("STATION_LOCKED", "Station must be unlocked before upgrading", http.StatusForbidden)
+	ErrStationPhaseLocked      = NewAppError("STATION_PHASE_LOCKED", "Station requires a higher kitchen phase to unlock", http.StatusForbidden)
+	ErrStageLocked             = NewAppError("STAGE_LOCKED", "Stage is locked", http.StatusForbidden)
+	ErrStageRequirementsNotMet = NewAppError("STAGE_REQUIREMENTS_NOT_MET", "Stage completion requirements are not met", http.StatusForbidden)
+	ErrUpgradeLocked           = NewAppError("UPGRADE_LOCKED", "Upgrade prerequisites are not met", http.StatusForbidden)
+	ErrAccountDisabled         = NewAppError("ACCOUNT_DISABLED", "Account is disabled", http.StatusForbidden)
+	ErrAccountSuspended        = NewAppError("ACCOUNT_SUSPENDED", "Account is suspended", http.StatusForbidden)
+	ErrAccountBanned           = NewAppError("ACCOUNT_BANNED", "Account is banned", http.StatusForbidden)
 
 	// --- 404 - NOT FOUND ERRORS ---
 
@@ -52,14 +68,17 @@ var (
 	ErrStageNotFound    = NewAppError("STAGE_NOT_FOUND", "Game stage not found", http.StatusNotFound)
 	ErrUpgradeNotFound  = NewAppError("UPGRADE_NOT_FOUND", "Upgrade not found", http.StatusNotFound)
 	ErrStageNotStarted  = NewAppError("STAGE_NOT_STARTED", "Stage not started", http.StatusNotFound)
-	
+	ErrSessionNotFound  = NewAppError("SESSION_NOT_FOUND", "Session not found", http.StatusNotFound)
+
 	// --- 409 - CONFLICT ERRORS ---
 
-	ErrConflict              = NewAppError("CONFLICT", "Resource already exists in the system", http.StatusConflict)
-	ErrAlreadyExists         = NewAppError("ALREADY_EXISTS", "Resource already exists", http.StatusConflict)
-	ErrInvalidState          = NewAppError("INVALID_STATE", "Operation cannot be performed in current state", http.StatusConflict)
-	ErrMaxLevelReached       = NewAppError("MAX_LEVEL_REACHED", "Station has already reached maximum level", http.StatusConflict)
-	ErrStageAlreadyCompleted = NewAppError("STAGE_ALREADY_COMPLETED", "Stage already completed", http.StatusConflict)
+	ErrConflict                 = NewAppError("CONFLICT", "Resource already exists in the system", http.StatusConflict)
+	ErrAlreadyExists            = NewAppError("ALREADY_EXISTS", "Resource already exists", http.StatusConflict)
+	ErrInvalidState             = NewAppError("INVALID_STATE", "Operation cannot be performed in current state", http.StatusConflict)
+	ErrMaxLevelReached          = NewAppError("MAX_LEVEL_REACHED", "Station has already reached maximum level", http.StatusConflict)
+	ErrStageAlreadyCompleted    = NewAppError("STAGE_ALREADY_COMPLETED", "Stage already completed", http.StatusConflict)
+	ErrIdempotencyKeyInProgress = NewAppError("IDEMPOTENCY_KEY_IN_PROGRESS", "A request with this idempotency key is still in progress", http.StatusConflict)
+	ErrEmailAlreadyLinked       = NewAppError("EMAIL_ALREADY_LINKED", "Email is already linked to another account", http.StatusConflict)
 
 	// --- 500 - INTERNAL SERVER ERRORS ---
 
@@ -69,6 +88,10 @@ var (
 	ErrNoUpdateRecord    = NewAppError("NO_UPDATE_RECORD", "No record found to update", http.StatusInternalServerError)
 	ErrFailedRetrieveID  = NewAppError("FAILED_RETRIEVE_ID", "Failed to retrieve last inserted ID", http.StatusInternalServerError)
 	ErrRequiredActiveTx  = NewAppError("REQUIRED_ACTIVE_TX", "This method requires an active transaction", http.StatusInternalServerError)
+
+	// --- 502 - BAD GATEWAY ERRORS ---
+
+	ErrIdentityProvider = NewAppError("IDENTITY_PROVIDER_ERROR", "Identity provider is unavailable", http.StatusBadGateway)
 )
 
 func ErrorNotFound(args ...string) *AppError {
@@ -91,6 +114,31 @@ func ErrorInvalidParam(args ...string) *AppError {
 	return NewAppError("INVALID_PARAM", "Invalid parameter "+strings.Join(args, " "), http.StatusBadRequest)
 }
 
+// ErrorAccountSuspended the details carry the expiry in RFC 3339, or "permanent"
+func ErrorAccountSuspended(reason string, expiresAt *time.Time) *AppError {
+	return accountRestrictionError(ErrAccountSuspended, reason, expiresAt)
+}
+
+// ErrorAccountBanned the details carry the expiry in RFC 3339, or "permanent"
+func ErrorAccountBanned(reason string, expiresAt *time.Time) *AppError {
+	return accountRestrictionError(ErrAccountBanned, reason, expiresAt)
+}
+
+func accountRestrictionError(base *AppError, reason string, expiresAt *time.Time) *AppError {
+	message := base.Message
+	expiry := "permanent"
+	if expiresAt != nil {
+		expiry = expiresAt.UTC().Format(time.RFC3339)
+		message = fmt.Sprintf("%s until %s", base.Message, expiry)
+	}
+
+	if reason != "" {
+		message = fmt.Sprintf("%s: %s", message, reason)
+	}
+
+	return NewAppError(base.Code, message, base.StatusCode).WithDetails(expiry)
+}
+
 // AppError represents a structured application error
 type AppError struct {
 	Code       string // Error code for client
@@ -7,10 +7,12 @@ import (
 )
 
 const (
-	ContextUserKey   = "user"
-	ContextTokenKey  = "token"
-	ContextEmailKey  = "email"
-	ContextUserIDKey = "userID"
+	ContextUserKey      = "user"
+	ContextTokenKey     = "token"
+	ContextEmailKey     = "email"
+	ContextUserIDKey    = "userID"
+	ContextAdminKey     = "admin"
+	ContextSessionIDKey = "sessionID"
 )
 
 func GetUserID(c *fiber.Ctx) int64 {
@@ -26,6 +28,11 @@ func GetEmail(c *fiber.Ctx) string {
 	return val
 }
 
+func GetSessionID(c *fiber.Ctx) int64 {
+	val, _ := c.Locals(ContextSessionIDKey).(int64)
+	return val
+}
+
 func GetToken(c *fiber.Ctx) string {
 	val, _ := c.Locals(ContextTokenKey).(string)
 	return val
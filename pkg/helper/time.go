@@ -1,7 +1,112 @@
 package helper
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Embedded so the timezones of the players load on images without a zoneinfo database
+	_ "time/tzdata"
+)
 
 func NowUTC() time.Time {
 	return time.Now().UTC()
 }
+
+const DefaultTimezone = "UTC"
+
+// ParseTimezone accepts an IANA name such as Asia/Jakarta or a UTC offset such as +07:00, +0700 or +7.
+// It returns the location and its normalized name, which is what gets stored
+func ParseTimezone(s string) (*time.Location, string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.UTC, DefaultTimezone, nil
+	}
+
+	if s[0] != '+' && s[0] != '-' {
+		// Local would be the timezone of the server, not of the player
+		if s == "Local" {
+			return nil, "", fmt.Errorf("unknown timezone %q", s)
+		}
+
+		loc, err := time.LoadLocation(s)
+		if err != nil {
+			return nil, "", fmt.Errorf("unknown timezone %q", s)
+		}
+
+		return loc, loc.String(), nil
+	}
+
+	hours, minutes, hasMinutes := strings.Cut(s[1:], ":")
+	if !hasMinutes && len(hours) == 4 {
+		hours, minutes, hasMinutes = hours[:2], hours[2:], true
+	}
+
+	// Atoi accepts signs, so the parts are checked to be digits first
+	if len(hours) == 0 || len(hours) > 2 || !isDigits(hours) || (hasMinutes && (len(minutes) != 2 || !isDigits(minutes))) {
+		return nil, "", fmt.Errorf("invalid utc offset %q", s)
+	}
+
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 14 {
+		return nil, "", fmt.Errorf("invalid utc offset %q", s)
+	}
+
+	var m int
+	if hasMinutes {
+		m, err = strconv.Atoi(minutes)
+		if err != nil || m < 0 || m > 59 {
+			return nil, "", fmt.Errorf("invalid utc offset %q", s)
+		}
+	}
+
+	offset := h*60*60 + m*60
+	if s[0] == '-' {
+		offset = -offset
+	}
+
+	name := fmt.Sprintf("%c%02d:%02d", s[0], h, m)
+	return time.FixedZone(name, offset), name, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LoadTimezone is ParseTimezone for a stored timezone, an unknown one falls back to UTC
+func LoadTimezone(s string) *time.Location {
+	loc, _, err := ParseTimezone(s)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// DayClock splits time into game days. A game day starts at ResetHour in the timezone of the player
+type DayClock struct {
+	ResetHour int
+}
+
+// Day returns the game day t falls on in loc, as midnight UTC of that date so days can be compared and subtracted
+func (c DayClock) Day(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc).Add(-time.Duration(c.ResetHour) * time.Hour)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// DaysBetween is how many game days passed from a to b in loc
+func (c DayClock) DaysBetween(a time.Time, b time.Time, loc *time.Location) int64 {
+	return int64(c.Day(b, loc).Sub(c.Day(a, loc)) / (24 * time.Hour))
+}
+
+// StartOf returns the moment the game day returned by Day starts in loc
+func (c DayClock) StartOf(day time.Time, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), c.ResetHour, 0, 0, 0, loc)
+}
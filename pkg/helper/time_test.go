@@ -0,0 +1,58 @@
+package helper
+
+import "testing"
+
+func TestParseTimezone(t *testing.T) {
+	tests := []struct {
+		input   string
+		name    string
+		offset  int
+		wantErr bool
+	}{
+		{input: "", name: "UTC"},
+		{input: "Asia/Jakarta", name: "Asia/Jakarta", offset: 7 * 60 * 60},
+		{input: "+7", name: "+07:00", offset: 7 * 60 * 60},
+		{input: "+07:00", name: "+07:00", offset: 7 * 60 * 60},
+		{input: "+0700", name: "+07:00", offset: 7 * 60 * 60},
+		{input: "-05:30", name: "-05:30", offset: -(5*60*60 + 30*60)},
+		{input: "+14", name: "+14:00", offset: 14 * 60 * 60},
+		{input: "Local", wantErr: true},
+		{input: "Mars/Olympus", wantErr: true},
+		{input: "+15", wantErr: true},
+		{input: "+-5", wantErr: true},
+		{input: "-+5", wantErr: true},
+		{input: "+05:-30", wantErr: true},
+		{input: "+05:+30", wantErr: true},
+		{input: "+05:60", wantErr: true},
+		{input: "+07:5", wantErr: true},
+		{input: "+7:", wantErr: true},
+		{input: "+123", wantErr: true},
+		{input: "+ 7", wantErr: true},
+		{input: "+", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			loc, name, err := ParseTimezone(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTimezone(%q) = %q, want an error", tt.input, name)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseTimezone(%q) returned %v", tt.input, err)
+			}
+
+			if name != tt.name {
+				t.Errorf("name = %q, want %q", name, tt.name)
+			}
+
+			// Asia/Jakarta has no daylight saving time, so any instant has the same offset
+			if _, offset := NowUTC().In(loc).Zone(); offset != tt.offset {
+				t.Errorf("offset = %d, want %d", offset, tt.offset)
+			}
+		})
+	}
+}
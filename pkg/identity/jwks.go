@@ -0,0 +1,109 @@
+package identity
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/winartodev/cat-cafe/pkg/jwk"
+)
+
+// minJWKSRefreshInterval prevents unknown kids from hammering the provider
+const minJWKSRefreshInterval = time.Minute
+
+// jwksCache keeps the provider signing keys and refreshes them when an unknown kid shows up
+type jwksCache struct {
+	uri        string
+	httpClient *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	lastFetched time.Time
+}
+
+func newJWKSCache(uri string, httpClient *http.Client) *jwksCache {
+	return &jwksCache{
+		uri:        uri,
+		httpClient: httpClient,
+		keys:       make(map[string]crypto.PublicKey),
+	}
+}
+
+func (c *jwksCache) get(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	c.mu.RLock()
+	key, ok := c.keys[kid]
+	lastFetched := c.lastFetched
+	c.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+
+	if time.Since(lastFetched) < minJWKSRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	if err := c.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	key, ok = c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	return key, nil
+}
+
+func (c *jwksCache) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.uri, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var set jwk.Set
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := k.PublicKey()
+		if err != nil {
+			// Skip keys we can't use instead of failing the whole set
+			continue
+		}
+
+		keys[k.Kid] = publicKey
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.lastFetched = time.Now()
+	c.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/winartodev/cat-cafe/pkg/apperror"
+)
+
+const (
+	defaultHTTPTimeout = 10 * time.Second
+	idTokenLeeway      = 30 * time.Second
+)
+
+type OIDCConfig struct {
+	Issuer        string
+	ClientID      string
+	ClientSecret  string
+	RedirectURI   string
+	TokenEndpoint string
+	JWKSURI       string
+	Timeout       time.Duration
+}
+
+type tokenResponse struct {
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+type idTokenClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	jwt.RegisteredClaims
+}
+
+// oidcProvider implements the OAuth2 authorization code flow and verifies the
+// returned OpenID Connect ID token against the provider JWKS
+type oidcProvider struct {
+	config     OIDCConfig
+	httpClient *http.Client
+	jwks       *jwksCache
+}
+
+func NewOIDCProvider(config OIDCConfig) (Provider, error) {
+	if config.TokenEndpoint == "" {
+		return nil, errors.New("identity: token endpoint is required")
+	}
+
+	if config.JWKSURI == "" {
+		return nil, errors.New("identity: jwks uri is required")
+	}
+
+	if config.ClientID == "" {
+		return nil, errors.New("identity: client id is required")
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	httpClient := &http.Client{Timeout: timeout}
+
+	return &oidcProvider{
+		config:     config,
+		httpClient: httpClient,
+		jwks:       newJWKSCache(config.JWKSURI, httpClient),
+	}, nil
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code string) (*Profile, error) {
+	token, err := p.exchangeCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := p.verifyIDToken(ctx, token.IDToken)
+	if err != nil {
+		return nil, apperror.ErrInvalidIDToken.WithError(err)
+	}
+
+	return &Profile{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+	}, nil
+}
+
+func (p *oidcProvider) exchangeCode(ctx context.Context, code string) (*tokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("client_id", p.config.ClientID)
+	if p.config.ClientSecret != "" {
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+	if p.config.RedirectURI != "" {
+		form.Set("redirect_uri", p.config.RedirectURI)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, apperror.ErrIdentityProvider.WithError(err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, apperror.ErrIdentityProvider.WithError(err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized:
+		return nil, apperror.ErrInvalidAuthCode
+	case resp.StatusCode != http.StatusOK:
+		return nil, apperror.ErrIdentityProvider.WithError(fmt.Errorf("token endpoint returned status %d", resp.StatusCode))
+	}
+
+	var token tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, apperror.ErrIdentityProvider.WithError(err)
+	}
+
+	if token.IDToken == "" {
+		return nil, apperror.ErrIdentityProvider.WithError(errors.New("token endpoint returned no id_token"))
+	}
+
+	return &token, nil
+}
+
+func (p *oidcProvider) verifyIDToken(ctx context.Context, idToken string) (*idTokenClaims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(idTokenLeeway),
+	}
+
+	if p.config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(p.config.Issuer))
+	}
+
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.jwks.get(ctx, kid)
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+
+	return claims, nil
+}
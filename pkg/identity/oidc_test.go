@@ -0,0 +1,257 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/winartodev/cat-cafe/pkg/jwk"
+)
+
+const (
+	testIssuer   = "https://idp.test"
+	testClientID = "cat-cafe"
+)
+
+// testIDP serves the token endpoint and the JWKS of a provider whose signing key can be rotated
+type testIDP struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu      sync.Mutex
+	kid     string
+	key     *rsa.PrivateKey
+	idToken string
+
+	jwksRequests atomic.Int32
+}
+
+func newTestIDP(t *testing.T) *testIDP {
+	t.Helper()
+
+	idp := &testIDP{t: t}
+	idp.rotate("key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.jwksRequests.Add(1)
+
+		idp.mu.Lock()
+		publicJWK, err := jwk.FromPublicKey(&idp.key.PublicKey, idp.kid, jwt.SigningMethodRS256.Alg())
+		idp.mu.Unlock()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(jwk.Set{Keys: []jwk.Key{*publicJWK}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		idp.mu.Lock()
+		idToken := idp.idToken
+		idp.mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(tokenResponse{IDToken: idToken, TokenType: "Bearer"})
+	})
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+// rotate replaces the signing key, the JWKS only serves the newest key
+func (idp *testIDP) rotate(kid string) {
+	idp.t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		idp.t.Fatalf("generate key: %v", err)
+	}
+
+	idp.mu.Lock()
+	idp.kid = kid
+	idp.key = key
+	idp.mu.Unlock()
+}
+
+func (idp *testIDP) sign(claims jwt.MapClaims) string {
+	idp.t.Helper()
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	return signToken(idp.t, idp.key, idp.kid, claims)
+}
+
+func (idp *testIDP) provider() *oidcProvider {
+	idp.t.Helper()
+
+	provider, err := NewOIDCProvider(OIDCConfig{
+		Issuer:        testIssuer,
+		ClientID:      testClientID,
+		TokenEndpoint: idp.server.URL + "/token",
+		JWKSURI:       idp.server.URL + "/jwks",
+	})
+	if err != nil {
+		idp.t.Fatalf("new provider: %v", err)
+	}
+
+	return provider.(*oidcProvider)
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            testIssuer,
+		"sub":            "player-1",
+		"aud":            testClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"email":          "player@cat.cafe",
+		"email_verified": true,
+		"name":           "Player",
+	}
+}
+
+func TestOIDCProviderExchange(t *testing.T) {
+	idp := newTestIDP(t)
+	idp.idToken = idp.sign(validClaims())
+
+	profile, err := idp.provider().Exchange(context.Background(), "code")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+
+	if profile.Subject != "player-1" || profile.Email != "player@cat.cafe" || !profile.EmailVerified || profile.Name != "Player" {
+		t.Fatalf("Exchange() profile = %+v", profile)
+	}
+}
+
+func TestOIDCProviderVerifyIDToken(t *testing.T) {
+	idp := newTestIDP(t)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	with := func(key string, value any) jwt.MapClaims {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "valid", token: idp.sign(validClaims())},
+		{name: "expired within leeway", token: idp.sign(with("exp", time.Now().Add(-10*time.Second).Unix()))},
+		{name: "forged signature", token: signToken(t, otherKey, "key-1", validClaims()), wantErr: true},
+		{name: "unsigned", token: unsignedToken(t, validClaims()), wantErr: true},
+		{name: "wrong audience", token: idp.sign(with("aud", "someone-else")), wantErr: true},
+		{name: "wrong issuer", token: idp.sign(with("iss", "https://evil.test")), wantErr: true},
+		{name: "expired", token: idp.sign(with("exp", time.Now().Add(-2*time.Minute).Unix())), wantErr: true},
+		{name: "no expiry", token: idp.sign(with("exp", nil)), wantErr: true},
+		{name: "no subject", token: idp.sign(with("sub", nil)), wantErr: true},
+	}
+
+	provider := idp.provider()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := provider.verifyIDToken(context.Background(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyIDToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && claims.Subject != "player-1" {
+				t.Fatalf("verifyIDToken() subject = %q", claims.Subject)
+			}
+		})
+	}
+}
+
+func TestOIDCProviderRefreshesJWKSOnNewKid(t *testing.T) {
+	idp := newTestIDP(t)
+	provider := idp.provider()
+	ctx := context.Background()
+
+	if _, err := provider.verifyIDToken(ctx, idp.sign(validClaims())); err != nil {
+		t.Fatalf("verifyIDToken() error = %v", err)
+	}
+
+	// Known kids are served from the cache
+	if _, err := provider.verifyIDToken(ctx, idp.sign(validClaims())); err != nil {
+		t.Fatalf("verifyIDToken() error = %v", err)
+	}
+
+	if got := idp.jwksRequests.Load(); got != 1 {
+		t.Fatalf("jwks requests = %d, want 1", got)
+	}
+
+	idp.rotate("key-2")
+	rotated := idp.sign(validClaims())
+
+	// A new kid right after a fetch is rejected without hitting the provider again
+	if _, err := provider.verifyIDToken(ctx, rotated); err == nil {
+		t.Fatal("verifyIDToken() accepted a kid inside the refresh interval")
+	}
+
+	if got := idp.jwksRequests.Load(); got != 1 {
+		t.Fatalf("jwks requests = %d, want 1", got)
+	}
+
+	provider.jwks.mu.Lock()
+	provider.jwks.lastFetched = time.Now().Add(-minJWKSRefreshInterval)
+	provider.jwks.mu.Unlock()
+
+	if _, err := provider.verifyIDToken(ctx, rotated); err != nil {
+		t.Fatalf("verifyIDToken() after rotation error = %v", err)
+	}
+
+	if got := idp.jwksRequests.Load(); got != 2 {
+		t.Fatalf("jwks requests = %d, want 2", got)
+	}
+}
+
+func unsignedToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	token.Header["kid"] = "key-1"
+
+	signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	return signed
+}
@@ -0,0 +1,17 @@
+package identity
+
+import "context"
+
+// Profile is the verified player identity returned by a provider
+type Profile struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// Provider exchanges an authorization code for a verified profile
+type Provider interface {
+	Exchange(ctx context.Context, code string) (*Profile, error)
+}
@@ -0,0 +1,162 @@
+package jwk
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	KeyTypeRSA = "RSA"
+	KeyTypeEC  = "EC"
+	KeyTypeOKP = "OKP"
+)
+
+var ErrUnsupportedKey = errors.New("jwk: unsupported key")
+
+// Key is a single JSON Web Key (RFC 7517) holding a public key
+type Key struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC and OKP
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// Set is a JSON Web Key Set
+type Set struct {
+	Keys []Key `json:"keys"`
+}
+
+// Find returns the key with the given kid
+func (s *Set) Find(kid string) (*Key, bool) {
+	for i := range s.Keys {
+		if s.Keys[i].Kid == kid {
+			return &s.Keys[i], true
+		}
+	}
+	return nil, false
+}
+
+// PublicKey converts the JWK into a crypto public key
+func (k *Key) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case KeyTypeRSA:
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case KeyTypeEC:
+		curve, err := curveByName(k.Crv)
+		if err != nil {
+			return nil, err
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case KeyTypeOKP:
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, k.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid Ed25519 key size", ErrUnsupportedKey)
+		}
+
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, fmt.Errorf("%w: key type %s", ErrUnsupportedKey, k.Kty)
+}
+
+// FromPublicKey builds a JWK for the given public key
+func FromPublicKey(publicKey crypto.PublicKey, kid string, alg string) (*Key, error) {
+	key := &Key{
+		Kid: kid,
+		Use: "sig",
+		Alg: alg,
+	}
+
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		key.Kty = KeyTypeRSA
+		key.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		key.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+
+	case *ecdsa.PublicKey:
+		key.Kty = KeyTypeEC
+		key.Crv = pub.Curve.Params().Name
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		key.X = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size)))
+		key.Y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size)))
+
+	case ed25519.PublicKey:
+		key.Kty = KeyTypeOKP
+		key.Crv = "Ed25519"
+		key.X = base64.RawURLEncoding.EncodeToString(pub)
+
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, publicKey)
+	}
+
+	return key, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+func curveByName(name string) (elliptic.Curve, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	}
+
+	return nil, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, name)
+}
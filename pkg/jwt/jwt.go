@@ -3,32 +3,53 @@ package jwt
 import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/winartodev/cat-cafe/pkg/apperror"
+	"github.com/winartodev/cat-cafe/pkg/jwk"
 	"time"
 )
 
+const (
+	defaultAccessTokenDuration  = 15 * time.Minute
+	defaultRefreshTokenDuration = 30 * 24 * time.Hour
+)
+
 type Claims struct {
-	UserID int64  `json:"user_id"`
-	Email  string `json:"email"`
+	UserID    int64  `json:"user_id"`
+	Email     string `json:"email"`
+	SessionID int64  `json:"sid"`
 	jwt.RegisteredClaims
 }
 
 type JWT struct {
-	secretKey     string
-	tokenDuration time.Duration
+	keySet               *KeySet
+	tokenDuration        time.Duration
+	refreshTokenDuration time.Duration
 }
 
-func NewJWT(secretKey string, tokenDuration int64) *JWT {
+// NewJWT accessTokenDuration is in minutes, refreshTokenDuration is in hours
+func NewJWT(keySet *KeySet, accessTokenDuration int64, refreshTokenDuration int64) *JWT {
+	tokenDuration := time.Duration(accessTokenDuration) * time.Minute
+	if tokenDuration <= 0 {
+		tokenDuration = defaultAccessTokenDuration
+	}
+
+	refreshDuration := time.Duration(refreshTokenDuration) * time.Hour
+	if refreshDuration <= 0 {
+		refreshDuration = defaultRefreshTokenDuration
+	}
+
 	return &JWT{
-		secretKey:     secretKey,
-		tokenDuration: time.Duration(tokenDuration) * time.Hour,
+		keySet:               keySet,
+		tokenDuration:        tokenDuration,
+		refreshTokenDuration: refreshDuration,
 	}
 }
 
-// GenerateToken Generate new JWT token
-func (c *JWT) GenerateToken(userID int64, email string) (string, error) {
+// GenerateToken Generate new short-lived access token bound to a device session
+func (c *JWT) GenerateToken(userID int64, email string, sessionID int64) (string, error) {
 	claims := Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:    userID,
+		Email:     email,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(c.tokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -36,8 +57,14 @@ func (c *JWT) GenerateToken(userID int64, email string) (string, error) {
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(c.secretKey))
+	key := c.keySet.active()
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != DefaultKeyID {
+		token.Header["kid"] = key.ID
+	}
+
+	return token.SignedString(key.signKey)
 }
 
 // ValidateToken Validate and parse JWT token
@@ -45,13 +72,13 @@ func (c *JWT) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&Claims{},
-		func(token *jwt.Token) (interface{}, error) {
-			// Verify signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, apperror.ErrInvalidToken
-			}
-			return []byte(c.secretKey), nil
-		},
+		c.keySet.verificationKey,
+		jwt.WithValidMethods([]string{
+			jwt.SigningMethodHS256.Alg(),
+			jwt.SigningMethodRS256.Alg(),
+			jwt.SigningMethodES256.Alg(),
+			jwt.SigningMethodEdDSA.Alg(),
+		}),
 	)
 
 	if err != nil {
@@ -71,7 +98,17 @@ func (c *JWT) ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
-// GetTokenDuration Get token expiration duration
+// JWKS Get the public keys used to validate access tokens
+func (c *JWT) JWKS() (*jwk.Set, error) {
+	return c.keySet.JWKS()
+}
+
+// GetTokenDuration Get access token expiration duration
 func (c *JWT) GetTokenDuration() time.Duration {
 	return c.tokenDuration
 }
+
+// GetRefreshTokenDuration Get refresh token expiration duration
+func (c *JWT) GetRefreshTokenDuration() time.Duration {
+	return c.refreshTokenDuration
+}
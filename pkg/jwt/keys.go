@@ -0,0 +1,167 @@
+package jwt
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/winartodev/cat-cafe/pkg/jwk"
+)
+
+// DefaultKeyID is used for the legacy HS256 secret and for tokens issued without a kid header
+const DefaultKeyID = "default"
+
+// SigningKey is one key of the key set. A retired key is neither used for signing
+// nor accepted during validation, and is removed from the JWKS
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Retired bool
+
+	signKey   any
+	verifyKey any
+}
+
+// KeySet holds every configured key, tokens are signed by the active one and validated by any non-retired one
+type KeySet struct {
+	activeKeyID string
+	keys        map[string]*SigningKey
+}
+
+// NewHMACKey builds an HS256 key from a shared secret
+func NewHMACKey(id string, secret string, retired bool) (*SigningKey, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("jwt: key %s: secret is required", id)
+	}
+
+	return &SigningKey{
+		ID:        id,
+		Method:    jwt.SigningMethodHS256,
+		Retired:   retired,
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+	}, nil
+}
+
+// NewAsymmetricKey builds an RS256, ES256 or EdDSA key from a PEM encoded private key.
+// When only a public key is given the key can validate tokens but never sign them
+func NewAsymmetricKey(id string, algorithm string, privateKeyPEM []byte, publicKeyPEM []byte, retired bool) (*SigningKey, error) {
+	key := &SigningKey{
+		ID:      id,
+		Retired: retired,
+	}
+
+	var err error
+	switch algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		key.Method = jwt.SigningMethodRS256
+		if len(privateKeyPEM) > 0 {
+			key.signKey, err = jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+		} else {
+			key.verifyKey, err = jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM)
+		}
+
+	case jwt.SigningMethodES256.Alg():
+		key.Method = jwt.SigningMethodES256
+		if len(privateKeyPEM) > 0 {
+			key.signKey, err = jwt.ParseECPrivateKeyFromPEM(privateKeyPEM)
+		} else {
+			key.verifyKey, err = jwt.ParseECPublicKeyFromPEM(publicKeyPEM)
+		}
+
+	case jwt.SigningMethodEdDSA.Alg():
+		key.Method = jwt.SigningMethodEdDSA
+		if len(privateKeyPEM) > 0 {
+			key.signKey, err = jwt.ParseEdPrivateKeyFromPEM(privateKeyPEM)
+		} else {
+			key.verifyKey, err = jwt.ParseEdPublicKeyFromPEM(publicKeyPEM)
+		}
+
+	default:
+		return nil, fmt.Errorf("jwt: key %s: unsupported algorithm %q", id, algorithm)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("jwt: key %s: %w", id, err)
+	}
+
+	if signer, ok := key.signKey.(crypto.Signer); ok {
+		key.verifyKey = signer.Public()
+	}
+
+	if key.verifyKey == nil {
+		return nil, fmt.Errorf("jwt: key %s: private or public key is required", id)
+	}
+
+	return key, nil
+}
+
+// NewKeySet validates that the active key exists, is not retired and can sign
+func NewKeySet(activeKeyID string, keys ...*SigningKey) (*KeySet, error) {
+	set := &KeySet{
+		activeKeyID: activeKeyID,
+		keys:        make(map[string]*SigningKey, len(keys)),
+	}
+
+	for _, key := range keys {
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("jwt: duplicate key id %s", key.ID)
+		}
+
+		set.keys[key.ID] = key
+	}
+
+	active, exists := set.keys[activeKeyID]
+	if !exists {
+		return nil, fmt.Errorf("jwt: active key %s is not configured", activeKeyID)
+	}
+
+	if active.Retired || active.signKey == nil {
+		return nil, fmt.Errorf("jwt: active key %s cannot sign tokens", activeKeyID)
+	}
+
+	return set, nil
+}
+
+// JWKS returns the public part of every non-retired asymmetric key
+func (s *KeySet) JWKS() (*jwk.Set, error) {
+	set := &jwk.Set{Keys: []jwk.Key{}}
+	for _, key := range s.keys {
+		if key.Retired || key.Method == jwt.SigningMethodHS256 {
+			continue
+		}
+
+		publicKey, err := jwk.FromPublicKey(key.verifyKey, key.ID, key.Method.Alg())
+		if err != nil {
+			return nil, err
+		}
+
+		set.Keys = append(set.Keys, *publicKey)
+	}
+
+	return set, nil
+}
+
+func (s *KeySet) active() *SigningKey {
+	return s.keys[s.activeKeyID]
+}
+
+// verificationKey resolves the key for a token by its kid header
+func (s *KeySet) verificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid = DefaultKeyID
+	}
+
+	key, exists := s.keys[kid]
+	if !exists || key.Retired {
+		return nil, errors.New("jwt: unknown or retired key")
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("jwt: signing method does not match key")
+	}
+
+	return key.verifyKey, nil
+}
@@ -0,0 +1,28 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const refreshTokenBytes = 32
+
+// GenerateRefreshToken Generate a random opaque refresh token and the hash to store
+func GenerateRefreshToken() (token string, hash string, err error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken Refresh tokens are only stored as a sha256 hash
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
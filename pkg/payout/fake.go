@@ -0,0 +1,71 @@
+package payout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FakeProvider pays out without calling anything, it is meant for development and tests.
+//
+// The first FailCount attempts of every idempotency key fail with a retryable error, and
+// keys listed in Reject are always rejected. A key that was paid once returns the same result
+type FakeProvider struct {
+	FailCount int
+	Reject    map[string]bool
+
+	mu       sync.Mutex
+	attempts map[string]int
+	paid     map[string]*Result
+	requests []Request
+}
+
+func NewFakeProvider(failCount int) *FakeProvider {
+	return &FakeProvider{
+		FailCount: failCount,
+		Reject:    map[string]bool{},
+		attempts:  map[string]int{},
+		paid:      map[string]*Result{},
+	}
+}
+
+func (f *FakeProvider) Send(ctx context.Context, req Request) (*Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if res, ok := f.paid[req.IdempotencyKey]; ok {
+		return res, nil
+	}
+
+	f.attempts[req.IdempotencyKey]++
+	if f.Reject[req.IdempotencyKey] {
+		return nil, fmt.Errorf("%w: %s", ErrRejected, req.IdempotencyKey)
+	}
+
+	if f.attempts[req.IdempotencyKey] <= f.FailCount {
+		return nil, errors.New("payout: fake provider is unavailable")
+	}
+
+	res := &Result{Reference: fmt.Sprintf("fake-%s", req.IdempotencyKey)}
+	f.paid[req.IdempotencyKey] = res
+	f.requests = append(f.requests, req)
+
+	return res, nil
+}
+
+// Paid returns every payout that was accepted, once per idempotency key
+func (f *FakeProvider) Paid() []Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Request(nil), f.requests...)
+}
+
+// Attempts returns how many times the idempotency key was sent until it was paid, replays of a paid key are not counted
+func (f *FakeProvider) Attempts(idempotencyKey string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.attempts[idempotencyKey]
+}
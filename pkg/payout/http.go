@@ -0,0 +1,98 @@
+package payout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultHTTPTimeout = 10 * time.Second
+
+type HTTPConfig struct {
+	Endpoint string
+	APIKey   string
+	Timeout  time.Duration
+}
+
+type sendRequest struct {
+	UserID   int64  `json:"user_id"`
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+}
+
+type sendResponse struct {
+	Reference string `json:"reference"`
+}
+
+// httpProvider posts payouts as JSON to the wallet endpoint, the idempotency key
+// is sent in the Idempotency-Key header
+type httpProvider struct {
+	config     HTTPConfig
+	httpClient *http.Client
+}
+
+func NewHTTPProvider(config HTTPConfig) (Provider, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("payout: endpoint is required")
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	return &httpProvider{
+		config:     config,
+		httpClient: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (p *httpProvider) Send(ctx context.Context, req Request) (*Result, error) {
+	body, err := json.Marshal(sendRequest{
+		UserID:   req.UserID,
+		Currency: req.Currency,
+		Amount:   req.Amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Idempotency-Key", req.IdempotencyKey)
+	if p.config.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("payout: send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		// Timeouts, rate limits and server errors are worth another attempt
+		if resp.StatusCode >= http.StatusInternalServerError ||
+			resp.StatusCode == http.StatusRequestTimeout ||
+			resp.StatusCode == http.StatusTooManyRequests {
+			return nil, fmt.Errorf("payout: provider returned status %d", resp.StatusCode)
+		}
+
+		return nil, fmt.Errorf("%w: status %d", ErrRejected, resp.StatusCode)
+	}
+
+	var res sendResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("payout: decode response: %w", err)
+	}
+
+	return &Result{Reference: res.Reference}, nil
+}
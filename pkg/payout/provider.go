@@ -0,0 +1,28 @@
+package payout
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrRejected is returned when the provider refused the payout, retrying the same request won't help
+var ErrRejected = errors.New("payout: rejected by provider")
+
+// Request is a single payout to a player
+type Request struct {
+	// IdempotencyKey stays the same on every attempt, the provider pays a key at most once
+	IdempotencyKey string
+	UserID         int64
+	Currency       string
+	Amount         int64
+}
+
+// Result is what the provider returns for an accepted payout
+type Result struct {
+	Reference string
+}
+
+// Provider sends payouts to an external wallet
+type Provider interface {
+	Send(ctx context.Context, req Request) (*Result, error)
+}